@@ -3,8 +3,10 @@ package api
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,24 +17,68 @@ import (
 type Client struct {
 	BaseURL    string
 	Token      string
+	UserAgent  string
 	HTTPClient *http.Client
+	// Cache, when set, makes GETs conditional on a previously seen ETag.
+	Cache *ETagCache
+	// Log, when set, receives one line per request with status, size and
+	// gzip savings (used for --verbose).
+	Log io.Writer
+	// Timings, when set, counts requests and their latency (used for --timings).
+	Timings *Timings
+	// Headers are added to every request (e.g. API gateway headers). They
+	// never replace Authorization.
+	Headers map[string]string
+	// TokenSource, when set, supplies the bearer token instead of Token. A
+	// 401 makes it fetch a fresh token and the request is retried once.
+	TokenSource TokenSource
 }
 
-// New creates an API client.
+// DefaultUserAgent identifies CLI traffic when no version is known.
+const DefaultUserAgent = "reposwarm-cli"
+
+// New creates an API client. Proxy settings are taken from the environment
+// (HTTP_PROXY/HTTPS_PROXY/NO_PROXY); use NewTransport to override them.
 func New(baseURL, token string) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
 	return &Client{
-		BaseURL: baseURL,
-		Token:   token,
+		BaseURL:   baseURL,
+		Token:     token,
+		UserAgent: DefaultUserAgent,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
 	}
 }
 
 // apiResponse wraps all API responses.
 type apiResponse struct {
-	Data  json.RawMessage `json:"data"`
-	Error string          `json:"error"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+	Success *bool           `json:"success"`
+	Message string          `json:"message"`
+	Hint    string          `json:"hint"`
+}
+
+// hasData reports whether the envelope carried a non-null data field.
+func (r *apiResponse) hasData() bool {
+	return len(r.Data) > 0 && string(r.Data) != "null"
+}
+
+// ResponseError is a failure reported in the body of a 2xx response, either
+// as {success: false, ...} or as a bare {error: ...} with no data. The body
+// is still decoded into the caller's result, so endpoint-specific fields
+// (e.g. a hint) remain available.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+	Hint       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
 }
 
 // Get performs a GET request and unmarshals the response data.
@@ -60,9 +106,39 @@ func (c *Client) Delete(ctx context.Context, path string, result any) error {
 	return c.do(ctx, http.MethodDelete, path, nil, result)
 }
 
+// Request performs a request with any method, for callers that don't know
+// the endpoint ahead of time. Pass a *RawBody as result to get the response
+// body exactly as the server sent it.
+func (c *Client) Request(ctx context.Context, method, path string, body any, result any) error {
+	return c.do(ctx, method, path, body, result)
+}
+
+// RawBody receives a successful response body as-is (after decompression),
+// without unwrapping {data: ...} or checking it for body-level failures.
+type RawBody []byte
+
 func (c *Client) do(ctx context.Context, method, path string, body any, result any) error {
+	err := c.doOnce(ctx, method, path, body, result, false)
+	if c.TokenSource != nil && errors.Is(err, ErrUnauthorized) {
+		c.logf("%s %s: token rejected, refreshing and retrying", method, path)
+		return c.doOnce(ctx, method, path, body, result, true)
+	}
+	return err
+}
+
+// doOnce sends a single request. refreshToken asks the TokenSource for a
+// new token first.
+func (c *Client) doOnce(ctx context.Context, method, path string, body any, result any, refreshToken bool) error {
 	url := c.BaseURL + path
 
+	token := c.Token
+	if c.TokenSource != nil {
+		var err error
+		if token, err = c.TokenSource.Token(ctx, refreshToken); err != nil {
+			return err
+		}
+	}
+
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -77,34 +153,94 @@ func (c *Client) do(ctx context.Context, method, path string, body any, result a
 		return fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	// Requested explicitly (which turns off the transport's transparent
+	// decompression) so the wire size is known and can be reported.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	var cached etagEntry
+	var haveCached bool
+	if c.Cache != nil && method == http.MethodGet {
+		if cached, haveCached = c.Cache.load(url); haveCached {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+	}
 
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("connection failed: %w", err)
+		if c.Timings != nil {
+			c.Timings.record(time.Since(start))
+		}
+		return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
+	if c.Timings != nil {
+		c.Timings.record(time.Since(start))
+	}
 	if err != nil {
 		return fmt.Errorf("reading response: %w", err)
 	}
-
-	if resp.StatusCode == 401 {
-		return fmt.Errorf("authentication failed (401): run 'reposwarm config init' to update your token")
+	wireSize := len(respBody)
+	// 304s and HEADs may carry the header without a body
+	gzipped := resp.Header.Get("Content-Encoding") == "gzip" && len(respBody) > 0
+	if gzipped {
+		if respBody, err = gunzip(respBody); err != nil {
+			return fmt.Errorf("decompressing response: %w", err)
+		}
 	}
-	if resp.StatusCode == 404 {
-		return fmt.Errorf("not found (404): %s", path)
+	c.logf("%s %s %d %s", method, path, resp.StatusCode, sizeSummary(wireSize, len(respBody), gzipped))
+
+	// 304 Not Modified: the cached body is still current
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		respBody = cached.Body
 	}
+
 	if resp.StatusCode >= 400 {
+		msg := string(respBody)
 		var apiErr apiResponse
 		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error != "" {
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, apiErr.Error)
+			msg = apiErr.Error
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: msg, Path: path}
+	}
+
+	if raw, ok := result.(*RawBody); ok {
+		*raw = RawBody(respBody)
+		return nil
+	}
+
+	var wrapped apiResponse
+	isEnvelope := json.Unmarshal(respBody, &wrapped) == nil
+
+	// Some endpoints signal failure in the body of a 200
+	if isEnvelope && ((wrapped.Success != nil && !*wrapped.Success) || (wrapped.Error != "" && !wrapped.hasData())) {
+		if result != nil {
+			payload := respBody
+			if wrapped.hasData() {
+				payload = wrapped.Data
+			}
+			json.Unmarshal(payload, result)
+		}
+		msg := firstNonEmpty(wrapped.Error, wrapped.Message, "request failed")
+		return &ResponseError{StatusCode: resp.StatusCode, Message: msg, Hint: wrapped.Hint}
+	}
+
+	if c.Cache != nil && method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.Cache.store(url, etag, respBody)
 		}
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
 	}
 
 	if result == nil {
@@ -112,8 +248,7 @@ func (c *Client) do(ctx context.Context, method, path string, body any, result a
 	}
 
 	// Try unwrapping { data: ... }
-	var wrapped apiResponse
-	if err := json.Unmarshal(respBody, &wrapped); err == nil && wrapped.Data != nil {
+	if isEnvelope && wrapped.Data != nil {
 		return json.Unmarshal(wrapped.Data, result)
 	}
 
@@ -121,6 +256,39 @@ func (c *Client) do(ctx context.Context, method, path string, body any, result a
 	return json.Unmarshal(respBody, result)
 }
 
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// sizeSummary describes a response size, with savings when it was compressed.
+func sizeSummary(wire, decoded int, gzipped bool) string {
+	if !gzipped || decoded == 0 {
+		return fmt.Sprintf("%d bytes", decoded)
+	}
+	saved := 100 - wire*100/decoded
+	return fmt.Sprintf("%d bytes (gzip %d bytes, %d%% saved)", decoded, wire, saved)
+}
+
+func (c *Client) logf(format string, args ...any) {
+	if c.Log != nil {
+		fmt.Fprintf(c.Log, "[verbose] "+format+"\n", args...)
+	}
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // Health checks the API connection.
 func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 	var h HealthResponse
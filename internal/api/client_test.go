@@ -1,11 +1,18 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetUnwrapsData(t *testing.T) {
@@ -121,3 +128,351 @@ func TestConnectionError(t *testing.T) {
 		t.Fatal("expected connection error")
 	}
 }
+
+func TestUserAgentHeader(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{}})
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "token")
+	client.UserAgent = "reposwarm-cli/1.2.3"
+	if err := client.Get(context.Background(), "/health", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != "reposwarm-cli/1.2.3" {
+		t.Errorf("User-Agent = %q, want reposwarm-cli/1.2.3", got)
+	}
+}
+
+func TestBodyFailureOn200(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string // empty = no error
+	}{
+		{"success false", `{"success":false,"error":"no CodeCommit access"}`, "no CodeCommit access"},
+		{"success false message", `{"success":false,"message":"workflow already running"}`, "workflow already running"},
+		{"error with null data", `{"data":null,"error":"table missing"}`, "table missing"},
+		{"bare error", `{"error":"boom"}`, "boom"},
+		{"wrapped data", `{"data":{"success":true,"added":2}}`, ""},
+		{"direct success", `{"success":true,"added":2}`, ""},
+		{"data with warning error", `{"data":{"added":1},"error":"partial"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var result struct {
+				Success bool   `json:"success"`
+				Added   int    `json:"added"`
+				Error   string `json:"error"`
+			}
+			err := New(server.URL, "token").Post(context.Background(), "/repos/discover", nil, &result)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var respErr *ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("expected *ResponseError, got %v", err)
+			}
+			if respErr.Message != tt.wantErr || respErr.StatusCode != 200 {
+				t.Errorf("got %+v, want message %q", respErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestErrorTypes(t *testing.T) {
+	for _, tt := range []struct {
+		status int
+		is     error
+		msg    string
+	}{
+		{401, ErrUnauthorized, "authentication failed (401)"},
+		{404, ErrNotFound, "not found (404): /repos/x"},
+		{500, nil, "API error (500): db down"},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			json.NewEncoder(w).Encode(map[string]string{"error": "db down"})
+		}))
+		err := New(server.URL, "token").Get(context.Background(), "/repos/x", nil)
+		server.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Fatalf("%d: expected *APIError, got %v", tt.status, err)
+		}
+		if tt.is != nil && !errors.Is(err, tt.is) {
+			t.Errorf("%d: errors.Is(%v) = false", tt.status, tt.is)
+		}
+		if errors.Is(err, ErrConnectionFailed) {
+			t.Errorf("%d: should not match ErrConnectionFailed", tt.status)
+		}
+		if !strings.HasPrefix(err.Error(), tt.msg) {
+			t.Errorf("%d: message %q, want prefix %q", tt.status, err.Error(), tt.msg)
+		}
+	}
+
+	err := New("http://127.0.0.1:1", "token").Get(context.Background(), "/health", nil)
+	if !errors.Is(err, ErrConnectionFailed) || !strings.HasPrefix(err.Error(), "connection failed: ") {
+		t.Errorf("expected connection failure, got %v", err)
+	}
+}
+
+func TestETagCacheConditionalGet(t *testing.T) {
+	var hits, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"content": "cached"}})
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-token")
+	client.Cache = NewETagCache(t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		var got map[string]string
+		if err := client.Get(context.Background(), "/wiki/repo/section", &got); err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+		if got["content"] != "cached" {
+			t.Errorf("Get #%d content = %q, want cached", i+1, got["content"])
+		}
+	}
+	if hits != 2 || notModified != 1 {
+		t.Errorf("hits = %d, notModified = %d; want 2 and 1", hits, notModified)
+	}
+}
+
+func TestETagCacheNoETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("sent If-None-Match without a stored ETag")
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": "ok"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := New(server.URL, "test-token")
+	client.Cache = NewETagCache(dir)
+	for i := 0; i < 2; i++ {
+		var got string
+		if err := client.Get(context.Background(), "/health", &got); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("cached %d entries without an ETag", len(entries))
+	}
+}
+
+func TestETagCacheGzipNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		gz := gzip.NewWriter(w)
+		json.NewEncoder(gz).Encode(map[string]any{"data": "cached"})
+		gz.Close()
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-token")
+	client.Cache = NewETagCache(t.TempDir())
+	for i := 0; i < 2; i++ {
+		var got string
+		if err := client.Get(context.Background(), "/wiki/repo", &got); err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+		if got != "cached" {
+			t.Errorf("Get #%d = %q, want cached", i+1, got)
+		}
+	}
+}
+
+func TestETagCacheBounds(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewETagCache(dir)
+	cache.MaxEntries = 2
+
+	old := time.Now().Add(-2 * cache.MaxAge)
+	cache.store("/stale", `"s"`, []byte(`"stale"`))
+	os.Chtimes(cache.file("/stale"), old, old)
+	if _, ok := cache.load("/stale"); ok {
+		t.Error("loaded an expired entry")
+	}
+	if _, err := os.Stat(cache.file("/stale")); !os.IsNotExist(err) {
+		t.Error("expired entry was not deleted")
+	}
+
+	// A fresh cache prunes on its first store, down to MaxEntries
+	for i, url := range []string{"/a", "/b", "/c"} {
+		cache.store(url, `"v"`, []byte(`"x"`))
+		at := time.Now().Add(time.Duration(i-3) * time.Minute)
+		os.Chtimes(cache.file(url), at, at)
+	}
+	cache = NewETagCache(dir)
+	cache.MaxEntries = 2
+	cache.store("/d", `"v"`, []byte(`"x"`))
+	for url, want := range map[string]bool{"/a": false, "/b": false, "/c": true, "/d": true} {
+		if _, ok := cache.load(url); ok != want {
+			t.Errorf("load(%s) ok = %v, want %v", url, ok, want)
+		}
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	payload := `{"data":{"content":"` + strings.Repeat("markdown ", 200) + `"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(payload))
+		zw.Close()
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := New(server.URL, "test-token")
+	client.Log = &log
+	var got map[string]string
+	if err := client.Get(context.Background(), "/wiki/repo/section", &got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !strings.HasPrefix(got["content"], "markdown markdown") {
+		t.Errorf("content not decompressed: %q", got["content"])
+	}
+	if !strings.Contains(log.String(), "% saved") {
+		t.Errorf("verbose log should report savings, got %q", log.String())
+	}
+}
+
+func TestTimingsCountsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-token")
+	client.Timings = &Timings{}
+	for i := 0; i < 3; i++ {
+		client.Get(context.Background(), "/wiki", nil)
+	}
+	client.Get(context.Background(), "/missing", nil)
+
+	if got := client.Timings.Requests(); got != 4 {
+		t.Errorf("Requests() = %d, want 4 (failed requests count too)", got)
+	}
+	if client.Timings.Network() <= 0 {
+		t.Error("Network() should accumulate request time")
+	}
+	if s := client.Timings.Summary(time.Second); !strings.HasPrefix(s, "4 requests, 1s total, ") {
+		t.Errorf("Summary = %q", s)
+	}
+}
+
+// countingTokenSource hands out "stale" first and "fresh" once refreshed.
+type countingTokenSource struct {
+	refreshes int
+}
+
+func (s *countingTokenSource) Token(ctx context.Context, refresh bool) (string, error) {
+	if refresh {
+		s.refreshes++
+	}
+	if s.refreshes > 0 {
+		return "fresh", nil
+	}
+	return "stale", nil
+}
+
+func TestTokenSourceRefreshOn401(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer server.Close()
+
+	src := &countingTokenSource{}
+	client := New(server.URL, "")
+	client.TokenSource = src
+	var got map[string]bool
+	if err := client.Get(context.Background(), "/repos", &got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got["ok"] || src.refreshes != 1 || strings.Join(seen, ",") != "Bearer stale,Bearer fresh" {
+		t.Errorf("got %v, refreshes %d, requests %v", got, src.refreshes, seen)
+	}
+
+	// A token that stays invalid is retried only once
+	seen = nil
+	client.TokenSource = staticTokenSource("bad")
+	if err := client.Get(context.Background(), "/repos", nil); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("err = %v, want ErrUnauthorized", err)
+	}
+	if len(seen) != 2 {
+		t.Errorf("requests = %d, want 2", len(seen))
+	}
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context, bool) (string, error) { return string(s), nil }
+
+func TestCommandTokenSource(t *testing.T) {
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "n")
+	src := NewCommandTokenSource("echo x >> " + counter + "; echo '  tok-123  '")
+
+	for i := 0; i < 2; i++ {
+		tok, err := src.Token(context.Background(), false)
+		if err != nil || tok != "tok-123" {
+			t.Fatalf("Token() = %q, %v", tok, err)
+		}
+	}
+	if _, err := src.Token(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(counter)
+	if runs := strings.Count(string(data), "x"); runs != 2 {
+		t.Errorf("command ran %d times, want 2 (cached once, then refreshed)", runs)
+	}
+
+	if _, err := NewCommandTokenSource("true").Token(context.Background(), false); err == nil {
+		t.Error("expected error for empty output")
+	}
+	if _, err := NewCommandTokenSource("echo nope >&2; exit 3").Token(context.Background(), false); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("err = %v, want stderr in message", err)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for errors.Is checks on client results.
+var (
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrNotFound         = errors.New("not found")
+	ErrConnectionFailed = errors.New("connection failed")
+)
+
+// APIError is a non-2xx response from the API.
+// errors.Is matches ErrUnauthorized for 401 and ErrNotFound for 404.
+type APIError struct {
+	StatusCode int
+	Message    string // server-provided error, or the raw body
+	Path       string
+}
+
+func (e *APIError) Error() string {
+	switch e.StatusCode {
+	case 401:
+		return "authentication failed (401): run 'reposwarm config init' to update your token"
+	case 404:
+		return fmt.Sprintf("not found (404): %s", e.Path)
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the status code corresponds to target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == 401
+	case ErrNotFound:
+		return e.StatusCode == 404
+	}
+	return false
+}
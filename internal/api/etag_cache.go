@@ -0,0 +1,121 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default bounds of an ETagCache.
+const (
+	DefaultETagCacheMaxAge     = 7 * 24 * time.Hour
+	DefaultETagCacheMaxEntries = 1000
+)
+
+// ETagCache stores GET response bodies on disk together with their ETag so
+// repeat requests can be made conditional (If-None-Match). Servers that don't
+// send ETags are unaffected: nothing is stored and requests go out as usual.
+//
+// Entries older than MaxAge are ignored and deleted, and the first store of
+// a run trims the directory to the MaxEntries most recent entries. Zero
+// disables either bound.
+type ETagCache struct {
+	Dir        string
+	MaxAge     time.Duration
+	MaxEntries int
+
+	pruneOnce sync.Once
+}
+
+// NewETagCache returns a cache rooted at dir with the default bounds. The
+// directory is created lazily.
+func NewETagCache(dir string) *ETagCache {
+	return &ETagCache{Dir: dir, MaxAge: DefaultETagCacheMaxAge, MaxEntries: DefaultETagCacheMaxEntries}
+}
+
+// etagEntry is the on-disk form of a cached response.
+type etagEntry struct {
+	URL  string          `json:"url"`
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body"`
+}
+
+func (c *ETagCache) file(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the cached entry for url, if any.
+func (c *ETagCache) load(url string) (etagEntry, bool) {
+	path := c.file(url)
+	if info, err := os.Stat(path); err != nil {
+		return etagEntry{}, false
+	} else if c.expired(info.ModTime()) {
+		os.Remove(path)
+		return etagEntry{}, false
+	}
+	var e etagEntry
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &e) != nil || e.URL != url || e.ETag == "" {
+		return etagEntry{}, false
+	}
+	return e, true
+}
+
+func (c *ETagCache) expired(modified time.Time) bool {
+	return c.MaxAge > 0 && time.Since(modified) > c.MaxAge
+}
+
+// store saves body under url. Failures are ignored; the cache is best-effort.
+func (c *ETagCache) store(url, etag string, body []byte) {
+	if !json.Valid(body) {
+		return
+	}
+	data, err := json.Marshal(etagEntry{URL: url, ETag: etag, Body: body})
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(c.Dir, 0700) != nil {
+		return
+	}
+	c.pruneOnce.Do(c.prune)
+	os.WriteFile(c.file(url), data, 0600)
+}
+
+// prune deletes expired entries and then the oldest ones beyond MaxEntries.
+func (c *ETagCache) prune() {
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return
+	}
+	type cached struct {
+		path     string
+		modified time.Time
+	}
+	var live []cached
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(c.Dir, e.Name())
+		if c.expired(info.ModTime()) {
+			os.Remove(path)
+			continue
+		}
+		live = append(live, cached{path, info.ModTime()})
+	}
+	if c.MaxEntries <= 0 || len(live) < c.MaxEntries {
+		return
+	}
+	// Keep room for the entry about to be written
+	sort.Slice(live, func(i, j int) bool { return live[i].modified.After(live[j].modified) })
+	for _, e := range live[c.MaxEntries-1:] {
+		os.Remove(e.path)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Timings counts requests and accumulates the time spent on them. It is safe
+// for concurrent use, so clients shared by worker pools report correctly.
+type Timings struct {
+	mu       sync.Mutex
+	requests int
+	network  time.Duration
+}
+
+// record adds one request that took d, including reading its body.
+func (t *Timings) record(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests++
+	t.network += d
+}
+
+// Requests returns the number of requests made so far.
+func (t *Timings) Requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.requests
+}
+
+// Network returns the summed request time. With concurrent requests it can
+// exceed the wall-clock time of the command.
+func (t *Timings) Network() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.network
+}
+
+// Summary formats the counters against the command's total run time, e.g.
+// "42 requests, 3.1s total, 2.8s network, 0.3s processing".
+func (t *Timings) Summary(total time.Duration) string {
+	requests, network := t.Requests(), t.Network()
+	processing := total - network
+	if processing < 0 {
+		processing = 0
+	}
+	return fmt.Sprintf("%d requests, %s total, %s network, %s processing",
+		requests, roundDuration(total), roundDuration(network), roundDuration(processing))
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(100 * time.Millisecond)
+}
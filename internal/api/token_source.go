@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// TokenSource supplies the bearer token for requests. refresh is set when
+// the server rejected the previous token (401), so a new one must be fetched
+// rather than a cached one returned.
+type TokenSource interface {
+	Token(ctx context.Context, refresh bool) (string, error)
+}
+
+// CommandTokenSource runs a shell command and uses its trimmed stdout as the
+// token, e.g. a vault or SSO CLI. The token is cached until refreshed.
+type CommandTokenSource struct {
+	Command string
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewCommandTokenSource returns a token source backed by command.
+func NewCommandTokenSource(command string) *CommandTokenSource {
+	return &CommandTokenSource{Command: command}
+}
+
+// Token returns the cached token, running the command when there is none
+// yet or refresh is set.
+func (s *CommandTokenSource) Token(ctx context.Context, refresh bool) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && !refresh {
+		return s.token, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.Command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("tokenCommand failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("tokenCommand failed: %w", err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("tokenCommand printed no token")
+	}
+	s.token = token
+	return token, nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransportTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"status": "healthy"}})
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	os.WriteFile(caFile, caPEM, 0600)
+
+	tests := []struct {
+		name    string
+		opts    TransportOptions
+		wantErr bool
+	}{
+		{"default rejects self-signed", TransportOptions{}, true},
+		{"insecure skips verification", TransportOptions{InsecureSkipVerify: true}, false},
+		{"custom CA trusts server", TransportOptions{CACertFile: caFile}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransport(tt.opts)
+			if err != nil {
+				t.Fatalf("NewTransport: %v", err)
+			}
+			client := New(server.URL, "token")
+			client.HTTPClient.Transport = transport
+			_, err = client.Health(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Health() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTransportBadCAFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.pem")
+	os.WriteFile(path, []byte("not a cert"), 0600)
+
+	if _, err := NewTransport(TransportOptions{CACertFile: path}); err == nil {
+		t.Error("expected error for file without PEM certificates")
+	}
+	if _, err := NewTransport(TransportOptions{CACertFile: path + ".missing"}); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxy     string
+		target    string
+		wantProxy string
+		wantErr   bool
+	}{
+		{"explicit proxy", "http://proxy.corp:8080", "https://api.example.com/v1", "http://proxy.corp:8080", false},
+		{"socks proxy", "socks5://127.0.0.1:1080", "https://api.example.com/v1", "socks5://127.0.0.1:1080", false},
+		{"loopback bypasses proxy", "http://proxy.corp:8080", "http://localhost:3000/v1", "", false},
+		{"loopback IP bypasses proxy", "http://proxy.corp:8080", "http://127.0.0.1:3000/v1", "", false},
+		{"unsupported scheme", "ftp://proxy.corp", "", "", true},
+		{"missing host", "http://", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := ProxyFunc(tt.proxy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProxyFunc(%q) error = %v, wantErr %v", tt.proxy, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, tt.target, nil)
+			got, err := fn(req)
+			if err != nil {
+				t.Fatalf("proxy func: %v", err)
+			}
+			gotStr := ""
+			if got != nil {
+				gotStr = got.String()
+			}
+			if gotStr != tt.wantProxy {
+				t.Errorf("proxy for %s = %q, want %q", tt.target, gotStr, tt.wantProxy)
+			}
+		})
+	}
+}
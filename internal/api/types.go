@@ -1,5 +1,10 @@
 package api
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
 // HealthResponse from GET /health.
 type HealthResponse struct {
 	Status   string `json:"status"`
@@ -29,7 +34,8 @@ type Repository struct {
 	HasDocs     bool   `json:"hasDocs"`
 }
 
-// DiscoverResult from POST /repos/discover.
+// DiscoverResult from POST /repos/discover. Servers that can preview
+// discovery also return it (without adding anything) from GET /repos/discover.
 type DiscoverResult struct {
 	Success      bool     `json:"success"`
 	Discovered   int      `json:"discovered"`
@@ -37,6 +43,7 @@ type DiscoverResult struct {
 	Skipped      int      `json:"skipped"`
 	Total        int      `json:"total"`
 	Repositories []string `json:"repositories"`
+	Error        string   `json:"error,omitempty"`
 }
 
 // WorkflowExecution from GET /workflows.
@@ -48,6 +55,7 @@ type WorkflowExecution struct {
 	StartTime  string `json:"startTime"`
 	CloseTime  string `json:"closeTime,omitempty"`
 	TaskQueue  string `json:"taskQueue,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
 }
 
 // WorkflowsResponse from GET /workflows.
@@ -87,6 +95,9 @@ type WikiRepoSummary struct {
 // WikiReposResponse from GET /wiki.
 type WikiReposResponse struct {
 	Repos []WikiRepoSummary `json:"repos"`
+	// NextPageToken is set when the server paginates and more repos remain;
+	// pass it back as ?pageToken= to read the next page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 // WikiSection from GET /wiki/:repo.
@@ -123,6 +134,13 @@ type WikiContent struct {
 	ReferenceKey string `json:"referenceKey"`
 }
 
+// ContentHash returns the hex SHA-256 of the section content, computed
+// locally so sections can be compared across runs and repos.
+func (c WikiContent) ContentHash() string {
+	sum := sha256.Sum256([]byte(c.Content))
+	return hex.EncodeToString(sum[:])
+}
+
 // ConfigResponse from GET /config.
 type ConfigResponse struct {
 	DefaultModel       string `json:"defaultModel"`
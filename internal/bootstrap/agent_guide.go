@@ -19,6 +19,7 @@ func GenerateAgentGuide(env *Environment, installDir string, cfg *Config) string
 	sb.WriteString(fmt.Sprintf("OS:              %s/%s\n", env.OS, env.Arch))
 	sb.WriteString(fmt.Sprintf("Install dir:     %s\n", installDir))
 	sb.WriteString(fmt.Sprintf("Docker:          %v (%s)\n", env.HasDocker, env.DockerVer))
+	sb.WriteString(fmt.Sprintf("Docker daemon:   %v\n", env.DockerRunning))
 	sb.WriteString(fmt.Sprintf("Docker Compose:  %v (%s)\n", env.HasCompose, env.ComposeVer))
 	sb.WriteString(fmt.Sprintf("Node.js:         %v (%s)\n", env.HasNode, env.NodeVer))
 	sb.WriteString(fmt.Sprintf("Python:          %v (%s)\n", env.HasPython, env.PythonVer))
@@ -33,7 +34,11 @@ func GenerateAgentGuide(env *Environment, installDir string, cfg *Config) string
 		sb.WriteString("## Step 0: Install Missing Dependencies\n\n")
 		sb.WriteString("These are REQUIRED before proceeding:\n\n")
 		for _, dep := range missing {
-			sb.WriteString(fmt.Sprintf("- [ ] Install %s\n", dep))
+			verb := "Install"
+			if strings.HasPrefix(dep, "docker daemon") {
+				verb = "Start"
+			}
+			sb.WriteString(fmt.Sprintf("- [ ] %s %s\n", verb, dep))
 		}
 		sb.WriteString("\n")
 		sb.WriteString(installInstructions(env, missing))
@@ -76,6 +81,9 @@ func GenerateAgentGuide(env *Environment, installDir string, cfg *Config) string
 	sb.WriteString("TEMPORAL_NAMESPACE=default\n")
 	sb.WriteString("TEMPORAL_TASK_QUEUE=investigate-task-queue\n")
 	sb.WriteString(fmt.Sprintf("AWS_REGION=%s\n", env.AWSRegion))
+	if env.AWSProfile != "" {
+		sb.WriteString(fmt.Sprintf("AWS_PROFILE=%s\n", env.AWSProfile))
+	}
 	sb.WriteString(fmt.Sprintf("DYNAMODB_TABLE=%s\n", cfg.DynamoDBTable))
 	sb.WriteString(fmt.Sprintf("DEFAULT_MODEL=%s\n", cfg.DefaultModel))
 	sb.WriteString("```\n\n")
@@ -2,14 +2,28 @@
 package bootstrap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Minimum runtime versions needed to build the services from source.
+const (
+	MinNodeVersion   = "22"
+	MinPythonVersion = "3.11"
+)
+
+// MinGoVersion is the Go version needed to build the CLI itself (optional).
+const MinGoVersion = "1.24"
+
 // Environment holds detected local environment info.
 type Environment struct {
 	OS           string `json:"os"`
@@ -44,6 +58,10 @@ type Environment struct {
 	HasAWSCLI    bool   `json:"hasAwsCli"`
 	AWSRegion    string `json:"awsRegion,omitempty"`
 	AWSProfile   string `json:"awsProfile,omitempty"`
+	// AWSCredentials reports whether `aws sts get-caller-identity` succeeded;
+	// nil until CheckAWSCredentials runs
+	AWSCredentials *bool  `json:"awsCredentials,omitempty"`
+	AWSAccount     string `json:"awsAccount,omitempty"`
 
 	// Package managers
 	HasBrew      bool   `json:"hasBrew"`
@@ -66,9 +84,7 @@ func Detect() *Environment {
 	// Runtimes
 	env.DockerVer, env.HasDocker = cmdVersion("docker", "--version")
 	if env.HasDocker {
-		// Check if Docker daemon is actually running
-		pingCmd := exec.Command("docker", "info")
-		env.DockerRunning = pingCmd.Run() == nil
+		env.DockerRunning = CheckDockerDaemon() == nil
 	}
 	env.ComposeVer, env.HasCompose = cmdVersion("docker", "compose", "version")
 	env.NodeVer, env.HasNode = cmdVersion("node", "--version")
@@ -101,6 +117,29 @@ func Detect() *Environment {
 	return env
 }
 
+// UseAWSProfile switches the environment to profile (e.g. from
+// --aws-profile), dropping any credential check made with the old one.
+func (e *Environment) UseAWSProfile(profile string) {
+	if profile == "" || profile == e.AWSProfile {
+		return
+	}
+	e.AWSProfile = profile
+	e.AWSAccount, e.AWSCredentials = "", nil
+}
+
+// CheckAWSCredentials runs `aws sts get-caller-identity` with the
+// environment's profile and records the result. It's a network call that
+// can take up to 10s, so Detect leaves it to commands that report on
+// credentials. Without the AWS CLI it does nothing.
+func (e *Environment) CheckAWSCredentials() {
+	if !e.HasAWSCLI {
+		return
+	}
+	account, err := AWSCallerAccount(e.AWSProfile)
+	ok := err == nil
+	e.AWSAccount, e.AWSCredentials = account, &ok
+}
+
 // AgentName returns the best available coding agent name, or "".
 func (e *Environment) AgentName() string {
 	if e.HasClaudeCode {
@@ -119,13 +158,19 @@ func (e *Environment) AgentName() string {
 }
 
 // MissingDeps returns a list of missing required dependencies.
-// With Docker images, only Docker and Compose are required.
+// With Docker images, only Docker and Compose are required; runtimes that
+// are present but too old to build from source are listed too.
 func (e *Environment) MissingDeps() []string {
+	return append(e.missingTools(), e.OutdatedDeps()...)
+}
+
+// missingTools lists the tools a Docker-based install can't run without.
+func (e *Environment) missingTools() []string {
 	var missing []string
 	if !e.HasDocker {
 		missing = append(missing, "docker")
 	} else if !e.DockerRunning {
-		missing = append(missing, "docker (installed but not running — start Docker Desktop or the Docker daemon)")
+		missing = append(missing, "docker daemon (installed but not running — "+DockerDaemonHint(e.OS)+")")
 	}
 	if !e.HasCompose {
 		missing = append(missing, "docker-compose")
@@ -136,6 +181,146 @@ func (e *Environment) MissingDeps() []string {
 	return missing
 }
 
+// OutdatedDeps lists runtimes that are installed but older than the minimum
+// needed to build the services from source. Missing runtimes aren't listed:
+// Docker installs don't need them on the host.
+func (e *Environment) OutdatedDeps() []string {
+	var outdated []string
+	if e.HasNode && !e.NodeOK() {
+		outdated = append(outdated, fmt.Sprintf("node %s+ (found %s)", MinNodeVersion, e.NodeVer))
+	}
+	if e.HasPython && !e.PythonOK() {
+		outdated = append(outdated, fmt.Sprintf("python %s+ (found %s)", MinPythonVersion, e.PythonVer))
+	}
+	return outdated
+}
+
+// NodeOK reports whether the detected Node.js meets MinNodeVersion.
+// An unparseable version is given the benefit of the doubt.
+func (e *Environment) NodeOK() bool {
+	return e.HasNode && VersionAtLeast(e.NodeVer, MinNodeVersion)
+}
+
+// PythonOK reports whether the detected Python meets MinPythonVersion.
+func (e *Environment) PythonOK() bool {
+	return e.HasPython && VersionAtLeast(e.PythonVer, MinPythonVersion)
+}
+
+var versionRe = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+// VersionAtLeast compares the first major[.minor] found in ver (e.g.
+// "v22.3.0", "Python 3.12.1") against min. It returns true when ver
+// can't be parsed.
+func VersionAtLeast(ver, min string) bool {
+	have := versionRe.FindStringSubmatch(ver)
+	want := versionRe.FindStringSubmatch(min)
+	if have == nil || want == nil {
+		return true
+	}
+	for i := 1; i <= 2; i++ {
+		h, _ := strconv.Atoi(have[i])
+		w, _ := strconv.Atoi(want[i])
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+// GoOK reports whether the detected Go meets MinGoVersion.
+func (e *Environment) GoOK() bool {
+	return e.HasGo && VersionAtLeast(strings.TrimPrefix(goVersionField(e.GoVer), "go"), MinGoVersion)
+}
+
+// goVersionField picks "go1.24.1" out of `go version` output.
+func goVersionField(out string) string {
+	for _, f := range strings.Fields(out) {
+		if strings.HasPrefix(f, "go1") {
+			return f
+		}
+	}
+	return out
+}
+
+// OptionalNotes describes optional dependencies: an outdated Go (only needed
+// for CLI development) and the AWS CLI's region, profile and, once checked,
+// whether its credentials resolve (only needed for CodeCommit discovery).
+// They are informational only and never include the account ID, since they
+// end up in generated guides.
+func (e *Environment) OptionalNotes() []string {
+	var notes []string
+	if e.HasGo && !e.GoOK() {
+		notes = append(notes, fmt.Sprintf("Go %s+ is needed for CLI development (found %s)", MinGoVersion, goVersionField(e.GoVer)))
+	}
+	if e.HasAWSCLI {
+		profile := firstNonEmpty(e.AWSProfile, "default")
+		switch {
+		case e.AWSCredentials == nil:
+			notes = append(notes, fmt.Sprintf("AWS CLI: region %s, profile %s", e.AWSRegion, profile))
+		case *e.AWSCredentials:
+			notes = append(notes, fmt.Sprintf("AWS CLI: region %s, profile %s, credentials OK", e.AWSRegion, profile))
+		default:
+			notes = append(notes, fmt.Sprintf("AWS CLI: region %s, profile %s, credentials don't resolve (aws sts get-caller-identity failed)", e.AWSRegion, profile))
+		}
+	}
+	return notes
+}
+
+// AWSCallerAccount runs `aws sts get-caller-identity` (with --profile when
+// profile isn't "") and returns the account ID. The error carries the first
+// line of the AWS CLI's message.
+func AWSCallerAccount(profile string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	args := []string{"sts", "get-caller-identity", "--query", "Account", "--output", "text"}
+	if profile != "" {
+		args = append(args, "--profile", profile)
+	}
+	out, err := exec.CommandContext(ctx, "aws", args...).Output()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("aws sts get-caller-identity did not respond within 10s")
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if line, _, _ := strings.Cut(strings.TrimSpace(string(exitErr.Stderr)), "\n"); line != "" {
+				return "", errors.New(line)
+			}
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CheckDockerDaemon reports whether the Docker daemon answers `docker info`.
+// The returned error carries the first line of docker's own message.
+func CheckDockerDaemon() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "docker", "info").CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("docker daemon did not respond within 15s")
+	}
+	for _, l := range strings.Split(string(out), "\n") {
+		l = strings.TrimSpace(l)
+		if strings.Contains(strings.ToLower(l), "daemon") || strings.HasPrefix(l, "ERROR") {
+			return fmt.Errorf("docker daemon not running: %s", l)
+		}
+	}
+	return fmt.Errorf("docker daemon not running: %w", err)
+}
+
+// DockerDaemonHint tells the user how to start the Docker daemon on goos.
+func DockerDaemonHint(goos string) string {
+	if goos == "darwin" {
+		return "start Docker Desktop (open -a Docker)"
+	}
+	return "start it with: sudo systemctl start docker"
+}
+
 // InstallDir returns the target installation directory.
 func (e *Environment) InstallDir() string {
 	home, err := os.UserHomeDir()
@@ -191,7 +376,11 @@ func (e *Environment) Summary() string {
 			sb.WriteString(fmt.Sprintf("    ❌ %s — not found\n", name))
 		}
 	}
-	rt("Docker", e.HasDocker, e.DockerVer)
+	if e.HasDocker && !e.DockerRunning {
+		sb.WriteString(fmt.Sprintf("    ⚠️  Docker — %s (daemon not running)\n", e.DockerVer))
+	} else {
+		rt("Docker", e.HasDocker, e.DockerVer)
+	}
 	rt("Docker Compose", e.HasCompose, e.ComposeVer)
 	rt("Node.js", e.HasNode, e.NodeVer)
 	rt("Python", e.HasPython, e.PythonVer)
@@ -217,5 +406,12 @@ func (e *Environment) Summary() string {
 		sb.WriteString("    (none detected)\n")
 	}
 
+	if notes := e.OptionalNotes(); len(notes) > 0 {
+		sb.WriteString("\n  Optional:\n")
+		for _, n := range notes {
+			sb.WriteString(fmt.Sprintf("    ℹ️  %s\n", n))
+		}
+	}
+
 	return sb.String()
 }
@@ -95,6 +95,66 @@ func TestMissingDepsAllPresent(t *testing.T) {
 	}
 }
 
+func TestMissingDepsDaemonNotRunning(t *testing.T) {
+	for _, tc := range []struct {
+		os, hint string
+	}{
+		{"darwin", "Docker Desktop"},
+		{"linux", "systemctl start docker"},
+	} {
+		env := &Environment{OS: tc.os, HasDocker: true, HasCompose: true, HasGit: true}
+		missing := env.MissingDeps()
+		if len(missing) != 1 {
+			t.Fatalf("%s: expected 1 missing dep, got %v", tc.os, missing)
+		}
+		if !strings.HasPrefix(missing[0], "docker daemon") || !strings.Contains(missing[0], tc.hint) {
+			t.Errorf("%s: got %q, want daemon entry mentioning %q", tc.os, missing[0], tc.hint)
+		}
+		if guide := installInstructions(env, missing); strings.Contains(guide, "get.docker.com") || strings.Contains(guide, "brew install") {
+			t.Errorf("%s: daemon entry should not suggest installing docker:\n%s", tc.os, guide)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		ver, min string
+		want     bool
+	}{
+		{"v22.3.0", MinNodeVersion, true},
+		{"v23.0.0", MinNodeVersion, true},
+		{"v16.20.2", MinNodeVersion, false},
+		{"Python 3.11.4", MinPythonVersion, true},
+		{"Python 3.12.1", MinPythonVersion, true},
+		{"Python 3.9.6", MinPythonVersion, false},
+		{"Python 2.7.18", MinPythonVersion, false},
+		{"unknown", MinNodeVersion, true},
+	}
+	for _, tt := range tests {
+		if got := VersionAtLeast(tt.ver, tt.min); got != tt.want {
+			t.Errorf("VersionAtLeast(%q, %q) = %v, want %v", tt.ver, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestMissingDepsOutdatedRuntimes(t *testing.T) {
+	env := &Environment{
+		HasDocker: true, DockerRunning: true, HasCompose: true, HasGit: true,
+		HasNode: true, NodeVer: "v16.20.2",
+		HasPython: true, PythonVer: "Python 3.12.1",
+	}
+	if env.NodeOK() || !env.PythonOK() {
+		t.Errorf("NodeOK=%v PythonOK=%v, want false/true", env.NodeOK(), env.PythonOK())
+	}
+	missing := env.MissingDeps()
+	if len(missing) != 1 || !strings.Contains(missing[0], "node 22+") || !strings.Contains(missing[0], "v16.20.2") {
+		t.Errorf("MissingDeps() = %v, want one outdated node entry", missing)
+	}
+	if tools := env.missingTools(); len(tools) != 0 {
+		t.Errorf("missingTools() = %v, outdated runtimes shouldn't block Docker setup", tools)
+	}
+}
+
 func TestAgentName(t *testing.T) {
 	tests := []struct {
 		env  Environment
@@ -191,3 +251,38 @@ func TestGenerateAgentGuideWithMissing(t *testing.T) {
 		t.Error("agent guide should have Step 0 for missing deps")
 	}
 }
+
+func TestOptionalNotes(t *testing.T) {
+	env := &Environment{HasGo: true, GoVer: "go version go1.22.5 linux/amd64"}
+	notes := env.OptionalNotes()
+	if len(notes) != 1 || !strings.Contains(notes[0], "go1.22.5") {
+		t.Errorf("old Go: notes = %v", notes)
+	}
+
+	env = &Environment{HasGo: true, GoVer: "go version go1.24.1 darwin/arm64", HasAWSCLI: true, AWSRegion: "eu-west-1"}
+	notes = env.OptionalNotes()
+	if len(notes) != 1 || !strings.Contains(notes[0], "eu-west-1") || !strings.Contains(notes[0], "profile default") || strings.Contains(notes[0], "credentials") {
+		t.Errorf("AWS unchecked: notes = %v", notes)
+	}
+
+	ok := true
+	env.AWSCredentials, env.AWSAccount = &ok, "123456789012"
+	notes = env.OptionalNotes()
+	if len(notes) != 1 || !strings.Contains(notes[0], "credentials OK") || strings.Contains(notes[0], "123456789012") {
+		t.Errorf("AWS checked: notes = %v", notes)
+	}
+}
+
+func TestUseAWSProfile(t *testing.T) {
+	ok := true
+	env := &Environment{AWSProfile: "host", AWSAccount: "111111111111", AWSCredentials: &ok}
+	env.UseAWSProfile("")
+	if env.AWSProfile != "host" || env.AWSAccount == "" {
+		t.Errorf("empty profile changed the environment: %+v", env)
+	}
+	// A check made with the host profile doesn't carry over
+	env.UseAWSProfile("other")
+	if env.AWSProfile != "other" || env.AWSAccount != "" || env.AWSCredentials != nil {
+		t.Errorf("host credentials kept for another profile: %+v", env)
+	}
+}
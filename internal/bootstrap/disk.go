@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// MinFreeDiskBytes is the free space a local install needs for Docker images,
+// cloned sources and build artifacts.
+const MinFreeDiskBytes uint64 = 5 << 30
+
+// existingAncestor returns dir or its nearest existing parent, so checks can
+// run before the install directory is created.
+func existingAncestor(dir string) string {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// AvailableDiskBytes returns the bytes available to unprivileged users on the
+// filesystem holding dir.
+func AvailableDiskBytes(dir string) (uint64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(existingAncestor(dir), &st); err != nil {
+		return 0, fmt.Errorf("checking disk space for %s: %w", dir, err)
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
+
+// CheckDiskSpace returns an error when the filesystem holding dir has less
+// than required bytes available.
+func CheckDiskSpace(dir string, required uint64) error {
+	avail, err := AvailableDiskBytes(dir)
+	if err != nil {
+		return err
+	}
+	if avail < required {
+		return fmt.Errorf("insufficient disk space in %s: %s available, %s required", dir, FormatBytes(avail), FormatBytes(required))
+	}
+	return nil
+}
+
+// CheckWritable verifies dir (or its nearest existing parent) accepts new files.
+func CheckWritable(dir string) error {
+	target := existingAncestor(dir)
+	f, err := os.CreateTemp(target, ".reposwarm-write-test-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", target, err)
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return nil
+}
+
+// FormatBytes renders a byte count using binary units (e.g. "4.2 GiB").
+func FormatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   uint64
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KiB"},
+		{5 << 30, "5.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.in); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	// Not-yet-created install dirs are checked via their nearest parent
+	dir := filepath.Join(t.TempDir(), "not", "created")
+
+	if err := CheckDiskSpace(dir, 1); err != nil {
+		t.Errorf("CheckDiskSpace(1 byte): %v", err)
+	}
+	err := CheckDiskSpace(dir, 1<<62)
+	if err == nil || !strings.Contains(err.Error(), "available") || !strings.Contains(err.Error(), "required") {
+		t.Errorf("CheckDiskSpace(huge) = %v, want available vs required error", err)
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := CheckWritable(filepath.Join(dir, "new")); err != nil {
+		t.Fatalf("CheckWritable: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("CheckWritable left %d files behind", len(entries))
+	}
+
+	if os.Getuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	ro := filepath.Join(dir, "ro")
+	os.Mkdir(ro, 0555)
+	if err := CheckWritable(ro); err == nil {
+		t.Error("CheckWritable on read-only dir should fail")
+	}
+}
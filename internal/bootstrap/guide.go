@@ -37,11 +37,18 @@ func GenerateGuide(env *Environment, installDir string, cfg *Config) string {
 
 	sb.WriteString("### Required\n")
 	sb.WriteString("- Docker & Docker Compose (all services run as containers)\n")
-	sb.WriteString("- Git\n\n")
+	sb.WriteString("- Git\n")
+	sb.WriteString(fmt.Sprintf("- Node.js %s+ and Python %s+ (to build the services from source below)\n\n", MinNodeVersion, MinPythonVersion))
 
 	sb.WriteString("### Optional\n")
 	sb.WriteString("- AWS CLI (for CodeCommit repo discovery)\n")
-	sb.WriteString("- Go 1.24+ (for CLI development only)\n\n")
+	sb.WriteString(fmt.Sprintf("- Go %s+ (for CLI development only)\n\n", MinGoVersion))
+	if notes := env.OptionalNotes(); len(notes) > 0 {
+		for _, n := range notes {
+			sb.WriteString(fmt.Sprintf("> ℹ️ %s\n", n))
+		}
+		sb.WriteString("\n")
+	}
 
 	// Temporal
 	sb.WriteString("## Temporal Server\n\n")
@@ -75,6 +82,9 @@ func GenerateGuide(env *Environment, installDir string, cfg *Config) string {
 	sb.WriteString("TEMPORAL_NAMESPACE=default\n")
 	sb.WriteString("TEMPORAL_TASK_QUEUE=investigate-task-queue\n")
 	sb.WriteString(fmt.Sprintf("AWS_REGION=%s\n", env.AWSRegion))
+	if env.AWSProfile != "" {
+		sb.WriteString(fmt.Sprintf("AWS_PROFILE=%s\n", env.AWSProfile))
+	}
 	sb.WriteString(fmt.Sprintf("DYNAMODB_TABLE=%s\n", cfg.DynamoDBTable))
 	sb.WriteString(fmt.Sprintf("DEFAULT_MODEL=%s\n", cfg.DefaultModel))
 	sb.WriteString("EOF\n\n")
@@ -186,6 +196,12 @@ func installInstructions(env *Environment, missing []string) string {
 	for _, dep := range missing {
 		sb.WriteString(fmt.Sprintf("**%s:**\n", dep))
 		switch {
+		case strings.HasPrefix(dep, "docker daemon"):
+			if env.OS == "darwin" {
+				sb.WriteString("```bash\nopen -a Docker\n```\n")
+			} else {
+				sb.WriteString("```bash\nsudo systemctl start docker\n```\n")
+			}
 		case strings.HasPrefix(dep, "docker"):
 			if env.OS == "darwin" {
 				sb.WriteString("```bash\nbrew install --cask docker\n```\n")
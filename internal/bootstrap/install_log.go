@@ -1,7 +1,9 @@
 package bootstrap
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,11 +15,19 @@ import (
 // InstallLog captures detailed install/setup information to a file.
 // It writes everything needed to debug issues remotely.
 type InstallLog struct {
-	file    *os.File
-	logPath string
-	started time.Time
+	file       *os.File
+	logPath    string
+	latestPath string // <installDir>/setup.log, a copy of the latest run's log
+	started    time.Time
+	stream     io.Writer // when set, RunCmd output is also streamed here live
+	echoed     string    // last line written by echo, to drop an identical follow-up
+	secrets    []string  // redacted from every line
 }
 
+// SetupLogFile is the copy of the most recent install log kept in the
+// install directory, the one file to attach to a bug report.
+const SetupLogFile = "setup.log"
+
 // NewInstallLog creates a new install log in the given directory.
 // Creates the directory if needed. Returns nil (no-op) if it can't create the file.
 func NewInstallLog(installDir string) *InstallLog {
@@ -26,20 +36,35 @@ func NewInstallLog(installDir string) *InstallLog {
 
 	timestamp := time.Now().Format("20060102-150405")
 	logPath := filepath.Join(logsDir, fmt.Sprintf("install-%s.log", timestamp))
+	latestPath := filepath.Join(installDir, SetupLogFile)
 
 	f, err := os.Create(logPath)
 	if err != nil {
-		return &InstallLog{logPath: logPath, started: time.Now()}
+		return &InstallLog{logPath: logPath, latestPath: latestPath, started: time.Now()}
 	}
 
-	il := &InstallLog{file: f, logPath: logPath, started: time.Now()}
+	il := &InstallLog{file: f, logPath: logPath, latestPath: latestPath, started: time.Now()}
 	il.writeHeader()
 	return il
 }
 
+// StreamTo makes RunCmd copy command output to w as it runs (e.g. os.Stderr
+// for --verbose), in addition to capturing it for the log.
+func (il *InstallLog) StreamTo(w io.Writer) { il.stream = w }
+
 // Path returns the log file path.
 func (il *InstallLog) Path() string { return il.logPath }
 
+// LatestPath returns the setup.log path, written when the log is closed.
+func (il *InstallLog) LatestPath() string { return il.latestPath }
+
+// Redact replaces secret with *** in everything logged from now on.
+func (il *InstallLog) Redact(secret string) {
+	if secret != "" {
+		il.secrets = append(il.secrets, secret)
+	}
+}
+
 // Section writes a section header.
 func (il *InstallLog) Section(title string) {
 	sep := strings.Repeat("=", 60)
@@ -112,6 +137,10 @@ func (il *InstallLog) Close() {
 	il.line(sep)
 	if il.file != nil {
 		il.file.Close()
+		il.file = nil
+		if data, err := os.ReadFile(il.logPath); err == nil {
+			os.WriteFile(il.latestPath, data, 0600)
+		}
 	}
 }
 
@@ -127,7 +156,18 @@ func (il *InstallLog) RunCmd(dir string, name string, args ...string) ([]byte, e
 	if dir != "" {
 		cmd.Dir = dir
 	}
-	out, err := cmd.CombinedOutput()
+	var out []byte
+	var err error
+	if il.stream != nil {
+		var buf bytes.Buffer
+		w := io.MultiWriter(&buf, il.stream)
+		cmd.Stdout = w
+		cmd.Stderr = w
+		err = cmd.Run()
+		out = buf.Bytes()
+	} else {
+		out, err = cmd.CombinedOutput()
+	}
 
 	if len(out) > 0 {
 		for _, l := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
@@ -196,5 +236,65 @@ func (il *InstallLog) line(s string) {
 	if il.file == nil {
 		return
 	}
+	for _, secret := range il.secrets {
+		s = strings.ReplaceAll(s, secret, "***")
+	}
+	if il.echoed != "" && s == il.echoed {
+		// Already recorded from the printer just before
+		il.echoed = ""
+		return
+	}
+	il.echoed = ""
 	il.file.WriteString(s + "\n")
 }
+
+// echo writes what the user was shown, so an identical log line right
+// after it isn't written twice.
+func (il *InstallLog) echo(s string) {
+	il.line(s)
+	for _, secret := range il.secrets {
+		s = strings.ReplaceAll(s, secret, "***")
+	}
+	il.echoed = s
+}
+
+// teePrinter shows messages through Printer and also records them in the
+// install log, so the log is a full transcript of the setup.
+type teePrinter struct {
+	Printer
+	log *InstallLog
+}
+
+func (p *teePrinter) Section(title string) {
+	p.Printer.Section(title)
+	p.log.echo("-- " + title)
+}
+
+func (p *teePrinter) Info(msg string) {
+	p.Printer.Info(msg)
+	p.log.echo("[INFO]  " + msg)
+}
+
+func (p *teePrinter) Success(msg string) {
+	p.Printer.Success(msg)
+	p.log.echo("[OK]    " + msg)
+}
+
+func (p *teePrinter) Warning(msg string) {
+	p.Printer.Warning(msg)
+	p.log.echo("[WARN]  " + msg)
+}
+
+func (p *teePrinter) Error(msg string) {
+	p.Printer.Error(msg)
+	p.log.echo("[ERROR] " + msg)
+}
+
+func (p *teePrinter) Printf(format string, args ...any) {
+	p.Printer.Printf(format, args...)
+	for _, l := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		if l = strings.TrimRight(l, " "); strings.TrimSpace(l) != "" {
+			p.log.echo(l)
+		}
+	}
+}
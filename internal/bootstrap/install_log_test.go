@@ -0,0 +1,75 @@
+package bootstrap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCmdStreamsAndLogs(t *testing.T) {
+	dir := t.TempDir()
+	il := NewInstallLog(dir)
+	var live bytes.Buffer
+	il.StreamTo(&live)
+
+	out, err := il.RunCmd("", "sh", "-c", "echo out; echo err >&2")
+	il.Close()
+	if err != nil {
+		t.Fatalf("RunCmd: %v", err)
+	}
+	for _, want := range []string{"out", "err"} {
+		if !strings.Contains(live.String(), want) {
+			t.Errorf("streamed output missing %q: %q", want, live.String())
+		}
+		if !strings.Contains(string(out), want) {
+			t.Errorf("returned output missing %q: %q", want, out)
+		}
+	}
+
+	data, err := os.ReadFile(il.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "  | out") || !strings.Contains(string(data), "  | err") {
+		t.Errorf("log file missing command output:\n%s", data)
+	}
+}
+
+type recordPrinter struct{ lines []string }
+
+func (p *recordPrinter) Section(title string)              { p.lines = append(p.lines, title) }
+func (p *recordPrinter) Info(msg string)                   { p.lines = append(p.lines, msg) }
+func (p *recordPrinter) Success(msg string)                { p.lines = append(p.lines, msg) }
+func (p *recordPrinter) Warning(msg string)                { p.lines = append(p.lines, msg) }
+func (p *recordPrinter) Error(msg string)                  { p.lines = append(p.lines, msg) }
+func (p *recordPrinter) Printf(format string, args ...any) {}
+
+func TestTeePrinterWritesSetupLog(t *testing.T) {
+	dir := t.TempDir()
+	il := NewInstallLog(dir)
+	inner := &recordPrinter{}
+	p := &teePrinter{Printer: inner, log: il}
+
+	il.Redact("s3cr3t-token")
+	p.Success("All prerequisites found")
+	il.Success("All prerequisites found")
+	p.Printf("  API Token:    %s\n", "s3cr3t-token")
+	il.Close()
+
+	if len(inner.lines) != 1 {
+		t.Errorf("inner printer got %v", inner.lines)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, SetupLogFile))
+	if err != nil {
+		t.Fatalf("setup.log not written: %v", err)
+	}
+	log := string(data)
+	if n := strings.Count(log, "[OK]    All prerequisites found"); n != 1 {
+		t.Errorf("success logged %d times, want 1:\n%s", n, log)
+	}
+	if strings.Contains(log, "s3cr3t-token") || !strings.Contains(log, "API Token:    ***") {
+		t.Errorf("token not redacted:\n%s", log)
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 )
 
 // Config is a subset of the full CLI config used by SetupLocal.
@@ -26,15 +29,84 @@ type Config struct {
 	APIPort         string
 	UIPort          string
 	Region          string
+	AWSProfile      string            // Written to the Docker .env as AWS_PROFILE (host value when empty)
 	ProviderEnvVars map[string]string // Provider-specific env vars (CLAUDE_CODE_USE_BEDROCK, CLAUDE_PROVIDER, etc.)
+	ProxyURL        string            // Overrides HTTP_PROXY/HTTPS_PROXY for readiness probes
+	Force           bool              // Ignore saved setup state and redo every step
+	Skip            []string          // Services to leave out (see LocalServices and ResolveSkip)
+	Verbose         bool              // Stream subprocess output (docker, git, npm, pip) to stderr live
+	ComposeFile     string            // Replaces the generated docker-compose.yml entirely
+	ComposeOverride string            // Copied to docker-compose.override.yml, merged by docker compose
+
+	// Readiness waits; zero means the built-in default
+	TemporalTimeout time.Duration // Temporal (default 300s; first run does schema setup)
+	ServiceTimeout  time.Duration // API and UI (default 120s in Docker, 30s for source installs)
+	PollInterval    time.Duration // Readiness probe interval (default 2s)
+}
+
+// DefaultTemporalTimeout and DefaultServiceTimeout bound the Docker readiness waits.
+const (
+	DefaultTemporalTimeout = 300 * time.Second
+	DefaultServiceTimeout  = 120 * time.Second
+	DefaultPollInterval    = 2 * time.Second
+)
+
+func (c *Config) temporalTimeout() time.Duration {
+	if c != nil && c.TemporalTimeout > 0 {
+		return c.TemporalTimeout
+	}
+	return DefaultTemporalTimeout
+}
+
+// serviceTimeout returns the configured API/UI wait, or def.
+func (c *Config) serviceTimeout(def time.Duration) time.Duration {
+	if c != nil && c.ServiceTimeout > 0 {
+		return c.ServiceTimeout
+	}
+	return def
+}
+
+func (c *Config) pollInterval() time.Duration {
+	if c != nil && c.PollInterval > 0 {
+		return c.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+// httpClient returns a client for readiness probes that honors the proxy settings.
+func (c *Config) httpClient(timeout time.Duration) *http.Client {
+	client := &http.Client{Timeout: timeout}
+	if c == nil {
+		return client
+	}
+	if transport, err := api.NewTransport(api.TransportOptions{ProxyURL: c.ProxyURL}); err == nil {
+		client.Transport = transport
+	}
+	return client
 }
 
 // LocalSetupResult holds the outcome of each setup step.
 type LocalSetupResult struct {
 	InstallDir string            `json:"installDir"`
 	Token      string            `json:"token"`
+	LogPath    string            `json:"logPath"` // setup.log transcript of this run
 	Steps      []LocalStepResult `json:"steps"`
 	Success    bool              `json:"success"`
+	Error      string            `json:"error,omitempty"` // why setup stopped, if it did
+}
+
+// recordFailure notes why setup stopped, making sure the failed step says so
+// even when the step itself didn't record a message.
+func (r *LocalSetupResult) recordFailure(err error) {
+	r.Success = false
+	r.Error = err.Error()
+	if n := len(r.Steps); n > 0 && r.Steps[n-1].Status == "fail" {
+		if r.Steps[n-1].Message == "" {
+			r.Steps[n-1].Message = err.Error()
+		}
+		return
+	}
+	r.Steps = append(r.Steps, LocalStepResult{"setup", "fail", err.Error()})
 }
 
 // LocalStepResult is one step in the setup process.
@@ -56,14 +128,24 @@ type Printer interface {
 
 // SetupLocal orchestrates a complete local RepoSwarm environment.
 // Config values drive repo URLs, ports, table names, and model IDs.
-func SetupLocal(env *Environment, installDir string, cfg *Config, printer Printer) (*LocalSetupResult, error) {
-	result := &LocalSetupResult{InstallDir: installDir}
+func SetupLocal(env *Environment, installDir string, cfg *Config, printer Printer) (result *LocalSetupResult, err error) {
+	result = &LocalSetupResult{InstallDir: installDir}
+	defer func() {
+		if err != nil {
+			result.recordFailure(err)
+		}
+	}()
 
-	// Initialize install log
+	// Initialize install log; everything shown to the user is recorded too
 	log := NewInstallLog(installDir)
+	if cfg.Verbose {
+		log.StreamTo(os.Stderr)
+	}
+	result.LogPath = log.LatestPath()
+	printer = &teePrinter{Printer: printer, log: log}
 	defer func() {
 		log.Close()
-		printer.Printf("\n  📄 Full install log: %s\n\n", log.Path())
+		printer.Printf("\n  📄 Full install log: %s\n\n", log.LatestPath())
 	}()
 
 	// Log config
@@ -77,28 +159,52 @@ func SetupLocal(env *Environment, installDir string, cfg *Config, printer Printe
 	log.Info(fmt.Sprintf("workerRepoURL: %s", cfg.WorkerRepoURL))
 	log.Info(fmt.Sprintf("apiRepoURL: %s", cfg.APIRepoURL))
 	log.Info(fmt.Sprintf("uiRepoURL: %s", cfg.UIRepoURL))
+	if len(cfg.Skip) > 0 {
+		log.Info(fmt.Sprintf("skip: %s", strings.Join(cfg.Skip, ", ")))
+	}
 
 	// Step 0: Check prerequisites
 	log.Section("Prerequisites")
 	printer.Section("Checking prerequisites")
-	if missing := env.MissingDeps(); len(missing) > 0 {
+	// Services run in containers, so an old host Node/Python is only a warning here.
+	outdated := env.OutdatedDeps()
+	for _, dep := range outdated {
+		printer.Warning(fmt.Sprintf("Outdated: %s — only matters when building services from source", dep))
+		log.Warning(fmt.Sprintf("Outdated runtime: %s", dep))
+	}
+	if missing := env.missingTools(); len(missing) > 0 {
 		for _, dep := range missing {
 			printer.Error(fmt.Sprintf("Missing: %s", dep))
 			log.Error(fmt.Sprintf("Missing prerequisite: %s", dep))
 		}
 		result.Steps = append(result.Steps, LocalStepResult{"prerequisites", "fail", "missing: " + strings.Join(missing, ", ")})
+		if len(missing) == 1 && env.HasDocker && !env.DockerRunning {
+			return result, fmt.Errorf("docker is installed but the daemon is not running — %s", DockerDaemonHint(env.OS))
+		}
 		return result, fmt.Errorf("missing prerequisites: %s — install them first", strings.Join(missing, ", "))
 	}
 	printer.Success("All prerequisites found")
 	log.Success("All prerequisites found")
 	result.Steps = append(result.Steps, LocalStepResult{"prerequisites", "ok", ""})
 
-	// Generate a bearer token for local auth
-	token, err := randomHex(32)
-	if err != nil {
-		return result, fmt.Errorf("generating token: %w", err)
+	// Check the install directory can hold the images and sources
+	printer.Section("Checking disk")
+	if err := CheckWritable(installDir); err != nil {
+		printer.Error(err.Error())
+		log.Error(err.Error())
+		result.Steps = append(result.Steps, LocalStepResult{"disk", "fail", err.Error()})
+		return result, err
 	}
-	result.Token = token
+	if err := CheckDiskSpace(installDir, MinFreeDiskBytes); err != nil {
+		printer.Error(err.Error())
+		log.Error(err.Error())
+		result.Steps = append(result.Steps, LocalStepResult{"disk", "fail", err.Error()})
+		return result, fmt.Errorf("%w — free up space or choose another --dir", err)
+	}
+	avail, _ := AvailableDiskBytes(installDir)
+	printer.Success(fmt.Sprintf("%s available in %s", FormatBytes(avail), installDir))
+	log.Success(fmt.Sprintf("Disk: %s available in %s", FormatBytes(avail), installDir))
+	result.Steps = append(result.Steps, LocalStepResult{"disk", "ok", FormatBytes(avail) + " available"})
 
 	// Step 1: Create directory structure
 	printer.Section("Creating directory structure")
@@ -109,19 +215,66 @@ func SetupLocal(env *Environment, installDir string, cfg *Config, printer Printe
 	printer.Success(fmt.Sprintf("Install directory: %s", installDir))
 	result.Steps = append(result.Steps, LocalStepResult{"directories", "ok", installDir})
 
+	// Completed steps from a previous run are skipped unless --force
+	state := LoadSetupState(installDir)
+	if cfg.Force {
+		state.Reset()
+	} else if len(state.Completed) > 0 {
+		printer.Info(fmt.Sprintf("Resuming previous setup (state in %s)", filepath.Join(installDir, SetupStateFile)))
+		log.Info("Resuming from " + filepath.Join(installDir, SetupStateFile))
+	}
+
+	// Generate a bearer token for local auth (reused when resuming)
+	token := state.Token
+	if token == "" {
+		var err error
+		if token, err = randomHex(32); err != nil {
+			result.Steps = append(result.Steps, LocalStepResult{"token", "fail", err.Error()})
+			return result, fmt.Errorf("generating token: %w", err)
+		}
+		state.Token = token
+		if err := state.Save(); err != nil {
+			log.Warning(err.Error())
+		}
+	}
+	result.Token = token
+	log.Redact(token)
+
 	// Step 2: Start all services (Temporal + API + Worker + UI via Docker Compose)
 	log.Section("Docker Compose Setup")
 	printer.Section("Starting all services (Docker Compose)")
-	if err := setupDocker(installDir, cfg, token, printer, log); err != nil {
-		result.Steps = append(result.Steps, LocalStepResult{"docker-compose", "fail", err.Error()})
-		return result, fmt.Errorf("docker compose setup: %w", err)
+	// The state key includes the service selection, so changing --skip redoes the step
+	composeStep := "docker-compose"
+	if len(cfg.Skip) > 0 {
+		composeStep += ":skip=" + strings.Join(cfg.Skip, ",")
+	}
+	// Only skip when the stack is still answering; a stopped stack is redone
+	if state.Done(composeStep) && serviceResponding(cfg, cfg.readinessURL()) {
+		printer.Info("Already running from a previous setup — skipping (use --force to redo)")
+		log.Info("docker-compose step already completed, skipping")
+		result.Steps = append(result.Steps, LocalStepResult{"docker-compose", "skip", "completed in a previous run"})
+	} else {
+		if err := setupDocker(installDir, cfg, token, printer, log); err != nil {
+			result.Steps = append(result.Steps, LocalStepResult{"docker-compose", "fail", err.Error()})
+			return result, fmt.Errorf("docker compose setup: %w", err)
+		}
+		if err := state.MarkDone(composeStep); err != nil {
+			log.Warning(err.Error())
+		}
+		for _, step := range []LocalStepResult{
+			{"temporal", "ok", fmt.Sprintf("http://localhost:%s", cfg.TemporalUIPort)},
+			{"api", "ok", fmt.Sprintf("http://localhost:%s", cfg.APIPort)},
+			{"worker", "ok", ""},
+			{"ui", "ok", fmt.Sprintf("http://localhost:%s", cfg.UIPort)},
+		} {
+			if cfg.Skipped(step.Name) {
+				step = LocalStepResult{step.Name, "skip", "skipped by request"}
+			}
+			result.Steps = append(result.Steps, step)
+		}
 	}
-	result.Steps = append(result.Steps, LocalStepResult{"temporal", "ok", fmt.Sprintf("http://localhost:%s", cfg.TemporalUIPort)})
-	result.Steps = append(result.Steps, LocalStepResult{"api", "ok", fmt.Sprintf("http://localhost:%s", cfg.APIPort)})
-	result.Steps = append(result.Steps, LocalStepResult{"worker", "ok", ""})
-	result.Steps = append(result.Steps, LocalStepResult{"ui", "ok", fmt.Sprintf("http://localhost:%s", cfg.UIPort)})
 
-	// Step 3: Configure CLI
+	// Step 3: Configure CLI (cheap and idempotent, so always re-run)
 	printer.Section("Configuring CLI")
 	if err := configureCLI(cfg, token); err != nil {
 		result.Steps = append(result.Steps, LocalStepResult{"cli-config", "fail", err.Error()})
@@ -146,8 +299,15 @@ func SetupLocal(env *Environment, installDir string, cfg *Config, printer Printe
 	}
 	printer.Printf("\n")
 	printer.Printf("  Temporal UI:  http://localhost:%s\n", cfg.TemporalUIPort)
-	printer.Printf("  API Server:   http://localhost:%s\n", cfg.APIPort)
-	printer.Printf("  UI:           http://localhost:%s\n", cfg.UIPort)
+	if !cfg.Skipped("api") {
+		printer.Printf("  API Server:   http://localhost:%s\n", cfg.APIPort)
+	}
+	if !cfg.Skipped("ui") {
+		printer.Printf("  UI:           http://localhost:%s\n", cfg.UIPort)
+	}
+	if len(cfg.Skip) > 0 {
+		printer.Printf("  Skipped:      %s\n", strings.Join(cfg.Skip, ", "))
+	}
 	printer.Printf("\n")
 	printer.Printf("  API Token:    %s\n", token)
 	printer.Printf("  Logs:         %s/*/*.log\n", installDir)
@@ -188,7 +348,14 @@ func setupDocker(installDir string, cfg *Config, token string, printer Printer,
 	}
 
 	// Free ports that might be used by existing non-Docker services
-	for _, port := range []string{cfg.APIPort, cfg.UIPort, cfg.TemporalPort, cfg.TemporalUIPort} {
+	ports := []string{cfg.TemporalPort, cfg.TemporalUIPort}
+	if !cfg.Skipped("api") {
+		ports = append(ports, cfg.APIPort)
+	}
+	if !cfg.Skipped("ui") {
+		ports = append(ports, cfg.UIPort)
+	}
+	for _, port := range ports {
 		killProcessOnPort(port)
 	}
 
@@ -198,12 +365,9 @@ func setupDocker(installDir string, cfg *Config, token string, printer Printer,
 		log.RunCmd(temporalDir, "docker", "compose", "down")
 	}
 
-	composePath := filepath.Join(temporalDir, "docker-compose.yml")
-	if err := os.WriteFile(composePath, []byte(TemporalComposeLocal()), 0644); err != nil {
-		return fmt.Errorf("writing docker-compose.yml: %w", err)
+	if err := writeComposeFiles(temporalDir, cfg, printer, log); err != nil {
+		return err
 	}
-	printer.Info("Wrote docker-compose.yml")
-	log.Info("Wrote docker-compose.yml to " + composePath)
 
 	// Write .env file with token, ports, and passthrough env vars
 	envVars := []string{
@@ -211,7 +375,13 @@ func setupDocker(installDir string, cfg *Config, token string, printer Printer,
 		fmt.Sprintf("API_PORT=%s", cfg.APIPort),
 		fmt.Sprintf("UI_PORT=%s", cfg.UIPort),
 	}
-	// Pass through LLM and git provider env vars from host
+	// Region and profile come from flags/config; the rest passes through from the host
+	if cfg.Region != "" {
+		envVars = append(envVars, "AWS_REGION="+cfg.Region)
+	}
+	if cfg.AWSProfile != "" {
+		envVars = append(envVars, "AWS_PROFILE="+cfg.AWSProfile)
+	}
 	for _, key := range []string{
 		"ANTHROPIC_API_KEY", "CLAUDE_CODE_USE_BEDROCK", "AWS_REGION",
 		"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
@@ -219,6 +389,9 @@ func setupDocker(installDir string, cfg *Config, token string, printer Printer,
 		"ANTHROPIC_BASE_URL", "ANTHROPIC_MODEL",
 		"GITHUB_TOKEN", "GITLAB_TOKEN",
 	} {
+		if (key == "AWS_REGION" && cfg.Region != "") || (key == "AWS_PROFILE" && cfg.AWSProfile != "") {
+			continue
+		}
 		if v := os.Getenv(key); v != "" {
 			envVars = append(envVars, fmt.Sprintf("%s=%s", key, v))
 		}
@@ -230,46 +403,103 @@ func setupDocker(installDir string, cfg *Config, token string, printer Printer,
 	printer.Info("Wrote .env")
 	log.Info("Wrote .env to " + envPath)
 
-	// docker compose up -d
-	out, err := log.RunCmd(temporalDir, "docker", "compose", "up", "-d")
+	// docker compose up -d [services...] (all services unless some are skipped)
+	upArgs := append([]string{"compose", "up", "-d"}, cfg.composeServices()...)
+	out, err := log.RunCmd(temporalDir, "docker", upArgs...)
 	if err != nil {
 		return fmt.Errorf("docker compose up failed: %w\n%s", err, string(out))
 	}
 	printer.Info("Docker containers starting...")
 
-	// Wait for Temporal to be ready (up to 60s)
-	printer.Info("Waiting for Temporal to be ready (first run may take up to 5 minutes for schema setup)...")
+	// Wait for Temporal to be ready
+	temporalTimeout := cfg.temporalTimeout()
+	printer.Info(fmt.Sprintf("Waiting for Temporal to be ready (up to %s; first run does schema setup)...", temporalTimeout))
 	log.Info("Waiting for Temporal on port " + cfg.TemporalUIPort)
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s/api/v1/namespaces", cfg.TemporalUIPort), 300*time.Second); err != nil {
+	took, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s/api/v1/namespaces", cfg.TemporalUIPort), temporalTimeout)
+	if err != nil {
 		// Check container status for debugging
 		statusOut, _ := log.RunCmd(temporalDir, "docker", "compose", "ps", "--format", "{{.Name}}\t{{.Status}}")
-		return fmt.Errorf("temporal not ready after 300s: %w\nContainer status:\n%s", err, string(statusOut))
+		return fmt.Errorf("temporal not ready after %s (raise with --temporal-timeout): %w\nContainer status:\n%s", temporalTimeout, err, string(statusOut))
 	}
-	printer.Success("Temporal is ready")
-	log.Success("Temporal is ready")
+	printer.Success(fmt.Sprintf("Temporal is ready (%s)", took))
+	log.Success(fmt.Sprintf("Temporal is ready after %s", took))
 
 	// Wait for API to be ready
-	printer.Info("Waiting for API server...")
-	log.Info("Waiting for API on port " + cfg.APIPort)
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort), 120*time.Second); err != nil {
-		statusOut, _ := log.RunCmd(temporalDir, "docker", "compose", "ps", "--format", "{{.Name}}\t{{.Status}}")
-		logsOut, _ := log.RunCmd(temporalDir, "docker", "compose", "logs", "api", "--tail", "30")
-		return fmt.Errorf("API not ready after 120s: %w\nContainer status:\n%s\nAPI logs:\n%s", err, string(statusOut), string(logsOut))
+	if !cfg.Skipped("api") {
+		timeout := cfg.serviceTimeout(DefaultServiceTimeout)
+		printer.Info("Waiting for API server...")
+		log.Info("Waiting for API on port " + cfg.APIPort)
+		took, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort), timeout)
+		if err != nil {
+			statusOut, _ := log.RunCmd(temporalDir, "docker", "compose", "ps", "--format", "{{.Name}}\t{{.Status}}")
+			logsOut, _ := log.RunCmd(temporalDir, "docker", "compose", "logs", "api", "--tail", "30")
+			return fmt.Errorf("API not ready after %s (raise with --service-timeout): %w\nContainer status:\n%s\nAPI logs:\n%s", timeout, err, string(statusOut), string(logsOut))
+		}
+		printer.Success(fmt.Sprintf("API server is ready (%s)", took))
+		log.Success(fmt.Sprintf("API server is ready after %s", took))
 	}
-	printer.Success("API server is ready")
-	log.Success("API server is ready")
 
 	// Wait for UI to be ready
-	printer.Info("Waiting for UI...")
-	log.Info("Waiting for UI on port " + cfg.UIPort)
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s", cfg.UIPort), 120*time.Second); err != nil {
-		printer.Warning("UI not ready yet — may still be starting. Check: docker compose logs ui")
-		log.Warning("UI not ready after 120s")
+	if !cfg.Skipped("ui") {
+		timeout := cfg.serviceTimeout(DefaultServiceTimeout)
+		printer.Info("Waiting for UI...")
+		log.Info("Waiting for UI on port " + cfg.UIPort)
+		if took, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s", cfg.UIPort), timeout); err != nil {
+			printer.Warning("UI not ready yet — may still be starting. Check: docker compose logs ui")
+			log.Warning(fmt.Sprintf("UI not ready after %s", timeout))
+		} else {
+			printer.Success(fmt.Sprintf("UI is ready (%s)", took))
+			log.Success(fmt.Sprintf("UI is ready after %s", took))
+		}
+	}
+
+	return nil
+}
+
+// writeComposeFiles writes docker-compose.yml (generated, or the user's
+// --compose-file) and copies any --compose-override next to it as
+// docker-compose.override.yml, which docker compose merges automatically.
+func writeComposeFiles(composeDir string, cfg *Config, printer Printer, log *InstallLog) error {
+	composePath := filepath.Join(composeDir, "docker-compose.yml")
+	compose := []byte(TemporalComposeLocal())
+	if cfg.ComposeFile != "" {
+		data, err := os.ReadFile(cfg.ComposeFile)
+		if err != nil {
+			return fmt.Errorf("reading compose file: %w", err)
+		}
+		compose = data
+	}
+	if err := os.WriteFile(composePath, compose, 0644); err != nil {
+		return fmt.Errorf("writing docker-compose.yml: %w", err)
+	}
+	if cfg.ComposeFile != "" {
+		printer.Info("Wrote docker-compose.yml from " + cfg.ComposeFile)
+		printer.Warning("Custom compose file: published ports must match the configured apiPort, uiPort, temporalPort and temporalUiPort")
+		log.Info("Copied " + cfg.ComposeFile + " to " + composePath)
 	} else {
-		printer.Success("UI is ready")
-		log.Success("UI is ready")
+		printer.Info("Wrote docker-compose.yml")
+		log.Info("Wrote docker-compose.yml to " + composePath)
 	}
 
+	overridePath := filepath.Join(composeDir, "docker-compose.override.yml")
+	if cfg.ComposeOverride == "" {
+		// Don't let compose keep merging an override from an earlier setup
+		if err := os.Remove(overridePath); err == nil {
+			log.Info("Removed stale " + overridePath)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("removing docker-compose.override.yml: %w", err)
+		}
+		return nil
+	}
+	data, err := os.ReadFile(cfg.ComposeOverride)
+	if err != nil {
+		return fmt.Errorf("reading compose override: %w", err)
+	}
+	if err := os.WriteFile(overridePath, data, 0644); err != nil {
+		return fmt.Errorf("writing docker-compose.override.yml: %w", err)
+	}
+	printer.Info("Wrote docker-compose.override.yml from " + cfg.ComposeOverride)
+	log.Info("Copied " + cfg.ComposeOverride + " to " + overridePath)
 	return nil
 }
 
@@ -362,10 +592,12 @@ AWS_SECRET_ACCESS_KEY=local
 
 	// Wait for API
 	printer.Info("Waiting for API to be ready...")
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort), 30*time.Second); err != nil {
-		return fmt.Errorf("API not ready after 30s: %w", err)
+	timeout := cfg.serviceTimeout(30 * time.Second)
+	took, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort), timeout)
+	if err != nil {
+		return fmt.Errorf("API not ready after %s: %w", timeout, err)
 	}
-	printer.Success("API server is ready")
+	printer.Success(fmt.Sprintf("API server is ready (%s)", took))
 	log.Success("API server is ready (PID " + fmt.Sprintf("%d", startCmd.Process.Pid) + ")")
 	return nil
 }
@@ -534,23 +766,24 @@ func setupUI(installDir string, cfg *Config, printer Printer, log *InstallLog) e
 
 	// Wait for UI
 	printer.Info("Waiting for UI to be ready...")
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s", cfg.UIPort), 30*time.Second); err != nil {
+	timeout := cfg.serviceTimeout(30 * time.Second)
+	took, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s", cfg.UIPort), timeout)
+	if err != nil {
 		printer.Warning("UI not responding yet — it may still be compiling (check ui/ui.log)")
-		log.Warning("UI not responding after 30s")
+		log.Warning(fmt.Sprintf("UI not responding after %s", timeout))
 		return nil // Non-fatal
 	}
-	printer.Success("UI is ready")
+	printer.Success(fmt.Sprintf("UI is ready (%s)", took))
 	log.Success("UI is ready (PID " + fmt.Sprintf("%d", startCmd.Process.Pid) + ")")
 	return nil
 }
 
 func configureCLI(cfg *Config, token string) error {
-	home, err := os.UserHomeDir()
+	configPath, err := config.ConfigPath()
 	if err != nil {
 		return err
 	}
-	configDir := filepath.Join(home, ".reposwarm")
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
 		return err
 	}
 	configContent := fmt.Sprintf(`{
@@ -562,24 +795,31 @@ func configureCLI(cfg *Config, token string) error {
   "outputFormat": "pretty"
 }
 `, cfg.APIPort, token, cfg.DefaultModel)
-	return os.WriteFile(filepath.Join(configDir, "config.json"), []byte(configContent), 0600)
+	return os.WriteFile(configPath, []byte(configContent), 0600)
 }
 
 func verifyServices(cfg *Config, printer Printer) LocalStepResult {
 	checks := []struct {
-		name string
-		url  string
+		name    string
+		url     string
+		service string
 	}{
-		{"Temporal", fmt.Sprintf("http://localhost:%s/api/v1/namespaces", cfg.TemporalUIPort)},
-		{"DynamoDB Local", "http://localhost:8000"},
-		{"API", fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort)},
-		{"UI", fmt.Sprintf("http://localhost:%s", cfg.UIPort)},
+		{"Temporal", fmt.Sprintf("http://localhost:%s/api/v1/namespaces", cfg.TemporalUIPort), "temporal"},
+		{"DynamoDB Local", "http://localhost:8000", ""},
+		{"API", fmt.Sprintf("http://localhost:%s/v1/health", cfg.APIPort), "api"},
+		{"UI", fmt.Sprintf("http://localhost:%s", cfg.UIPort), "ui"},
 	}
 
+	client := cfg.httpClient(10 * time.Second)
 	allOK := true
 	var messages []string
 	for _, c := range checks {
-		resp, err := http.Get(c.url)
+		// DynamoDB Local runs with either the API or the worker
+		if (c.service != "" && cfg.Skipped(c.service)) || (c.service == "" && cfg.Skipped("api") && cfg.Skipped("worker")) {
+			messages = append(messages, fmt.Sprintf("%s: skipped", c.name))
+			continue
+		}
+		resp, err := client.Get(c.url)
 		if err != nil {
 			printer.Warning(fmt.Sprintf("%s: not responding (%s)", c.name, err))
 			messages = append(messages, fmt.Sprintf("%s: fail", c.name))
@@ -732,30 +972,51 @@ volumes:
 `
 }
 
-func waitForHTTP(url string, timeout time.Duration) error {
+// waitForHTTP polls url until it answers below HTTP 500 or timeout passes,
+// returning how long the wait took (rounded to the second).
+func waitForHTTP(cfg *Config, url string, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	ticker := time.NewTicker(2 * time.Second)
+	client := cfg.httpClient(5 * time.Second)
+	ticker := time.NewTicker(cfg.pollInterval())
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("timeout waiting for %s", url)
+			return time.Since(start).Round(time.Second), fmt.Errorf("timeout waiting for %s", url)
 		case <-ticker.C:
 			resp, err := client.Get(url)
 			if err == nil {
 				resp.Body.Close()
 				if resp.StatusCode < 500 {
-					return nil
+					return time.Since(start).Round(time.Second), nil
 				}
 			}
 		}
 	}
 }
 
+// readinessURL is the probe that shows a previously started stack is still up.
+func (c *Config) readinessURL() string {
+	if c.Skipped("api") {
+		return fmt.Sprintf("http://localhost:%s/api/v1/namespaces", c.TemporalUIPort)
+	}
+	return fmt.Sprintf("http://localhost:%s/v1/health", c.APIPort)
+}
+
+// serviceResponding does a single readiness probe against url.
+func serviceResponding(cfg *Config, url string) bool {
+	resp, err := cfg.httpClient(3 * time.Second).Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 500
+}
+
 func randomHex(n int) (string, error) {
 	b := make([]byte, n)
 	if _, err := rand.Read(b); err != nil {
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 )
 
 // ServiceStatus holds runtime info about a locally managed service.
@@ -305,8 +307,9 @@ func localStartAPI(installDir string, cfg *Config) error {
 	os.WriteFile(pidFile, []byte(fmt.Sprintf("%d", startCmd.Process.Pid)), 0644)
 
 	// Wait for API to be ready
-	if err := waitForHTTP(fmt.Sprintf("http://localhost:%s/v1/health", orDefaultStr(cfg.APIPort, "3000")), 30*time.Second); err != nil {
-		return fmt.Errorf("API started but not responding after 30s (check %s/api.log)", apiDir)
+	timeout := cfg.serviceTimeout(30 * time.Second)
+	if _, err := waitForHTTP(cfg, fmt.Sprintf("http://localhost:%s/v1/health", orDefaultStr(cfg.APIPort, "3000")), timeout); err != nil {
+		return fmt.Errorf("API started but not responding after %s (check %s/api.log)", timeout, apiDir)
 	}
 	return nil
 }
@@ -383,14 +386,14 @@ func localStartUI(installDir string, cfg *Config) error {
 	return nil
 }
 
-// readTokenFromConfig reads the API token from the CLI config file.
+// readTokenFromConfig reads the API token from the CLI config file
+// (honouring --config, REPOSWARM_CONFIG_HOME and XDG_CONFIG_HOME).
 func readTokenFromConfig(installDir string) string {
-	// Try reading from ~/.reposwarm/config.json
-	home, err := os.UserHomeDir()
+	path, err := config.ConfigPath()
 	if err != nil {
 		return ""
 	}
-	data, err := os.ReadFile(filepath.Join(home, ".reposwarm", "config.json"))
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,109 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LocalServices are the services SetupLocal can start, in dependency order.
+var LocalServices = []string{"temporal", "api", "worker", "ui"}
+
+// localServiceDeps lists what each service needs running alongside it.
+var localServiceDeps = map[string][]string{
+	"api":    {"temporal"},
+	"worker": {"temporal"},
+	"ui":     {"api"},
+}
+
+// composeServicesFor maps a local service to its docker-compose services.
+var composeServicesFor = map[string][]string{
+	"temporal": {"postgres", "temporal", "temporal-ui"},
+	"api":      {"dynamodb-local", "api"},
+	"worker":   {"dynamodb-local", "worker"},
+	"ui":       {"ui"},
+}
+
+// ResolveSkip turns --skip / --only values into the list of services to skip.
+// Names are validated, and a service can't be skipped while one that depends
+// on it is kept.
+func ResolveSkip(skip, only []string) ([]string, error) {
+	if len(skip) > 0 && len(only) > 0 {
+		return nil, fmt.Errorf("--skip and --only can't be combined")
+	}
+	for _, name := range append(append([]string{}, skip...), only...) {
+		if !isLocalService(name) {
+			return nil, fmt.Errorf("unknown service %q (valid: %s)", name, strings.Join(LocalServices, ", "))
+		}
+	}
+
+	skipped := map[string]bool{}
+	for _, s := range skip {
+		skipped[s] = true
+	}
+	if len(only) > 0 {
+		keep := map[string]bool{}
+		for _, s := range only {
+			keep[s] = true
+		}
+		for _, s := range LocalServices {
+			skipped[s] = !keep[s]
+		}
+	}
+
+	var result []string
+	for _, s := range LocalServices {
+		if !skipped[s] {
+			for _, dep := range localServiceDeps[s] {
+				if skipped[dep] {
+					return nil, fmt.Errorf("%s requires %s — it can't be skipped", s, dep)
+				}
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+	if len(result) == len(LocalServices) {
+		return nil, fmt.Errorf("nothing to set up: every service is skipped")
+	}
+	return result, nil
+}
+
+func isLocalService(name string) bool {
+	for _, s := range LocalServices {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Skipped reports whether svc was left out via --skip / --only.
+func (c *Config) Skipped(svc string) bool {
+	for _, s := range c.Skip {
+		if s == svc {
+			return true
+		}
+	}
+	return false
+}
+
+// composeServices returns the docker-compose services to start, or nil for all.
+func (c *Config) composeServices() []string {
+	if len(c.Skip) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	var services []string
+	for _, svc := range LocalServices {
+		if c.Skipped(svc) {
+			continue
+		}
+		for _, cs := range composeServicesFor[svc] {
+			if !seen[cs] {
+				seen[cs] = true
+				services = append(services, cs)
+			}
+		}
+	}
+	return services
+}
@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+)
+
+func TestResolveSkip(t *testing.T) {
+	tests := []struct {
+		name    string
+		skip    []string
+		only    []string
+		want    string
+		wantErr string
+	}{
+		{"nothing skipped", nil, nil, "", ""},
+		{"skip ui and worker", []string{"ui", "worker"}, nil, "worker,ui", ""},
+		{"only backend", nil, []string{"temporal", "api"}, "worker,ui", ""},
+		{"unknown service", []string{"redis"}, nil, "", "unknown service"},
+		{"dependency kept", []string{"api"}, nil, "", "ui requires api"},
+		{"only needs deps", nil, []string{"api"}, "", "api requires temporal"},
+		{"both flags", []string{"ui"}, []string{"api"}, "", "can't be combined"},
+		{"everything skipped", []string{"temporal", "api", "worker", "ui"}, nil, "", "nothing to set up"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSkip(tt.skip, tt.only)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveSkip() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveSkip(): %v", err)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("ResolveSkip() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeServices(t *testing.T) {
+	if got := (&Config{}).composeServices(); got != nil {
+		t.Errorf("no skips should start every service, got %v", got)
+	}
+
+	cfg := &Config{Skip: []string{"worker", "ui"}}
+	got := strings.Join(cfg.composeServices(), ",")
+	if got != "postgres,temporal,temporal-ui,dynamodb-local,api" {
+		t.Errorf("composeServices() = %s", got)
+	}
+}
+
+func TestReadTokenFromConfigHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.ConfigHomeEnv, dir)
+	os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"apiToken": "from-config-home"}`), 0600)
+	if got := readTokenFromConfig(""); got != "from-config-home" {
+		t.Errorf("readTokenFromConfig() = %q, want from-config-home", got)
+	}
+}
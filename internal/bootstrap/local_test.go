@@ -176,3 +176,82 @@ func TestRemoveComposeWorkerMount_PreservesOtherMounts(t *testing.T) {
 		t.Error("config-data:/data volume mount should have been preserved")
 	}
 }
+
+// nopPrinter discards setup output in tests.
+type nopPrinter struct{}
+
+func (nopPrinter) Section(string)        {}
+func (nopPrinter) Info(string)           {}
+func (nopPrinter) Success(string)        {}
+func (nopPrinter) Warning(string)        {}
+func (nopPrinter) Error(string)          {}
+func (nopPrinter) Printf(string, ...any) {}
+
+func TestWriteComposeFiles(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.yml")
+	override := filepath.Join(dir, "limits.yml")
+	os.WriteFile(custom, []byte("services: {}\n"), 0644)
+	os.WriteFile(override, []byte("services:\n  worker:\n    mem_limit: 4g\n"), 0644)
+
+	tests := []struct {
+		name         string
+		cfg          Config
+		wantCompose  string
+		wantOverride string
+	}{
+		{"generated", Config{}, TemporalComposeLocal(), ""},
+		{"override", Config{ComposeOverride: override}, TemporalComposeLocal(), "mem_limit: 4g"},
+		{"custom file", Config{ComposeFile: custom}, "services: {}\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			composeDir := t.TempDir()
+			log := NewInstallLog(composeDir)
+			defer log.Close()
+			// An override left by an earlier setup must not survive
+			os.WriteFile(filepath.Join(composeDir, "docker-compose.override.yml"), []byte("stale: true\n"), 0644)
+
+			if err := writeComposeFiles(composeDir, &tt.cfg, nopPrinter{}, log); err != nil {
+				t.Fatalf("writeComposeFiles: %v", err)
+			}
+			got, _ := os.ReadFile(filepath.Join(composeDir, "docker-compose.yml"))
+			if string(got) != tt.wantCompose {
+				t.Errorf("docker-compose.yml mismatch (len %d, want %d)", len(got), len(tt.wantCompose))
+			}
+			ov, err := os.ReadFile(filepath.Join(composeDir, "docker-compose.override.yml"))
+			if tt.wantOverride == "" {
+				if err == nil {
+					t.Error("unexpected docker-compose.override.yml")
+				}
+			} else if !strings.Contains(string(ov), tt.wantOverride) {
+				t.Errorf("override = %q, want it to contain %q", ov, tt.wantOverride)
+			}
+		})
+	}
+
+	missing := &Config{ComposeOverride: filepath.Join(dir, "nope.yml")}
+	if err := writeComposeFiles(t.TempDir(), missing, nopPrinter{}, NewInstallLog(t.TempDir())); err == nil {
+		t.Error("missing override file should fail")
+	}
+}
+
+// TestRecordFailure verifies a failed setup always names the failing step
+// and why it failed.
+func TestRecordFailure(t *testing.T) {
+	r := &LocalSetupResult{Steps: []LocalStepResult{{"prerequisites", "ok", ""}, {"disk", "fail", ""}}}
+	r.recordFailure(os.ErrPermission)
+	if got := r.Steps[1].Message; got != os.ErrPermission.Error() {
+		t.Errorf("failed step message = %q, want the error", got)
+	}
+	if r.Error == "" || r.Success {
+		t.Errorf("result = %+v, want an error and success=false", r)
+	}
+
+	r = &LocalSetupResult{Steps: []LocalStepResult{{"directories", "ok", "/tmp/x"}}}
+	r.recordFailure(os.ErrNotExist)
+	last := r.Steps[len(r.Steps)-1]
+	if last.Status != "fail" || last.Message == "" {
+		t.Errorf("last step = %+v, want a failed step with a message", last)
+	}
+}
@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SetupStateFile records which SetupLocal steps completed, so a failed setup
+// can be re-run without redoing finished work.
+const SetupStateFile = ".reposwarm-setup-state.json"
+
+// SetupState is the persisted progress of a local setup.
+type SetupState struct {
+	// Token is reused across runs so completed steps stay consistent with
+	// the credentials they were configured with.
+	Token     string            `json:"token,omitempty"`
+	Completed map[string]string `json:"completed"` // step name → RFC3339 completion time
+
+	path string
+}
+
+// LoadSetupState reads the setup state from installDir. A missing or
+// unreadable file yields an empty state.
+func LoadSetupState(installDir string) *SetupState {
+	s := &SetupState{
+		Completed: map[string]string{},
+		path:      filepath.Join(installDir, SetupStateFile),
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s
+	}
+	if json.Unmarshal(data, s) != nil || s.Completed == nil {
+		s.Token = ""
+		s.Completed = map[string]string{}
+	}
+	return s
+}
+
+// Done reports whether step completed in a previous run.
+func (s *SetupState) Done(step string) bool {
+	_, ok := s.Completed[step]
+	return ok
+}
+
+// MarkDone records step as completed and persists the state.
+func (s *SetupState) MarkDone(step string) error {
+	s.Completed[step] = time.Now().UTC().Format(time.RFC3339)
+	return s.Save()
+}
+
+// Reset forgets all completed steps (used by --force).
+func (s *SetupState) Reset() {
+	s.Token = ""
+	s.Completed = map[string]string{}
+}
+
+// Save writes the state file. It holds the API token, so it's owner-only.
+func (s *SetupState) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing setup state: %w", err)
+	}
+	return nil
+}
+
+// installMarkers are entries only a RepoSwarm install directory has.
+var installMarkers = []string{"api", "worker", ComposeSubDir, SetupStateFile}
+
+// cliEntries are files the CLI keeps in ~/.reposwarm, which is also the
+// default install directory, plus the guides and logs 'new' writes into the
+// install directory (even when setup fails early), so they don't make it
+// "foreign".
+var cliEntries = map[string]bool{
+	"config.json": true, "cache": true, "providers.json": true, "logs": true,
+	"INSTALL.md": true, "REPOSWARM_INSTALL.md": true, SetupLogFile: true,
+}
+
+// ForeignEntries lists what's in dir when it holds neither a previous
+// RepoSwarm install nor only CLI files. A missing or empty dir, or a prior
+// install, yields nil, so installing there can't clobber unrelated files.
+func ForeignEntries(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	for _, m := range installMarkers {
+		if _, err := os.Stat(filepath.Join(dir, m)); err == nil {
+			return nil
+		}
+	}
+	var foreign []string
+	for _, e := range entries {
+		if !cliEntries[e.Name()] {
+			foreign = append(foreign, e.Name())
+		}
+	}
+	return foreign
+}
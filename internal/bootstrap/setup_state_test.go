@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	s := LoadSetupState(dir)
+	if s.Done("docker-compose") {
+		t.Fatal("fresh state should have no completed steps")
+	}
+	s.Token = "tok"
+	if err := s.MarkDone("docker-compose"); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, SetupStateFile))
+	if err != nil {
+		t.Fatalf("state file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("state file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded := LoadSetupState(dir)
+	if !loaded.Done("docker-compose") || loaded.Token != "tok" {
+		t.Errorf("reloaded state = %+v, want docker-compose done with token", loaded)
+	}
+
+	loaded.Reset()
+	if loaded.Done("docker-compose") || loaded.Token != "" {
+		t.Error("Reset should clear steps and token")
+	}
+}
+
+func TestLoadSetupStateCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, SetupStateFile), []byte("{not json"), 0600)
+
+	s := LoadSetupState(dir)
+	if len(s.Completed) != 0 || s.Token != "" {
+		t.Errorf("corrupt state should load empty, got %+v", s)
+	}
+}
+
+func TestForeignEntries(t *testing.T) {
+	dir := t.TempDir()
+	if got := ForeignEntries(filepath.Join(dir, "missing")); got != nil {
+		t.Errorf("missing dir: got %v, want nil", got)
+	}
+	os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(dir, "INSTALL.md"), nil, 0644)
+	os.WriteFile(filepath.Join(dir, "REPOSWARM_INSTALL.md"), nil, 0644)
+	if got := ForeignEntries(dir); got != nil {
+		t.Errorf("CLI files and guides only: got %v, want nil", got)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644)
+	if got := ForeignEntries(dir); len(got) != 1 || got[0] != "notes.txt" {
+		t.Errorf("unrelated file: got %v, want [notes.txt]", got)
+	}
+	os.Mkdir(filepath.Join(dir, "worker"), 0755)
+	if got := ForeignEntries(dir); got != nil {
+		t.Errorf("prior install: got %v, want nil", got)
+	}
+}
+
+func TestForeignEntriesAfterEarlyFailure(t *testing.T) {
+	// A run that fails before saving setup state still leaves its logs
+	dir := t.TempDir()
+	NewInstallLog(dir).Close()
+	if got := ForeignEntries(dir); got != nil {
+		t.Errorf("rerun after a failed setup: got %v, want nil", got)
+	}
+}
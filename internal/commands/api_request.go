@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newAPICmd() *cobra.Command {
+	var data string
+
+	cmd := &cobra.Command{
+		Use:   "api <METHOD> <path> [body]",
+		Short: "Advanced: send an authenticated request to any API endpoint",
+		Long: `Send a request to the RepoSwarm API with the configured URL, token and
+headers, and print the response body as-is (pretty-printed when it's JSON).
+
+For debugging and exploring endpoints the CLI doesn't wrap yet. The path is
+relative to apiUrl. The body is JSON, given inline or with --data; use
+--data @file.json to read it from a file, or --data @- for stdin.
+
+Examples:
+  reposwarm api GET /repos
+  reposwarm api GET "/workflows?pageSize=5"
+  reposwarm api POST /repos '{"name":"my-repo","url":"https://github.com/acme/my-repo"}'
+  reposwarm api PUT /prompts/hl_overview --data @prompt.json`,
+		Hidden: true,
+		Args:   friendlyRangeArgs(2, 3, "reposwarm api <METHOD> <path> [body]\n\nExample:\n  reposwarm api GET /repos"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			method := strings.ToUpper(args[0])
+			switch method {
+			case "GET", "POST", "PUT", "PATCH", "DELETE":
+			default:
+				return fmt.Errorf("unsupported method %q (use GET, POST, PUT, PATCH or DELETE)", args[0])
+			}
+			path := args[1]
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+
+			if len(args) == 3 {
+				if data != "" {
+					return fmt.Errorf("give the body as an argument or with --data, not both")
+				}
+				data = args[2]
+			}
+			var body any
+			if data != "" {
+				raw, err := readRequestBody(data)
+				if err != nil {
+					return err
+				}
+				body = raw
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+			var resp api.RawBody
+			if err := client.Request(ctx(), method, path, body, &resp); err != nil {
+				return err
+			}
+
+			var pretty bytes.Buffer
+			if json.Indent(&pretty, resp, "", "  ") == nil {
+				pretty.WriteByte('\n')
+				_, err = os.Stdout.Write(pretty.Bytes())
+				return err
+			}
+			_, err = os.Stdout.Write(resp)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&data, "data", "d", "", "JSON request body, or @file / @- to read it")
+	return cmd
+}
+
+// readRequestBody resolves --data: inline JSON, @file or @- (stdin).
+func readRequestBody(data string) (json.RawMessage, error) {
+	b := []byte(data)
+	if name, ok := strings.CutPrefix(data, "@"); ok {
+		var err error
+		if name == "-" {
+			b, err = io.ReadAll(os.Stdin)
+		} else {
+			b, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+	}
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("request body is not valid JSON")
+	}
+	return json.RawMessage(b), nil
+}
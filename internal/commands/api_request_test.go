@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAPICmdPrintsRawResponse(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos":  []map[string]any{{"name": "left-pad"}},
+		"POST /repos": map[string]any{"created": true},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "api", "get", "repos")
+	if err != nil {
+		t.Fatalf("api GET /repos: %v", err)
+	}
+	// The {data: ...} envelope is kept
+	var envelope struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &envelope); err != nil || len(envelope.Data) != 1 {
+		t.Fatalf("expected the raw envelope, got %q (%v)", out, err)
+	}
+	if strings.Contains(out, "--for-agent") {
+		t.Errorf("raw output should not include the agent hint:\n%s", out)
+	}
+
+	body := filepath.Join(t.TempDir(), "body.json")
+	os.WriteFile(body, []byte(`{"name":"left-pad"}`), 0600)
+	out, err = runCmd(t, "api", "POST", "/repos", "--data", "@"+body)
+	if err != nil {
+		t.Fatalf("api POST --data @file: %v", err)
+	}
+	if !strings.Contains(out, `"created": true`) {
+		t.Errorf("unexpected POST output:\n%s", out)
+	}
+
+	if _, err := runCmd(t, "api", "POST", "/repos", "{not json"); err == nil || !strings.Contains(err.Error(), "not valid JSON") {
+		t.Errorf("expected invalid JSON error, got %v", err)
+	}
+	if _, err := runCmd(t, "api", "FETCH", "/repos"); err == nil {
+		t.Error("expected unsupported method error")
+	}
+}
@@ -452,7 +452,7 @@ func runSimpleAsk(client *api.Client, question string) error {
 	}
 
 	err := client.Post(ctx(), "/ask", map[string]string{"question": question}, &resp)
-	if err != nil {
+	if err != nil && !isBodyFailure(err) {
 		if flagJSON {
 			return output.JSON(map[string]any{"success": false, "error": err.Error()})
 		}
@@ -522,7 +522,7 @@ func runArchAsk(client *api.Client, question, repos, adapter string, noWait bool
 	}
 
 	err := client.Post(ctx(), "/ask/arch", body, &submitResp)
-	if err != nil {
+	if err != nil && !isBodyFailure(err) {
 		if flagJSON {
 			return output.JSON(map[string]any{"success": false, "error": err.Error()})
 		}
@@ -577,7 +577,13 @@ func runArchAsk(client *api.Client, question, repos, adapter string, noWait bool
 		}
 
 		err := client.Get(ctx(), fmt.Sprintf("/ask/arch/%s", askID), &pollResp)
-		if err != nil {
+		if isBodyFailure(err) {
+			// A {success: false} body ends the ask like a failed status
+			pollResp.Status = "failed"
+			if pollResp.Error == "" {
+				pollResp.Error = err.Error()
+			}
+		} else if err != nil {
 			if flagJSON {
 				return output.JSON(map[string]any{
 					"success": false,
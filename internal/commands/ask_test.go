@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -106,3 +108,27 @@ func TestAskCmdNoArgs(t *testing.T) {
 		t.Fatal("expected error with no arguments")
 	}
 }
+
+func TestArchAskPollBodyFailure(t *testing.T) {
+	server, cleanup := testServer(t, nil)
+	defer cleanup()
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "askId": "a1", "status": "pending"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "askId": "a1", "status": "failed", "error": "model timed out"})
+	})
+
+	out, err := runCmd(t, "ask", "--arch", "how is auth done?", "--json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\noutput: %s", err, out)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got["status"] != "failed" || got["error"] != "model timed out" {
+		t.Errorf("got %v, want status failed with the server's error", got)
+	}
+}
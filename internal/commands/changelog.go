@@ -262,8 +262,12 @@ func fetchAllReleases(limit int) ([]releaseEntry, error) {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", userAgent())
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client, err := newHTTPClient(10 * time.Second)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
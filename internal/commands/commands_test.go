@@ -3,11 +3,20 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
 )
 
 // testServer creates a mock API server with route handlers.
@@ -46,6 +55,7 @@ func testServer(t *testing.T, routes map[string]any) (*httptest.Server, func())
 	dir := t.TempDir()
 	origHome := os.Getenv("HOME")
 	os.Setenv("HOME", dir)
+	t.Setenv(config.ConfigHomeEnv, "")
 	cleanup := func() {
 		os.Setenv("HOME", origHome)
 		server.Close()
@@ -182,6 +192,27 @@ func TestReposListCmdJSON(t *testing.T) {
 	}
 }
 
+func TestReposListJSONFields(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "repo1", "source": "CodeCommit", "enabled": true, "url": "https://example.com/repo1"},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "list", "--json", "--fields", "name,enabled")
+	if err != nil {
+		t.Fatalf("repos list --json --fields: %v", err)
+	}
+	var repos []map[string]any
+	if err := json.Unmarshal([]byte(out), &repos); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(repos) != 1 || len(repos[0]) != 2 || repos[0]["name"] != "repo1" || repos[0]["enabled"] != true {
+		t.Errorf("projected repos = %v, want only name and enabled", repos)
+	}
+}
+
 func TestReposListFilterSource(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"GET /repos": []map[string]any{
@@ -203,6 +234,48 @@ func TestReposListFilterSource(t *testing.T) {
 	}
 }
 
+func TestReposListWide(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "left-pad", "source": "GitHub", "enabled": true, "url": "https://github.com/acme/left-pad", "description": "Pads strings"},
+		},
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{{"name": "left-pad", "lastUpdated": "2026-01-02T03:04:05Z"}},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "list", "--for-agent")
+	if err != nil {
+		t.Fatalf("repos list: %v", err)
+	}
+	if strings.Contains(out, "https://github.com/acme/left-pad") {
+		t.Errorf("compact listing should not include the URL:\n%s", out)
+	}
+
+	out, err = runCmd(t, "repos", "list", "--wide", "--for-agent")
+	if err != nil {
+		t.Fatalf("repos list --wide: %v", err)
+	}
+	for _, want := range []string{"URL", "Last Investigated", "https://github.com/acme/left-pad", "Pads strings", "2026-01-02T03:04:05Z"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("wide listing missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCmd(t, "repos", "list", "--columns", "name,url", "--for-agent")
+	if err != nil {
+		t.Fatalf("repos list --columns: %v", err)
+	}
+	if !strings.Contains(out, "https://github.com/acme/left-pad") || strings.Contains(out, "Source") {
+		t.Errorf("--columns name,url should show only those columns:\n%s", out)
+	}
+
+	if _, err := runCmd(t, "repos", "list", "--columns", "name,bogus"); err == nil || !strings.Contains(err.Error(), "unknown column") {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
 func TestDiscoverCmd(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"POST /repos/discover": map[string]any{
@@ -240,6 +313,23 @@ func TestDiscoverCmdJSON(t *testing.T) {
 	}
 }
 
+func TestDiscoverCmdSuccessFalse(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"POST /repos/discover": map[string]any{
+			"success": false, "error": "no CodeCommit access",
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover")
+	if err == nil || !strings.Contains(err.Error(), "no CodeCommit access") {
+		t.Fatalf("expected discovery error, got %v", err)
+	}
+	if strings.Contains(out, "Discovered") {
+		t.Errorf("should not report success: %s", out)
+	}
+}
+
 func TestWorkflowsListCmd(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"/workflows": map[string]any{
@@ -260,6 +350,36 @@ func TestWorkflowsListCmd(t *testing.T) {
 	}
 }
 
+func TestWorkflowsListJSONL(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/workflows": map[string]any{
+			"executions": []map[string]any{
+				{"workflowId": "wf-1", "status": "Running"},
+				{"workflowId": "wf-2", "status": "Completed"},
+			},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "workflows", "list", "--jsonl")
+	if err != nil {
+		t.Fatalf("workflows list --jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), out)
+	}
+	for i, line := range lines {
+		var wf map[string]any
+		if err := json.Unmarshal([]byte(line), &wf); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", i, err)
+		}
+		if want := fmt.Sprintf("wf-%d", i+1); wf["workflowId"] != want {
+			t.Errorf("line %d workflowId = %v, want %s", i, wf["workflowId"], want)
+		}
+	}
+}
+
 func TestWorkflowsStatusCmd(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"GET /workflows/wf-1": map[string]any{
@@ -337,6 +457,97 @@ func TestResultsReadSectionCmd(t *testing.T) {
 	}
 }
 
+func TestResultsReadAllRawStreamsInOrder(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki/is-odd": map[string]any{
+			"repo": "is-odd",
+			"sections": []map[string]any{
+				{"id": "hl_overview", "label": "Overview", "createdAt": "2026-01-01"},
+				{"id": "dependencies", "label": "Dependencies", "createdAt": "2026-01-01"},
+			},
+			"hasDocs": true,
+		},
+		"GET /wiki/is-odd/hl_overview": map[string]any{
+			"repo": "is-odd", "section": "hl_overview", "content": "overview body",
+		},
+		"GET /wiki/is-odd/dependencies": map[string]any{
+			"repo": "is-odd", "section": "dependencies", "content": "deps body",
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "read", "is-odd", "--raw")
+	if err != nil {
+		t.Fatalf("results read --raw: %v", err)
+	}
+	overview := strings.Index(out, "## hl_overview\n\noverview body")
+	deps := strings.Index(out, "## dependencies\n\ndeps body")
+	if overview < 0 || deps < 0 || overview > deps {
+		t.Errorf("sections missing or out of order: %q", out)
+	}
+}
+
+func TestFilterSections(t *testing.T) {
+	index := []api.WikiSection{{ID: "hl_overview"}, {StepName: "DBs"}, {ID: "security_check"}}
+	tests := []struct {
+		name    string
+		filter  string
+		want    []string
+		missing []string
+	}{
+		{"no filter", "", []string{"hl_overview", "DBs", "security_check"}, nil},
+		{"keeps index order", "security_check, DBs", []string{"DBs", "security_check"}, nil},
+		{"unknown ignored", "nope,hl_overview", []string{"hl_overview"}, []string{"nope"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing := missingSections{}
+			var got []string
+			for _, s := range filterSections("repo", index, parseSectionFilter(tt.filter), missing) {
+				got = append(got, s.Name())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filterSections(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+			if strings.Join(missing["repo"], ",") != strings.Join(tt.missing, ",") {
+				t.Errorf("filterSections(%q) missing = %v, want %v", tt.filter, missing["repo"], tt.missing)
+			}
+		})
+	}
+}
+
+func TestResultsOpenPrint(t *testing.T) {
+	tests := []struct {
+		name string
+		set  []string
+		want string
+	}{
+		{"default port", nil, "http://localhost:3001/wiki/is-odd"},
+		{"ui port", []string{"uiPort", "4000"}, "http://localhost:4000/wiki/is-odd"},
+		{"ui url", []string{"uiUrl", "https://ui.example.com/"}, "https://ui.example.com/wiki/is-odd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cleanup := testServer(t, nil)
+			defer cleanup()
+			if tt.set != nil {
+				if _, err := runCmd(t, append([]string{"config", "set"}, tt.set...)...); err != nil {
+					t.Fatalf("config set: %v", err)
+				}
+			}
+
+			out, err := runCmd(t, "results", "open", "is-odd", "--print")
+			if err != nil {
+				t.Fatalf("results open: %v", err)
+			}
+			// Human mode appends the agent hint after the URL
+			if url := strings.SplitN(out, "\n", 2)[0]; url != tt.want {
+				t.Errorf("url = %q, want %q", url, tt.want)
+			}
+		})
+	}
+}
+
 func TestResultsMetaCmdJSON(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"GET /wiki/is-odd/hl_overview": map[string]any{
@@ -396,6 +607,49 @@ func TestPromptsListEnabledFilter(t *testing.T) {
 	if len(prompts) != 1 {
 		t.Errorf("got %d prompts, want 1 (only enabled)", len(prompts))
 	}
+
+	// A --filter matching nothing reports no prompts, not derived sections
+	out, err = runCmd(t, "prompts", "list", "--filter", "type=nope", "--json")
+	if err != nil {
+		t.Fatalf("prompts list --filter: %v", err)
+	}
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty list, got %s", out)
+	}
+}
+
+func TestPromptsListConfigDefault(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /prompts": []map[string]any{
+			{"name": "overview", "type": "base", "enabled": true, "order": 1, "version": 2},
+			{"name": "security", "type": "base", "enabled": false, "order": 5, "version": 1},
+		},
+	})
+	defer cleanup()
+
+	if _, err := runCmd(t, "config", "set", "promptListDefault", "enabled"); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{nil, 1},
+		{[]string{"--all"}, 2},
+		{[]string{"--disabled"}, 1},
+	}
+	for _, tt := range tests {
+		out, err := runCmd(t, append([]string{"prompts", "list", "--json"}, tt.args...)...)
+		if err != nil {
+			t.Fatalf("prompts list %v: %v", tt.args, err)
+		}
+		var prompts []map[string]any
+		json.Unmarshal([]byte(out), &prompts)
+		if len(prompts) != tt.want {
+			t.Errorf("prompts list %v: got %d prompts, want %d", tt.args, len(prompts), tt.want)
+		}
+	}
 }
 
 func TestPromptsShowCmd(t *testing.T) {
@@ -458,6 +712,38 @@ func TestPromptsTypesCmd(t *testing.T) {
 	}
 }
 
+func TestPromptsToggleType(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /prompts/types/detection": []map[string]any{
+			{"name": "secrets", "type": "detection", "enabled": true},
+			{"name": "pii", "type": "detection", "enabled": true},
+			{"name": "licenses", "type": "detection", "enabled": false},
+		},
+		"PATCH /prompts/secrets/toggle": map[string]any{"name": "secrets", "enabled": false},
+		"PATCH /prompts/pii/toggle":     map[string]any{"name": "pii", "enabled": false},
+	})
+	defer cleanup()
+
+	if _, err := runCmd(t, "prompts", "toggle", "--type", "detection"); err == nil {
+		t.Error("expected an error without --on/--off")
+	}
+
+	out, err := runCmd(t, "prompts", "toggle", "--type", "detection", "--off", "-y", "--json")
+	if err != nil {
+		t.Fatalf("prompts toggle --type --off: %v", err)
+	}
+	var result struct {
+		Changed   []string `json:"changed"`
+		Unchanged int      `json:"unchanged"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if strings.Join(result.Changed, ",") != "secrets,pii" || result.Unchanged != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
 func TestServerConfigShowCmd(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"GET /config": map[string]any{
@@ -508,6 +794,126 @@ func TestConfigShowCmdJSON(t *testing.T) {
 	}
 }
 
+func TestConfigPathJSON(t *testing.T) {
+	_, cleanup := testServer(t, nil)
+	defer cleanup()
+
+	out, err := runCmd(t, "config", "path", "--json")
+	if err != nil {
+		t.Fatalf("config path --json: %v", err)
+	}
+	var paths map[string]string
+	if err := json.Unmarshal([]byte(out), &paths); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	home := os.Getenv("HOME")
+	want := map[string]string{
+		"configFile": filepath.Join(home, ".reposwarm", "config.json"),
+		"configDir":  filepath.Join(home, ".reposwarm"),
+		"cacheDir":   filepath.Join(home, ".reposwarm", "cache"),
+	}
+	for k, v := range want {
+		if paths[k] != v {
+			t.Errorf("%s = %q, want %q", k, paths[k], v)
+		}
+	}
+}
+
+func TestConfigFlag(t *testing.T) {
+	server, cleanup := testServer(t, map[string]any{
+		"GET /repos": []any{},
+	})
+	defer cleanup()
+	t.Cleanup(func() { config.SetPath("") })
+
+	alt := filepath.Join(t.TempDir(), "staging", "config.json")
+	if _, err := runCmd(t, "config", "set", "apiUrl", server.URL, "--config", alt); err != nil {
+		t.Fatalf("config set --config: %v", err)
+	}
+	if _, err := runCmd(t, "config", "set", "apiToken", "staging-token", "--config", alt); err != nil {
+		t.Fatalf("config set --config: %v", err)
+	}
+
+	// The default config is untouched and still works
+	if _, err := runCmd(t, "repos", "list", "--json"); err != nil {
+		t.Fatalf("repos list with default config: %v", err)
+	}
+	if _, err := runCmd(t, "repos", "list", "--json", "--config", alt); err == nil {
+		t.Error("expected 401 with the staging token from --config")
+	}
+
+	out, err := runCmd(t, "config", "path", "--json", "--config", alt)
+	if err != nil {
+		t.Fatalf("config path --config: %v", err)
+	}
+	var paths map[string]string
+	json.Unmarshal([]byte(out), &paths)
+	if paths["configFile"] != alt {
+		t.Errorf("configFile = %q, want %q", paths["configFile"], alt)
+	}
+}
+
+func TestConfigInitNonInteractive(t *testing.T) {
+	server, cleanup := testServer(t, map[string]any{
+		"/health": map[string]any{"status": "healthy", "version": "1.0.0"},
+	})
+	defer cleanup()
+
+	if _, err := runCmd(t, "config", "init", "--non-interactive"); err == nil || !strings.Contains(err.Error(), "--api-token") {
+		t.Fatalf("expected missing token error, got %v", err)
+	}
+	if _, err := runCmd(t, "config", "init", "--api-token", "t", "--non-interactive", "--no-test"); err == nil || !strings.Contains(err.Error(), "--api-url") {
+		t.Fatalf("expected missing URL error, got %v", err)
+	}
+	out, err := runCmd(t, "config", "init", "--api-token", "t", "--no-test")
+	if err != nil || !strings.Contains(out, "default") {
+		t.Fatalf("expected a default URL warning, got %q, %v", out, err)
+	}
+
+	if _, err := runCmd(t, "config", "init", "--api-url", server.URL, "--api-token", "test-token"); err != nil {
+		t.Fatalf("config init with flags: %v", err)
+	}
+	if _, err := runCmd(t, "config", "init", "--api-url", "http://127.0.0.1:1", "--api-token", "scripted", "--no-test"); err != nil {
+		t.Fatalf("config init --no-test: %v", err)
+	}
+	home, _ := os.UserHomeDir()
+	data, err := os.ReadFile(home + "/.reposwarm/config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "http://127.0.0.1:1") || !strings.Contains(string(data), "scripted") {
+		t.Errorf("config not saved from flags: %s", data)
+	}
+}
+
+func TestConfigValidateCmd(t *testing.T) {
+	_, cleanup := testServer(t, nil)
+	defer cleanup()
+
+	if _, err := runCmd(t, "config", "validate"); err != nil {
+		t.Fatalf("config validate on good config: %v", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	os.WriteFile(home+"/.reposwarm/config.json", []byte(`{"apiUrl":"localhost:3000","apiToken":"t","outputFormat":"xml"}`), 0600)
+	out, err := runCmd(t, "config", "validate", "--json")
+	if err == nil {
+		t.Fatal("expected error for invalid config")
+	}
+	var result struct {
+		Valid    bool `json:"valid"`
+		Problems []struct {
+			Key string `json:"key"`
+		} `json:"problems"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Valid || len(result.Problems) != 2 || result.Problems[0].Key != "apiUrl" || result.Problems[1].Key != "outputFormat" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
 func TestVersionFlag(t *testing.T) {
 	root := NewRootCmd("1.2.3")
 	var buf bytes.Buffer
@@ -564,6 +970,99 @@ func TestInvestigateNoArgs(t *testing.T) {
 	}
 }
 
+func TestInvestigateAllDryRun(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "repo-a", "enabled": true},
+			{"name": "repo-b", "enabled": false},
+			{"name": "repo-c", "enabled": true},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "investigate", "--all", "--dry-run", "--force", "--model", "m1", "--json")
+	if err != nil {
+		t.Fatalf("investigate --all --dry-run: %v", err)
+	}
+
+	var result struct {
+		DryRun   bool     `json:"dryRun"`
+		Model    string   `json:"model"`
+		Repos    []string `json:"repos"`
+		Requests []struct {
+			RepoName string `json:"repo_name"`
+			Model    string `json:"model"`
+			Force    bool   `json:"force"`
+		} `json:"requests"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !result.DryRun || result.Model != "m1" {
+		t.Errorf("dryRun=%v model=%q", result.DryRun, result.Model)
+	}
+	if strings.Join(result.Repos, ",") != "repo-a,repo-c" {
+		t.Errorf("repos = %v, want enabled repos only", result.Repos)
+	}
+	if len(result.Requests) != 2 || result.Requests[0].RepoName != "repo-a" || result.Requests[0].Model != "m1" || !result.Requests[0].Force {
+		t.Errorf("requests = %+v", result.Requests)
+	}
+}
+
+func TestInvestigateStaleDryRun(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "fresh", "enabled": true},
+			{"name": "old", "enabled": true},
+			{"name": "missing", "enabled": true},
+			{"name": "disabled", "enabled": false},
+		},
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{
+				{"name": "fresh", "sectionCount": 17, "lastUpdated": recent},
+				{"name": "old", "sectionCount": 17, "lastUpdated": "2020-01-01T00:00:00Z"},
+				{"name": "disabled", "sectionCount": 0},
+			},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "investigate", "--stale", "--dry-run", "--force", "--json")
+	if err != nil {
+		t.Fatalf("investigate --stale --dry-run: %v", err)
+	}
+	var result struct {
+		Repos   []string          `json:"repos"`
+		Reasons map[string]string `json:"reasons"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if strings.Join(result.Repos, ",") != "old,missing" {
+		t.Errorf("repos = %v, want old,missing", result.Repos)
+	}
+	if result.Reasons["missing"] != "no results" || !strings.HasPrefix(result.Reasons["old"], "updated ") {
+		t.Errorf("reasons = %v", result.Reasons)
+	}
+
+	// A shorter cutoff also picks up the repo updated a day ago
+	out, err = runCmd(t, "investigate", "--stale=1h", "--dry-run", "--force", "--json")
+	if err != nil {
+		t.Fatalf("investigate --stale=1h: %v", err)
+	}
+	json.Unmarshal([]byte(out), &result)
+	if len(result.Repos) != 3 {
+		t.Errorf("repos = %v, want all three enabled repos", result.Repos)
+	}
+
+	// With a space the age becomes a positional repo name
+	_, err = runCmd(t, "investigate", "--stale", "30d", "--dry-run", "--force")
+	if err == nil || !strings.Contains(err.Error(), "--stale=30d") {
+		t.Errorf("expected a --stale=30d hint, got %v", err)
+	}
+}
+
 func TestDiffCmd(t *testing.T) {
 	_, cleanup := testServer(t, map[string]any{
 		"GET /wiki/repo1": map[string]any{
@@ -591,6 +1090,43 @@ func TestDiffCmd(t *testing.T) {
 	}
 }
 
+func TestDiffMatrix(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{{"name": "repo1"}, {"name": "repo2"}},
+		},
+		"GET /wiki/repo1": map[string]any{
+			"repo":     "repo1",
+			"sections": []map[string]any{{"id": "overview"}, {"id": "apis"}},
+		},
+		"GET /wiki/repo2": map[string]any{
+			"repo":     "repo2",
+			"sections": []map[string]any{{"id": "overview"}, {"id": "security"}},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "diff", "--matrix", "--json")
+	if err != nil {
+		t.Fatalf("results diff --matrix: %v", err)
+	}
+
+	var m map[string]map[string]bool
+	if err := json.Unmarshal([]byte(out), &m); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !m["repo1"]["overview"] || !m["repo1"]["apis"] || m["repo1"]["security"] {
+		t.Errorf("repo1 = %v", m["repo1"])
+	}
+	if !m["repo2"]["security"] || m["repo2"]["apis"] {
+		t.Errorf("repo2 = %v", m["repo2"])
+	}
+
+	if _, err := runCmd(t, "results", "diff", "--matrix", "repo1"); err == nil {
+		t.Error("expected error for --matrix with repo arguments")
+	}
+}
+
 func TestDoctorCmdRegistered(t *testing.T) {
 	root := NewRootCmd("test")
 	for _, c := range root.Commands() {
@@ -625,6 +1161,48 @@ func TestReposShowCmd(t *testing.T) {
 	}
 }
 
+func TestReposShowIncludesResults(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos/is-odd": map[string]any{
+			"name": "is-odd", "source": "GitHub", "enabled": true, "hasDocs": true,
+		},
+		"GET /wiki/is-odd": map[string]any{
+			"repo": "is-odd",
+			"sections": []map[string]any{
+				{"id": "hl_overview", "timestamp": 100, "createdAt": "2026-01-01T00:00:00Z"},
+				{"id": "dbs", "timestamp": 200, "createdAt": "2026-01-02T00:00:00Z"},
+			},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "show", "is-odd", "--json")
+	if err != nil {
+		t.Fatalf("repos show: %v", err)
+	}
+	var repo struct {
+		Name    string `json:"name"`
+		Results struct {
+			SectionCount int    `json:"sectionCount"`
+			LastUpdated  string `json:"lastUpdated"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &repo); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if repo.Name != "is-odd" || repo.Results.SectionCount != 2 || repo.Results.LastUpdated != "2026-01-02T00:00:00Z" {
+		t.Errorf("unexpected repos show output: %s", out)
+	}
+
+	human, err := runCmd(t, "repos", "show", "is-odd")
+	if err != nil {
+		t.Fatalf("repos show: %v", err)
+	}
+	if !strings.Contains(human, "Sections") || !strings.Contains(human, "2026-01-02") {
+		t.Errorf("human output missing results summary: %s", human)
+	}
+}
+
 func TestUpgradeCmdJSON(t *testing.T) {
 	root := NewRootCmd("1.0.0")
 	var buf bytes.Buffer
@@ -727,3 +1305,1039 @@ func TestNewCmdJSON(t *testing.T) {
 		t.Error("expected os in environment")
 	}
 }
+
+func TestWorkflowsStatusPrintURL(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/workflows/investigate-single-is-odd": api.WorkflowExecution{
+			WorkflowID: "investigate-single-is-odd",
+			RunID:      "run-1",
+			Status:     "Running",
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "workflows", "status", "investigate-single-is-odd", "--print")
+	if err != nil {
+		t.Fatalf("workflows status: %v", err)
+	}
+	want := "http://localhost:8233/namespaces/default/workflows/investigate-single-is-odd/run-1"
+	if got := strings.SplitN(out, "\n", 2)[0]; got != want {
+		t.Errorf("url = %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowsCancel(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"POST /workflows/wf-1/cancel":    map[string]any{"success": true},
+		"POST /workflows/wf-2/terminate": map[string]any{"success": true},
+	})
+	defer cleanup()
+
+	tests := []struct {
+		id         string
+		cancelled  bool
+		terminated bool
+	}{
+		{"wf-1", true, false},
+		{"wf-2", false, true}, // no cancel endpoint, falls back to terminate
+	}
+	for _, tt := range tests {
+		out, err := runCmd(t, "workflows", "cancel", tt.id, "-y", "--json")
+		if err != nil {
+			t.Fatalf("workflows cancel %s: %v", tt.id, err)
+		}
+		var result map[string]any
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+		}
+		if result["cancelled"] != tt.cancelled || (result["terminated"] == true) != tt.terminated {
+			t.Errorf("%s: result = %v", tt.id, result)
+		}
+	}
+}
+
+func TestResultsMetaRaw(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/wiki/is-odd/hl_overview": map[string]any{
+			"repo":         "is-odd",
+			"section":      "hl_overview",
+			"content":      "# Overview",
+			"referenceKey": "is-odd/hl_overview",
+			"storageKey":   "s3://bucket/is-odd/hl_overview.md",
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "meta", "is-odd", "hl_overview", "--raw")
+	if err != nil {
+		t.Fatalf("results meta --raw: %v", err)
+	}
+	var result map[string]any
+	if err := json.NewDecoder(strings.NewReader(out)).Decode(&result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result["storageKey"] != "s3://bucket/is-odd/hl_overview.md" {
+		t.Errorf("unmodeled field missing: %v", result)
+	}
+	if _, ok := result["content"]; ok {
+		t.Errorf("content should be omitted: %v", result)
+	}
+}
+
+func TestResultsExportAllRepos(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/wiki":                     map[string]any{"repos": []map[string]any{{"name": "is-odd"}, {"name": "is-even"}}},
+		"/wiki/is-odd":              map[string]any{"repo": "is-odd", "sections": []map[string]any{{"id": "hl_overview"}}},
+		"/wiki/is-even":             map[string]any{"repo": "is-even", "sections": []map[string]any{{"id": "hl_overview"}}},
+		"/wiki/is-odd/hl_overview":  map[string]any{"content": "odd"},
+		"/wiki/is-even/hl_overview": map[string]any{"content": "even"},
+	})
+	defer cleanup()
+
+	dir := t.TempDir()
+	out, err := runCmd(t, "results", "export", "--all-repos", "-d", dir, "--json")
+	if err != nil {
+		t.Fatalf("results export --all-repos: %v", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result["files"] != float64(3) {
+		t.Errorf("files = %v, want 3 (2 repos + index)", result["files"])
+	}
+	index, err := os.ReadFile(dir + "/index.md")
+	if err != nil {
+		t.Fatalf("index not written: %v", err)
+	}
+	if !strings.Contains(string(index), "[is-odd](is-odd.arch.md)") {
+		t.Errorf("index missing repo link:\n%s", index)
+	}
+}
+
+func TestDiscoverCmdMatchDryRun(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "team-a-api"}, {"name": "team-a-ui"}, {"name": "team-b-api"},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--prefix", "team-a-", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("repos discover --dry-run: %v", err)
+	}
+	var result struct {
+		Include []string `json:"include"`
+		Exclude []string `json:"exclude"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if len(result.Include) != 2 || len(result.Exclude) != 1 || result.Exclude[0] != "team-b-api" {
+		t.Errorf("include = %v, exclude = %v", result.Include, result.Exclude)
+	}
+}
+
+// auditRoutes serves /wiki and /wiki/<repo> for repos with the given sections.
+func auditRoutes(repoSections map[string][]string) map[string]any {
+	routes := map[string]any{}
+	var repos []map[string]any
+	for name, ids := range repoSections {
+		repos = append(repos, map[string]any{"name": name})
+		var sections []map[string]any
+		for _, id := range ids {
+			sections = append(sections, map[string]any{"id": id})
+		}
+		routes["/wiki/"+name] = map[string]any{"repo": name, "sections": sections}
+	}
+	routes["/wiki"] = map[string]any{"repos": repos}
+	return routes
+}
+
+func TestResultsAuditConcurrent(t *testing.T) {
+	_, cleanup := testServer(t, auditRoutes(map[string][]string{
+		"repo-c": {"hl_overview", "apis"},
+		"repo-a": {"hl_overview", "apis"},
+		"repo-d": {"hl_overview"},
+		"repo-b": {"hl_overview", "apis"},
+	}))
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "audit", "--concurrency", "3", "--json")
+	if err != nil {
+		t.Fatalf("results audit: %v", err)
+	}
+	var result struct {
+		Passed int `json:"passed"`
+		Failed int `json:"failed"`
+		Repos  []struct {
+			Name    string   `json:"name"`
+			Missing []string `json:"missing"`
+		} `json:"repos"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result.Passed != 3 || result.Failed != 1 {
+		t.Errorf("passed = %d, failed = %d; want 3 and 1", result.Passed, result.Failed)
+	}
+	var names []string
+	for _, r := range result.Repos {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "repo-a,repo-b,repo-c,repo-d" {
+		t.Errorf("repos not sorted by name: %v", names)
+	}
+}
+
+func TestResultsAuditExpected(t *testing.T) {
+	_, cleanup := testServer(t, auditRoutes(map[string][]string{
+		"old-1": {"hl_overview", "apis", "security_check"},
+		"new-1": {"hl_overview"},
+		"new-2": {"hl_overview"},
+	}))
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		args     []string
+		mode     string
+		expected int
+		failed   int
+	}{
+		{"majority", nil, "inferred", 1, 0},
+		{"min coverage", []string{"--min-coverage", "30"}, "inferred", 3, 2},
+		{"explicit", []string{"--expected", "hl_overview,apis"}, "explicit", 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runCmd(t, append([]string{"results", "audit", "--json"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("results audit: %v", err)
+			}
+			var result struct {
+				Mode             string   `json:"mode"`
+				ExpectedSections []string `json:"expectedSections"`
+				Failed           int      `json:"failed"`
+			}
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+			}
+			if result.Mode != tt.mode || len(result.ExpectedSections) != tt.expected || result.Failed != tt.failed {
+				t.Errorf("mode = %s, expected = %v, failed = %d", result.Mode, result.ExpectedSections, result.Failed)
+			}
+		})
+	}
+}
+
+func TestResultsAuditOnly(t *testing.T) {
+	_, cleanup := testServer(t, auditRoutes(map[string][]string{
+		"team-a-api": {"hl_overview", "apis"},
+		"team-a-ui":  {"hl_overview", "apis"},
+		"team-b-api": {"hl_overview"},
+	}))
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "audit", "--only", "team-a-*", "--json")
+	if err != nil {
+		t.Fatalf("results audit: %v", err)
+	}
+	var result struct {
+		TotalRepos int `json:"totalRepos"`
+		Failed     int `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result.TotalRepos != 2 || result.Failed != 0 {
+		t.Errorf("totalRepos = %d, failed = %d; want 2 and 0", result.TotalRepos, result.Failed)
+	}
+}
+
+func TestResultsAuditAllRepos(t *testing.T) {
+	_, cleanup := testServer(t, auditRoutes(map[string][]string{
+		"repo-a": {"hl_overview", "apis"},
+		"repo-b": {"hl_overview", "apis"},
+		"repo-c": {"hl_overview"},
+	}))
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "audit")
+	if err != nil {
+		t.Fatalf("results audit: %v", err)
+	}
+	if strings.Contains(out, "repo-a") || !strings.Contains(out, "repo-c") {
+		t.Errorf("default output should list failing repos only:\n%s", out)
+	}
+
+	out, err = runCmd(t, "results", "audit", "--all-repos")
+	if err != nil {
+		t.Fatalf("results audit --all-repos: %v", err)
+	}
+	if !strings.Contains(out, "repo-a") || !strings.Contains(out, "repo-c") {
+		t.Errorf("--all-repos should list every repo:\n%s", out)
+	}
+}
+
+func TestDestructiveCommandsRequireYesWithoutTTY(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"DELETE /prompts/old-prompt":     map[string]any{"success": true},
+		"POST /workflows/wf-1/terminate": map[string]any{"success": true},
+		"DELETE /repos/is-odd":           map[string]any{"success": true},
+	})
+	defer cleanup()
+
+	for _, args := range [][]string{
+		{"prompts", "delete", "old-prompt"},
+		{"workflows", "terminate", "wf-1"},
+		{"repos", "remove", "is-odd"},
+	} {
+		_, err := runCmd(t, args...)
+		if !errors.Is(err, output.ErrNotInteractive) {
+			t.Errorf("%v: err = %v, want ErrNotInteractive", args, err)
+		}
+	}
+}
+
+func TestWorkflowsProgressRepo(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /workflows": map[string]any{
+			"executions": []map[string]any{
+				{"workflowId": "investigate-daily-1", "type": "InvestigateReposWorkflow", "status": "Running", "startTime": "2026-03-01T10:00:00Z"},
+				{"workflowId": "investigate-single-is-odd-1772359300000", "runId": "r1", "type": "InvestigateSingleRepoWorkflow", "status": "Completed", "startTime": "2026-03-01T10:01:00Z", "closeTime": "2026-03-01T10:05:30Z"},
+				{"workflowId": "investigate-single-meshmart-1772359300000", "type": "InvestigateSingleRepoWorkflow", "status": "Running", "startTime": "2026-03-01T10:01:00Z"},
+				{"workflowId": "investigate-single-left-pad-1772000000000", "type": "InvestigateSingleRepoWorkflow", "status": "Completed", "startTime": "2026-02-27T10:01:00Z"},
+			},
+		},
+		"GET /workflows/investigate-single-is-odd-1772359300000/history": map[string]any{
+			"events": []map[string]any{
+				{"eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED"},
+				{"eventType": "EVENT_TYPE_ACTIVITY_TASK_FAILED"},
+				{"eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED", "eventTime": "2026-03-01T10:05:30Z"},
+			},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "workflows", "progress", "is-odd", "--json")
+	if err != nil {
+		t.Fatalf("workflows progress is-odd: %v", err)
+	}
+	var got struct {
+		WorkflowID string `json:"workflowId"`
+		Status     string `json:"status"`
+		Duration   string `json:"duration"`
+		History    struct {
+			Events           int    `json:"events"`
+			LastEvent        string `json:"lastEvent"`
+			FailedActivities int    `json:"failedActivities"`
+		} `json:"history"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.WorkflowID != "investigate-single-is-odd-1772359300000" || got.Status != "Completed" || got.Duration != "4m30s" {
+		t.Errorf("progress = %+v", got)
+	}
+	if got.History.Events != 3 || got.History.LastEvent != "WORKFLOW_EXECUTION_COMPLETED" || got.History.FailedActivities != 1 {
+		t.Errorf("history = %+v", got.History)
+	}
+
+	// left-pad ran before the current daily run started
+	if _, err := runCmd(t, "workflows", "progress", "left-pad"); err == nil || !strings.Contains(err.Error(), "not part of the current daily run") {
+		t.Errorf("expected not-part-of-run error, got %v", err)
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	server, cleanup := testServer(t, nil)
+	defer cleanup()
+
+	var got http.Header
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+	})
+
+	cfg, _ := config.Load()
+	cfg.ExtraHeaders = map[string]string{"X-Team-Id": "from-config", "X-Env": "dev"}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCmd(t, "repos", "list", "--json", "--header", "X-Team-Id=42"); err != nil {
+		t.Fatalf("repos list: %v", err)
+	}
+	if got.Get("X-Team-Id") != "42" || got.Get("X-Env") != "dev" {
+		t.Errorf("headers = %v, want --header to override config and config headers kept", got)
+	}
+	if got.Get("Authorization") != "Bearer test-token" {
+		t.Errorf("Authorization = %q", got.Get("Authorization"))
+	}
+
+	if _, err := runCmd(t, "repos", "list", "--header", "Authorization=Bearer evil"); err == nil {
+		t.Error("expected --header Authorization to be rejected")
+	}
+}
+
+func TestTokenCommand(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []any{},
+	})
+	defer cleanup()
+
+	cfg, _ := config.Load()
+	cfg.APIToken = ""
+	cfg.TokenCommand = "echo test-token"
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCmd(t, "repos", "list", "--json"); err != nil {
+		t.Fatalf("repos list with tokenCommand: %v", err)
+	}
+
+	// An explicit --api-token wins over tokenCommand
+	if _, err := runCmd(t, "repos", "list", "--json", "--api-token", "wrong"); err == nil {
+		t.Error("expected 401 with --api-token overriding tokenCommand")
+	}
+}
+
+func TestAPITokenFile(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []any{},
+	})
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("test-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.Load()
+	cfg.APIToken = "file:" + path
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCmd(t, "repos", "list", "--json"); err != nil {
+		t.Fatalf("repos list with apiToken file: %v", err)
+	}
+
+	// The file is read at use time, so a rotated secret takes effect
+	os.WriteFile(path, []byte("rotated"), 0600)
+	if _, err := runCmd(t, "repos", "list", "--json"); err == nil {
+		t.Error("expected 401 after the token file changed")
+	}
+
+	if _, err := runCmd(t, "repos", "list", "--json", "--api-token", "file:"+filepath.Join(t.TempDir(), "missing")); err == nil || !strings.Contains(err.Error(), "token file") {
+		t.Errorf("expected token file error, got %v", err)
+	}
+}
+
+func TestResultsSectionsNoIcons(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki/is-odd": map[string]any{
+			"repo":     "is-odd",
+			"sections": []map[string]any{{"id": "hl_overview"}},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "sections", "is-odd")
+	if err != nil {
+		t.Fatalf("results sections: %v", err)
+	}
+	if !strings.Contains(out, "📋 hl_overview") {
+		t.Errorf("expected section icon in human output:\n%s", out)
+	}
+
+	out, err = runCmd(t, "results", "sections", "is-odd", "--no-icons")
+	if err != nil {
+		t.Fatalf("results sections --no-icons: %v", err)
+	}
+	if strings.Contains(out, "📋") || !strings.Contains(out, "hl_overview") {
+		t.Errorf("expected plain section names with --no-icons:\n%s", out)
+	}
+}
+
+func TestResultsReadRender(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki/is-odd/hl_overview": map[string]any{
+			"repo":    "is-odd",
+			"section": "hl_overview",
+			"content": "# Overview\n- **fast**",
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "read", "is-odd", "hl_overview", "--render")
+	if err != nil {
+		t.Fatalf("results read --render: %v", err)
+	}
+	// Explicit --render keeps colors even though stdout isn't a terminal
+	if strings.Contains(out, "# Overview") || !strings.Contains(out, "fast") || !strings.Contains(out, "\x1b[") {
+		t.Errorf("expected rendered, colored markdown:\n%q", out)
+	}
+
+	// Not a terminal: markdown source by default
+	out, err = runCmd(t, "results", "read", "is-odd", "hl_overview")
+	if err != nil {
+		t.Fatalf("results read: %v", err)
+	}
+	if !strings.Contains(out, "# Overview\n- **fast**") {
+		t.Errorf("expected markdown source when not on a terminal:\n%s", out)
+	}
+
+	if _, err := runCmd(t, "results", "read", "is-odd", "hl_overview", "--raw", "--render"); err == nil {
+		t.Error("expected --raw and --render to conflict")
+	}
+}
+
+func TestNewGuideOnlyJSON(t *testing.T) {
+	dir := t.TempDir()
+	out, err := runCmd(t, "new", "--guide-only", "--dir", dir, "--json")
+	if err != nil {
+		t.Fatalf("new --guide-only --json: %v", err)
+	}
+	var result struct {
+		Mode       string            `json:"mode"`
+		InstallDir string            `json:"installDir"`
+		Missing    []string          `json:"missing"`
+		GuidePaths map[string]string `json:"guidePaths"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result.Mode != "guide-only" || result.InstallDir != dir || result.Missing == nil {
+		t.Errorf("unexpected envelope: %+v", result)
+	}
+	if _, err := os.Stat(result.GuidePaths["guide"]); err != nil {
+		t.Errorf("guide not written: %v", err)
+	}
+}
+
+func TestExpandAgentCmd(t *testing.T) {
+	dir := filepath.Join("home", "me", ".reposwarm")
+	got := expandAgentCmd("wrap-agent --guide {guide} --cwd {dir}", dir)
+	want := "wrap-agent --guide " + filepath.Join(dir, "REPOSWARM_INSTALL.md") + " --cwd " + dir
+	if got != want {
+		t.Errorf("expandAgentCmd = %q, want %q", got, want)
+	}
+
+	if runtime.GOOS != "windows" {
+		got = expandAgentCmd("cd {dir}", "/tmp/my dir; rm -rf ~'s")
+		if want := `cd '/tmp/my dir; rm -rf ~'\''s'`; got != want {
+			t.Errorf("expandAgentCmd = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNewLocalRefusesForeignDir(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "thesis.tex"), nil, 0644)
+
+	_, err := runCmd(t, "new", "--local", "--dir", dir, "--json")
+	if err == nil || !strings.Contains(err.Error(), "--allow-non-empty") {
+		t.Fatalf("expected a refusal mentioning --allow-non-empty, got %v", err)
+	}
+	out, err := runCmd(t, "new", "--local", "--dir", dir, "--json-stream")
+	if err == nil || !strings.Contains(err.Error(), "--allow-non-empty") || out != "" {
+		t.Errorf("expected --json-stream to refuse without human output, got %v, stdout %q", err, out)
+	}
+
+	// A guide written by 'new --guide-only' doesn't make the dir foreign
+	guideDir := t.TempDir()
+	os.WriteFile(filepath.Join(guideDir, "INSTALL.md"), nil, 0644)
+	os.WriteFile(filepath.Join(guideDir, "REPOSWARM_INSTALL.md"), nil, 0644)
+	if err := confirmInstallDir(guideDir, false, false); err != nil {
+		t.Errorf("guide-only dir refused: %v", err)
+	}
+}
+
+func TestEnvJSON(t *testing.T) {
+	for _, args := range [][]string{{"env", "--json"}, {"doctor", "env", "--json"}} {
+		out, err := runCmd(t, args...)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		var env map[string]any
+		if err := json.Unmarshal([]byte(out), &env); err != nil {
+			t.Fatalf("%v: invalid JSON: %v\noutput: %s", args, err, out)
+		}
+		if env["os"] == nil || env["arch"] == nil {
+			t.Errorf("%v: missing os/arch in %s", args, out)
+		}
+	}
+}
+
+func TestDoctorActionForAWSCLICredentials(t *testing.T) {
+	actions := buildRecommendedActions([]checkResult{{"AWS CLI credentials", "warn", "expired token"}})
+	if len(actions) != 1 || actions[0].cmd != "aws configure" {
+		t.Errorf("actions = %+v, want aws configure", actions)
+	}
+}
+
+func TestNewAWSOverridesInGuide(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := runCmd(t, "new", "--guide-only", "--dir", dir, "--aws-region", "eu-central-1", "--aws-profile", "acme-dev", "--json"); err != nil {
+		t.Fatalf("new --guide-only: %v", err)
+	}
+	guide, err := os.ReadFile(filepath.Join(dir, "INSTALL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"AWS_REGION=eu-central-1", "AWS_PROFILE=acme-dev"} {
+		if !strings.Contains(string(guide), want) {
+			t.Errorf("guide missing %q", want)
+		}
+	}
+}
+
+func TestJSONStreamPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	p := &jsonStreamPrinter{enc: json.NewEncoder(&buf)}
+	p.Section("Checking disk")
+	p.Success("40 GB available")
+	p.Printf("  summary line\n")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d events, want 2:\n%s", len(lines), buf.String())
+	}
+	var ev struct{ Step, Status, Message, TS string }
+	if err := json.Unmarshal([]byte(lines[1]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Step != "Checking disk" || ev.Status != "ok" || ev.Message != "40 GB available" || ev.TS == "" {
+		t.Errorf("event = %+v", ev)
+	}
+
+	if _, err := runCmd(t, "new", "--json-stream"); err == nil || !strings.Contains(err.Error(), "--local") {
+		t.Errorf("expected --json-stream to require --local, got %v", err)
+	}
+}
+
+func TestConfigInitRetriesAndSaveAnyway(t *testing.T) {
+	defer func(d []time.Duration) { initRetryDelays = d }(initRetryDelays)
+	initRetryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+
+	server, cleanup := testServer(t, nil)
+	defer cleanup()
+	calls := 0
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"status": "healthy", "version": "1.0.0"}})
+	})
+
+	if _, err := runCmd(t, "config", "init", "--api-url", server.URL, "--api-token", "test-token"); err != nil {
+		t.Fatalf("config init while the API boots: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("health calls = %d, want 3", calls)
+	}
+
+	if _, err := runCmd(t, "config", "init", "--api-url", "http://127.0.0.1:1", "--api-token", "t", "--non-interactive"); err == nil || !strings.Contains(err.Error(), "--save-anyway") {
+		t.Fatalf("expected failure suggesting --save-anyway, got %v", err)
+	}
+	if _, err := runCmd(t, "config", "init", "--api-url", "http://127.0.0.1:1", "--api-token", "kept", "--save-anyway"); err != nil {
+		t.Fatalf("config init --save-anyway: %v", err)
+	}
+	path, _ := config.ConfigPath()
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "kept") {
+		t.Errorf("config not saved with --save-anyway: %s", data)
+	}
+}
+
+func TestConfigTest(t *testing.T) {
+	server, cleanup := testServer(t, map[string]any{
+		"/health": map[string]any{"status": "healthy", "version": "1.2.3"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "config", "test", "--json")
+	if err != nil {
+		t.Fatalf("config test: %v", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result["ok"] != true || result["version"] != "1.2.3" {
+		t.Errorf("unexpected result: %v", result)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	out, err = runCmd(t, "config", "test", "--json")
+	if err == nil {
+		t.Fatal("expected an error for a rejected token")
+	}
+	result = nil
+	json.Unmarshal([]byte(out), &result)
+	if result["reason"] != "unauthorized" {
+		t.Errorf("reason = %v, want unauthorized", result["reason"])
+	}
+}
+
+func TestResultsSearchCount(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/wiki/my-app":      map[string]any{"repo": "my-app", "sections": []map[string]any{{"id": "DBs"}, {"id": "APIs"}}},
+		"/wiki/my-app/DBs":  map[string]any{"content": "Uses DynamoDB\n\nDynamoDB streams feed the worker\nNothing else"},
+		"/wiki/my-app/APIs": map[string]any{"content": "REST only"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "search", "dynamodb", "--repo", "my-app", "--count", "--json")
+	if err != nil {
+		t.Fatalf("results search --count: %v", err)
+	}
+	var counts []map[string]any
+	if err := json.Unmarshal([]byte(out), &counts); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(counts) != 1 || counts[0]["section"] != "DBs" || counts[0]["count"] != float64(2) {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+}
+
+func TestResultsReadPairsFile(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/wiki/a/DBs":  map[string]any{"content": "a dbs"},
+		"/wiki/b/APIs": map[string]any{"content": "b apis"},
+	})
+	defer cleanup()
+
+	pairs := filepath.Join(t.TempDir(), "pairs.txt")
+	os.WriteFile(pairs, []byte("# curated\nb APIs\n\na/DBs\n"), 0644)
+
+	out, err := runCmd(t, "results", "read", "--pairs-file", pairs, "--json")
+	if err != nil {
+		t.Fatalf("results read --pairs-file: %v", err)
+	}
+	var contents []map[string]any
+	if err := json.Unmarshal([]byte(out), &contents); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(contents) != 2 || contents[0]["repo"] != "b" || contents[1]["content"] != "a dbs" {
+		t.Errorf("unexpected contents: %v", contents)
+	}
+
+	if _, err := parseSectionPairs(strings.NewReader("only-a-repo\n")); err == nil {
+		t.Error("expected an error for a line without a section")
+	}
+}
+
+func TestWorkflowsDescribeJSON(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/workflows/wf-1": map[string]any{"workflowId": "wf-1", "status": "Failed", "type": "DailyWorkflow", "startTime": "2026-03-02T10:00:00Z", "closeTime": "2026-03-02T10:05:00Z"},
+		"/workflows/wf-1/history": map[string]any{"events": []map[string]any{
+			{"eventId": "1", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED", "eventTime": "2026-03-02T10:00:00Z"},
+			{"eventId": "2", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_FAILED", "eventTime": "2026-03-02T10:05:00Z"},
+		}},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "workflows", "describe", "wf-1", "--json")
+	if err != nil {
+		t.Fatalf("workflows describe: %v", err)
+	}
+	var result struct {
+		Execution map[string]any `json:"execution"`
+		Duration  string         `json:"duration"`
+		History   struct {
+			Events   int             `json:"events"`
+			Timeline []timelineEntry `json:"timeline"`
+		} `json:"history"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Execution["workflowId"] != "wf-1" || result.Duration != "5m00s" {
+		t.Errorf("unexpected execution: %+v", result)
+	}
+	if result.History.Events != 2 || len(result.History.Timeline) != 2 || !result.History.Timeline[1].Failed {
+		t.Errorf("unexpected history: %+v", result.History)
+	}
+}
+
+func TestReportRepoPatternAndSections(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{
+				{"name": "team-a-api", "sectionCount": 2},
+				{"name": "team-b-api", "sectionCount": 2},
+			},
+		},
+		"GET /wiki/team-a-api": map[string]any{
+			"repo":     "team-a-api",
+			"sections": []map[string]any{{"id": "DBs", "label": "Databases"}, {"id": "hl_overview", "label": "Overview"}},
+		},
+		"GET /wiki/team-a-api/DBs":         map[string]any{"content": "Postgres"},
+		"GET /wiki/team-a-api/hl_overview": map[string]any{"content": "An API"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "report", "TEAM-A-*", "--sections", "DBs", "--json")
+	if err != nil {
+		t.Fatalf("results report: %v", err)
+	}
+	var reports []struct {
+		Name    string            `json:"name"`
+		Content map[string]string `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(out), &reports); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(reports) != 1 || reports[0].Name != "team-a-api" || len(reports[0].Content) != 1 || reports[0].Content["DBs"] != "Postgres" {
+		t.Errorf("unexpected report: %+v", reports)
+	}
+
+	if _, err := runCmd(t, "results", "report", "--repo", "team-c-*"); err == nil || !strings.Contains(err.Error(), "team-c-*") {
+		t.Errorf("expected a no-match error naming the pattern, got %v", err)
+	}
+}
+
+func TestReportExclude(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{
+				{"name": "team-a-api", "sectionCount": 1},
+				{"name": "archived-api", "sectionCount": 1},
+				{"name": "sandbox", "sectionCount": 1},
+			},
+		},
+		"GET /wiki/team-a-api": map[string]any{
+			"repo":     "team-a-api",
+			"sections": []map[string]any{{"id": "DBs", "label": "Databases"}},
+		},
+		"GET /wiki/team-a-api/DBs": map[string]any{"content": "Postgres"},
+	})
+	defer cleanup()
+
+	if _, err := runCmd(t, "config", "set", "excludeRepos", "sandbox"); err != nil {
+		t.Fatalf("config set excludeRepos: %v", err)
+	}
+
+	out, err := runCmd(t, "results", "report", "--exclude", "Archived-*", "--json")
+	if err != nil {
+		t.Fatalf("results report: %v", err)
+	}
+	var reports []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(out), &reports); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(reports) != 1 || reports[0].Name != "team-a-api" {
+		t.Errorf("expected only team-a-api, got %+v", reports)
+	}
+
+	if _, err := runCmd(t, "results", "report", "archived-*", "--exclude", "archived-*"); err == nil || !strings.Contains(err.Error(), "excluded") {
+		t.Errorf("expected an all-excluded error, got %v", err)
+	}
+}
+
+func TestResultsSearchRetriesAndReportsFetchErrors(t *testing.T) {
+	defer func(d []time.Duration) { fetchRetryDelays = d }(fetchRetryDelays)
+	fetchRetryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+
+	server, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{"repos": []map[string]any{{"name": "app"}}},
+		"GET /wiki/app": map[string]any{
+			"repo":     "app",
+			"sections": []map[string]any{{"id": "DBs"}, {"id": "apis"}, {"id": "gone"}},
+		},
+		"GET /wiki/app/DBs": map[string]any{"content": "uses DynamoDB"},
+	})
+	defer cleanup()
+	routes := server.Config.Handler
+	flaky := 0
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/app/DBs":
+			// Fails once, then succeeds on retry
+			if flaky++; flaky == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+		case "/wiki/app/apis":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	out, err := runCmd(t, "results", "search", "dynamodb", "--json")
+	if err != nil {
+		t.Fatalf("results search: %v", err)
+	}
+	var hits []map[string]any
+	if err := json.Unmarshal([]byte(out), &hits); err != nil {
+		t.Fatalf("warnings leaked into --json output: %v\n%s", err, out)
+	}
+	if len(hits) != 1 || hits[0]["line"] != "uses DynamoDB" {
+		t.Errorf("expected the retried section's hit, got %s", out)
+	}
+
+	flaky = 0
+	if _, err := runCmd(t, "results", "search", "dynamodb", "--strict"); err == nil || !strings.Contains(err.Error(), "1 section could not be read") {
+		t.Errorf("expected --strict to fail on the unreadable section, got %v", err)
+	}
+}
+
+func TestSummaryJSON(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/health": map[string]any{"status": "healthy", "version": "1.2.3"},
+		"/repos":  []map[string]any{{"name": "a", "enabled": true}, {"name": "b", "enabled": false}},
+		"/wiki":   map[string]any{"repos": []map[string]any{{"name": "a"}}},
+		"/workflows": map[string]any{"executions": []map[string]any{
+			{"workflowId": "w1", "status": "Running"},
+			{"workflowId": "w2", "status": "FAILED"},
+			{"workflowId": "w3", "status": "Completed"},
+		}},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "summary", "--json")
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	var sum fleetSummary
+	if err := json.Unmarshal([]byte(out), &sum); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !sum.API.Connected || sum.Repos.Tracked != 2 || sum.Repos.Enabled != 1 || sum.Results.Repos != 1 {
+		t.Errorf("unexpected summary: %+v", sum)
+	}
+	if sum.Workflows.Running != 1 || sum.Workflows.Failed != 1 || sum.Workflows.Completed != 1 {
+		t.Errorf("unexpected workflow counts: %+v", sum.Workflows)
+	}
+}
+
+func TestResultsExportAllResume(t *testing.T) {
+	server, cleanup := testServer(t, map[string]any{
+		"/wiki":                     map[string]any{"repos": []map[string]any{{"name": "is-odd"}, {"name": "is-even"}}},
+		"/wiki/is-odd":              map[string]any{"repo": "is-odd", "sections": []map[string]any{{"id": "hl_overview"}}},
+		"/wiki/is-even":             map[string]any{"repo": "is-even", "sections": []map[string]any{{"id": "hl_overview"}}},
+		"/wiki/is-odd/hl_overview":  map[string]any{"content": "odd"},
+		"/wiki/is-even/hl_overview": map[string]any{"content": "even"},
+	})
+	defer cleanup()
+	routes := server.Config.Handler
+	evenDown, oddFetches := true, 0
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wiki/is-odd" {
+			oddFetches++
+		}
+		if evenDown && r.URL.Path == "/wiki/is-even" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	dir := t.TempDir()
+	if _, err := runCmd(t, "results", "export", "--all", "-d", dir, "--json"); err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Fatalf("expected a partial failure suggesting --resume, got %v", err)
+	}
+
+	evenDown = false
+	out, err := runCmd(t, "results", "export", "--all", "-d", dir, "--resume", "--json")
+	if err != nil {
+		t.Fatalf("results export --resume: %v", err)
+	}
+	var result struct {
+		Skipped []string `json:"skipped"`
+		Repos   []any    `json:"repos"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "is-odd" || len(result.Repos) != 2 {
+		t.Errorf("skipped = %v, repos = %v", result.Skipped, result.Repos)
+	}
+	if oddFetches != 1 {
+		t.Errorf("is-odd fetched %d times, want 1", oddFetches)
+	}
+	if index, _ := os.ReadFile(filepath.Join(dir, "index.md")); !strings.Contains(string(index), "is-even.arch.md") || !strings.Contains(string(index), "is-odd.arch.md") {
+		t.Errorf("index should list both repos:\n%s", index)
+	}
+
+	// A --sections mismatch warns without breaking the JSON
+	out, err = runCmd(t, "results", "export", "--all", "-d", dir, "--resume", "--sections", "hl_overview", "--json")
+	if err != nil {
+		t.Fatalf("results export --resume --sections: %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("warning leaked into --json output: %v\noutput: %s", err, out)
+	}
+}
+
+func TestDiffHashes(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki/repo1":          map[string]any{"sections": []map[string]any{{"id": "overview"}, {"id": "apis"}, {"id": "dbs"}}},
+		"GET /wiki/repo2":          map[string]any{"sections": []map[string]any{{"id": "overview"}, {"id": "apis"}, {"id": "dbs"}}},
+		"GET /wiki/repo1/overview": map[string]any{"content": "same text"},
+		"GET /wiki/repo2/overview": map[string]any{"content": "same text"},
+		"GET /wiki/repo1/apis":     map[string]any{"content": "REST"},
+		"GET /wiki/repo2/apis":     map[string]any{"content": "gRPC"},
+		"GET /wiki/repo1/dbs":      map[string]any{"content": "DynamoDB"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "diff", "repo1", "repo2", "--hashes", "--json")
+	if err != nil {
+		t.Fatalf("results diff --hashes: %v", err)
+	}
+	var result struct {
+		Changed       []string                     `json:"changed"`
+		Unchanged     []string                     `json:"unchanged"`
+		Unknown       []string                     `json:"unknown"`
+		ContentHashes map[string]map[string]string `json:"contentHashes"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if strings.Join(result.Changed, ",") != "apis" || strings.Join(result.Unchanged, ",") != "overview" || strings.Join(result.Unknown, ",") != "dbs" {
+		t.Errorf("changed = %v, unchanged = %v, unknown = %v", result.Changed, result.Unchanged, result.Unknown)
+	}
+	if want := (api.WikiContent{Content: "REST"}).ContentHash(); result.ContentHashes["apis"]["repo1"] != want {
+		t.Errorf("apis hash = %q, want %q", result.ContentHashes["apis"]["repo1"], want)
+	}
+
+	out, err = runCmd(t, "results", "meta", "repo1", "apis", "--json")
+	if err != nil {
+		t.Fatalf("results meta: %v", err)
+	}
+	if !strings.Contains(out, (api.WikiContent{Content: "REST"}).ContentHash()) {
+		t.Errorf("results meta missing contentHash:\n%s", out)
+	}
+}
+
+func TestRemoveConfigFilesKeepsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"config.json", "providers.json", "other-tool.json"} {
+		os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600)
+	}
+	os.MkdirAll(filepath.Join(dir, "cache", "http"), 0700)
+
+	if err := removeConfigFiles(dir); err != nil {
+		t.Fatalf("removeConfigFiles: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "other-tool.json" {
+		t.Errorf("expected only other-tool.json to remain, got %v", entries)
+	}
+
+	os.Remove(filepath.Join(dir, "other-tool.json"))
+	os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0600)
+	if err := removeConfigFiles(dir); err != nil {
+		t.Fatalf("removeConfigFiles: %v", err)
+	}
+	if dirExists(dir) {
+		t.Error("expected the emptied config dir to be removed")
+	}
+}
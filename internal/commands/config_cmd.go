@@ -2,9 +2,11 @@ package commands
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/config"
@@ -19,6 +21,9 @@ func newConfigCmd() *cobra.Command {
 	}
 	cmd.AddCommand(newConfigInitCmd())
 	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigPathCmd())
+	cmd.AddCommand(newConfigTestCmd())
 	cmd.AddCommand(newConfigWorkerEnvCmd())
 	cmd.AddCommand(newConfigSetCmd())
 	cmd.AddCommand(newConfigServerCmd())
@@ -31,39 +36,97 @@ func newConfigCmd() *cobra.Command {
 }
 
 func newConfigInitCmd() *cobra.Command {
-	return &cobra.Command{
+	var nonInteractive, noTest, saveAnyway bool
+
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Interactive setup wizard",
-		Long:  "Set up API URL and token interactively. Tests the connection before saving.",
+		Long: `Set up API URL and token interactively. Tests the connection before saving.
+
+For scripted setup, pass the values as flags and no prompts are shown:
+  reposwarm config init --api-url http://localhost:3000/v1 --api-token <token> --no-test
+
+--non-interactive never prompts and fails if the token or URL is missing. A
+token given without --api-url otherwise gets a warning that the default URL
+is used.
+
+The connection test retries for about 10 seconds, so an API that is still
+starting (e.g. right after 'reposwarm new --local') gets time to come up. If
+it still fails, you're asked whether to save anyway; --save-anyway does so
+without asking.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := config.DefaultConfig()
-			reader := bufio.NewReader(os.Stdin)
+			if flagAPIUrl != "" {
+				if err := config.Set(cfg, "apiUrl", flagAPIUrl); err != nil {
+					return fmt.Errorf("--api-url: %w", err)
+				}
+			}
+			cfg.APIToken = flagAPIToken
 
 			F := output.F
-			F.Section("RepoSwarm CLI Setup")
-
-			fmt.Printf("  API URL [%s]: ", cfg.APIUrl)
-			if line, _ := reader.ReadString('\n'); strings.TrimSpace(line) != "" {
-				cfg.APIUrl = strings.TrimSpace(line)
+			if cfg.APIToken == "" && nonInteractive {
+				return fmt.Errorf("--api-token is required with --non-interactive")
 			}
-
-			for cfg.APIToken == "" {
-				fmt.Print("  API Token: ")
-				line, _ := reader.ReadString('\n')
-				cfg.APIToken = strings.TrimSpace(line)
-				if cfg.APIToken == "" {
-					F.Warning("API token is required — paste your token and press Enter")
+			if cfg.APIToken != "" && flagAPIUrl == "" {
+				if nonInteractive {
+					return fmt.Errorf("--api-url is required with --non-interactive")
 				}
+				warning(fmt.Sprintf("No --api-url given; the token will be used with the default %s", cfg.APIUrl))
 			}
+			// Prompt only for what wasn't given on the command line.
+			if cfg.APIToken == "" {
+				reader := bufio.NewReader(os.Stdin)
+				F.Section("RepoSwarm CLI Setup")
 
-			F.Info(fmt.Sprintf("Testing connection to %s...", cfg.APIUrl))
-			client := api.New(cfg.APIUrl, cfg.APIToken)
-			health, err := client.Health(ctx())
-			if err != nil {
-				return fmt.Errorf("connection test failed: %w", err)
+				if flagAPIUrl == "" {
+					fmt.Printf("  API URL [%s]: ", cfg.APIUrl)
+					if line, _ := reader.ReadString('\n'); strings.TrimSpace(line) != "" {
+						if err := config.Set(cfg, "apiUrl", line); err != nil {
+							return err
+						}
+					}
+				}
+
+				for cfg.APIToken == "" {
+					fmt.Print("  API Token: ")
+					line, err := reader.ReadString('\n')
+					cfg.APIToken = strings.TrimSpace(line)
+					if cfg.APIToken == "" && err != nil {
+						return fmt.Errorf("API token is required (stdin closed) — pass --api-token for non-interactive setup")
+					}
+					if cfg.APIToken == "" {
+						F.Warning("API token is required — paste your token and press Enter")
+					}
+				}
 			}
 
-			F.Success(fmt.Sprintf("Connected to RepoSwarm API %s (%s)", health.Version, health.Status))
+			if !noTest {
+				F.Info(fmt.Sprintf("Testing connection to %s...", cfg.APIUrl))
+				token, err := config.ResolveToken(cfg.APIToken)
+				if err != nil {
+					return err
+				}
+				client := api.New(cfg.APIUrl, token)
+				client.UserAgent = userAgent()
+				if err := configureTransport(client, cfg); err != nil {
+					return err
+				}
+				health, err := healthWithRetry(client)
+				switch {
+				case err == nil:
+					F.Success(fmt.Sprintf("Connected to RepoSwarm API %s (%s)", health.Version, health.Status))
+				case saveAnyway:
+					F.Warning(fmt.Sprintf("Connection test failed: %v — saving anyway (--save-anyway)", err))
+				case nonInteractive || flagJSON:
+					return fmt.Errorf("connection test failed: %w (pass --save-anyway to keep the config)", err)
+				default:
+					F.Warning(fmt.Sprintf("Connection test failed: %v", err))
+					ok, confirmErr := output.Confirm("Save the config anyway?")
+					if confirmErr != nil || !ok {
+						return fmt.Errorf("connection test failed: %w (pass --save-anyway to keep the config)", err)
+					}
+				}
+			}
 
 			if err := config.Save(cfg); err != nil {
 				return fmt.Errorf("saving config: %w", err)
@@ -75,6 +138,33 @@ func newConfigInitCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt; fail if --api-token or --api-url is missing")
+	cmd.Flags().BoolVar(&noTest, "no-test", false, "Save without testing the connection")
+	cmd.Flags().BoolVar(&saveAnyway, "save-anyway", false, "Save the config even if the connection test fails")
+	return cmd
+}
+
+// initRetryDelays are the waits between 'config init' connection attempts
+// (about 10s in total), giving a freshly started API time to come up.
+var initRetryDelays = []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}
+
+// healthWithRetry checks the API's health, retrying while it may still be
+// starting. A rejected token isn't retried.
+func healthWithRetry(client *api.Client) (*api.HealthResponse, error) {
+	health, err := client.Health(ctx())
+	if err == nil || errors.Is(err, api.ErrUnauthorized) {
+		return health, err
+	}
+	status := startStatus("Waiting for API...")
+	defer status.Stop()
+	for _, d := range initRetryDelays {
+		time.Sleep(d)
+		if health, err = client.Health(ctx()); err == nil || errors.Is(err, api.ErrUnauthorized) {
+			break
+		}
+	}
+	return health, err
 }
 
 func newConfigShowCmd() *cobra.Command {
@@ -227,7 +317,7 @@ func newConfigSetCmd() *cobra.Command {
 		Short: "Set a configuration value",
 		Args:  friendlyExactArgs(2, "reposwarm config set <key> <value>\n\nExample:\n  reposwarm config set apiUrl http://localhost:3000"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			cfg, err := config.LoadForEdit()
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newConfigTestCmd() *cobra.Command {
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Check that the saved config can reach the API",
+		Long: `Load the saved config, call GET /health once and report the API version and
+latency, or why it failed: a rejected token (401), a wrong URL (404) or an
+unreachable server. Nothing is prompted for or changed.
+
+Use 'doctor' for a full diagnosis of the installation.
+
+Examples:
+  reposwarm config test
+  reposwarm config test --json`,
+		Args: friendlyMaxArgs(0, "reposwarm config test"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+			path, _ := config.ConfigPath()
+
+			c, cancel := context.WithTimeout(ctx(), time.Duration(timeout)*time.Second)
+			start := time.Now()
+			health, err := client.Health(c)
+			latency := time.Since(start)
+			cancel()
+
+			if flagJSON {
+				result := map[string]any{
+					"url":        client.BaseURL,
+					"configFile": path,
+					"ok":         err == nil,
+					"latencyMs":  latency.Milliseconds(),
+				}
+				if err != nil {
+					result["reason"] = connectionFailureReason(err)
+					result["error"] = err.Error()
+					if hint := connectionHint(err); hint != "" {
+						result["hint"] = hint
+					}
+				} else {
+					result["version"] = health.Version
+					result["status"] = health.Status
+				}
+				if jsonErr := output.JSON(result); jsonErr != nil {
+					return jsonErr
+				}
+				if err != nil {
+					return fmt.Errorf("connection test failed (%s)", connectionFailureReason(err))
+				}
+				return nil
+			}
+
+			F := output.F
+			F.KeyValue("Config", path)
+			F.KeyValue("API URL", client.BaseURL)
+			if err != nil {
+				F.Error(fmt.Sprintf("Connection test failed: %v", err))
+				if hint := connectionHint(err); hint != "" {
+					F.Info(hint)
+				}
+				return fmt.Errorf("connection test failed (%s)", connectionFailureReason(err))
+			}
+			F.Success(fmt.Sprintf("Connected to RepoSwarm API %s (%s) in %s", health.Version, health.Status, latency.Round(time.Millisecond)))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&timeout, "timeout", 5, "Timeout in seconds")
+	return cmd
+}
+
+// connectionFailureReason classifies a failed API call for scripts:
+// unauthorized, not_found, unreachable or error.
+func connectionFailureReason(err error) string {
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, api.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, api.ErrConnectionFailed), errors.Is(err, context.DeadlineExceeded):
+		return "unreachable"
+	}
+	return "error"
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newConfigPathCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "path",
+		Short: "Print where config and cached data are stored",
+		Long: `Print the config file, config directory and cache directory the CLI uses.
+
+Examples:
+  reposwarm config path
+  reposwarm config path --json | jq -r .configFile`,
+		Args: friendlyMaxArgs(0, "reposwarm config path"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := config.ConfigPath()
+			if err != nil {
+				return err
+			}
+			dir, err := config.ConfigDir()
+			if err != nil {
+				return err
+			}
+			cache, err := config.CacheDir()
+			if err != nil {
+				return err
+			}
+
+			if flagJSON {
+				return output.JSON(map[string]string{
+					"configFile": file,
+					"configDir":  dir,
+					"cacheDir":   cache,
+				})
+			}
+			F := output.F
+			F.KeyValue("Config file", file)
+			F.KeyValue("Config dir", dir)
+			F.KeyValue("Cache dir", cache)
+			return nil
+		},
+	}
+}
@@ -338,7 +338,7 @@ Examples:
 					Hint        string `json:"hint"`
 				}
 
-				if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil {
+				if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil && !isBodyFailure(err) {
 					if !flagJSON {
 						output.F.Println()
 						output.F.Warning(fmt.Sprintf("Could not run inference check: %v", err))
@@ -604,7 +604,7 @@ func newProviderSetCmd() *cobra.Command {
 						Hint        string `json:"hint"`
 					}
 
-					if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil {
+					if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil && !isBodyFailure(err) {
 						if !flagJSON {
 							output.F.Println()
 							output.F.Warning(fmt.Sprintf("Could not run inference check: %v", err))
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check config.json for invalid values (offline)",
+		Long: `Load the config and check each value without contacting the API:
+apiUrl and the proxies are valid URLs, the token is set, ports are numeric,
+chunkSize is positive, outputFormat/installType/provider are known values,
+and durations parse. Exits non-zero if any problem is found.`,
+		Args: friendlyMaxArgs(0, "reposwarm config validate"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := config.ConfigPath()
+			cfg, err := config.LoadForEdit()
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			problems := config.Validate(cfg)
+			if flagJSON {
+				if problems == nil {
+					problems = []config.Problem{}
+				}
+				if err := output.JSON(map[string]any{
+					"path":     path,
+					"valid":    len(problems) == 0,
+					"problems": problems,
+				}); err != nil {
+					return err
+				}
+			} else if len(problems) == 0 {
+				output.Successf("%s is valid", path)
+			} else {
+				output.F.Section("Config problems")
+				for _, p := range problems {
+					output.F.Error(fmt.Sprintf("%s: %s", p.Key, p.Message))
+				}
+				output.F.Println()
+			}
+
+			if len(problems) > 0 {
+				return fmt.Errorf("%d config problem(s) in %s", len(problems), path)
+			}
+			return nil
+		},
+	}
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
@@ -10,35 +12,64 @@ import (
 )
 
 func newDiffCmd() *cobra.Command {
-	return &cobra.Command{
+	var matrix, hashes bool
+
+	cmd := &cobra.Command{
 		Use:   "diff <repo1> <repo2> [section]",
 		Short: "Compare investigation results between two repos",
 		Long: `Compare investigation results side-by-side.
 
 Shows sections present in one but not the other, and line count differences.
 
+With --hashes, every shared section is fetched once per repo and compared by
+the SHA-256 of its content (also shown as contentHash by 'results meta'), so
+you see which shared sections actually differ. Sections that couldn't be
+read from both repos are warned about and listed as "unknown" with --json.
+
+With --matrix (alias --all) and no repos, builds a repos × sections coverage
+table for every repo with results instead: one row per repo, sections ordered
+from most to least common. Unlike 'results audit', which only reports pass or
+fail, this shows how sections are actually distributed across the fleet.
+
 Examples:
   reposwarm results diff is-odd meshmart-catalog
-  reposwarm results diff is-odd meshmart-catalog hl_overview`,
-		Args: friendlyRangeArgs(2, 3, "reposwarm results diff <repo1> <repo2> [section]\n\nExamples:\n  reposwarm results diff is-odd meshmart-catalog\n  reposwarm results diff is-odd meshmart-catalog hl_overview"),
+  reposwarm results diff is-odd meshmart-catalog hl_overview
+  reposwarm results diff is-odd meshmart-catalog --hashes
+  reposwarm results diff --matrix
+  reposwarm results diff --matrix --json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if matrix {
+				if len(args) > 0 {
+					return fmt.Errorf("--matrix compares all repos and takes no arguments")
+				}
+				return nil
+			}
+			return friendlyRangeArgs(2, 3, "reposwarm results diff <repo1> <repo2> [section]\n\nExamples:\n  reposwarm results diff is-odd meshmart-catalog\n  reposwarm results diff is-odd meshmart-catalog hl_overview\n  reposwarm results diff --matrix")(cmd, args)
+		},
+		ValidArgsFunction: completeResults(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			if matrix {
+				return printDiffMatrix(client)
+			}
+
 			repo1, repo2 := args[0], args[1]
 
 			if len(args) == 3 {
 				section := args[2]
 				var c1, c2 api.WikiContent
 				if err := client.Get(ctx(), "/wiki/"+repo1+"/"+section, &c1); err != nil {
-					return fmt.Errorf("reading %s/%s: %w", repo1, section, err)
+					return fmt.Errorf("reading %s/%s: %w", repo1, section, withResultsSuggestions(client, err, repo1, section))
 				}
 				if err := client.Get(ctx(), "/wiki/"+repo2+"/"+section, &c2); err != nil {
-					return fmt.Errorf("reading %s/%s: %w", repo2, section, err)
+					return fmt.Errorf("reading %s/%s: %w", repo2, section, withResultsSuggestions(client, err, repo2, section))
 				}
 
+				identical := c1.Content == c2.Content
 				if flagJSON {
 					return output.JSON(map[string]any{
 						"section":   section,
@@ -48,7 +79,7 @@ Examples:
 						"lines2":    len(strings.Split(c2.Content, "\n")),
 						"created1":  c1.CreatedAt,
 						"created2":  c2.CreatedAt,
-						"identical": c1.Content == c2.Content,
+						"identical": identical,
 					})
 				}
 
@@ -61,7 +92,7 @@ Examples:
 				F.KeyValue("B", fmt.Sprintf("%s (%d lines, %s)", repo2, len(lines2), c2.CreatedAt))
 				F.Println()
 
-				if c1.Content == c2.Content {
+				if identical {
 					F.Success("Sections are identical")
 				} else {
 					F.Info(fmt.Sprintf("Sections differ (%d vs %d lines)", len(lines1), len(lines2)))
@@ -73,10 +104,10 @@ Examples:
 			// Compare all sections
 			var idx1, idx2 api.WikiIndex
 			if err := client.Get(ctx(), "/wiki/"+repo1, &idx1); err != nil {
-				return err
+				return withResultsSuggestions(client, err, repo1, "")
 			}
 			if err := client.Get(ctx(), "/wiki/"+repo2, &idx2); err != nil {
-				return err
+				return withResultsSuggestions(client, err, repo2, "")
 			}
 
 			set1 := make(map[string]bool)
@@ -88,9 +119,17 @@ Examples:
 				set2[s.ID] = true
 			}
 
+			var sectionHashes map[string][2]string
+			if hashes {
+				_, _, both := diffSets(set1, set2)
+				var fetchErrs []fetchError
+				sectionHashes, fetchErrs = fetchSectionHashes(client, repo1, repo2, both)
+				reportFetchErrors(fetchErrs, false)
+			}
+
 			if flagJSON {
 				only1, only2, both := diffSets(set1, set2)
-				return output.JSON(map[string]any{
+				out := map[string]any{
 					"repo1":     repo1,
 					"repo2":     repo2,
 					"only1":     only1,
@@ -98,7 +137,32 @@ Examples:
 					"shared":    both,
 					"sections1": len(idx1.Sections),
 					"sections2": len(idx2.Sections),
-				})
+				}
+				if hashes {
+					// unknown: shared sections that couldn't be read from both repos
+					changed, unchanged, unknown := []string{}, []string{}, []string{}
+					contentHashes := map[string]map[string]string{}
+					for _, s := range both {
+						h := sectionHashes[s]
+						contentHashes[s] = map[string]string{repo1: h[0], repo2: h[1]}
+						switch {
+						case h[0] == "" || h[1] == "":
+							unknown = append(unknown, s)
+						case h[0] == h[1]:
+							unchanged = append(unchanged, s)
+						default:
+							changed = append(changed, s)
+						}
+					}
+					sort.Strings(changed)
+					sort.Strings(unchanged)
+					sort.Strings(unknown)
+					out["changed"] = changed
+					out["unchanged"] = unchanged
+					out["unknown"] = unknown
+					out["contentHashes"] = contentHashes
+				}
+				return output.JSON(out)
 			}
 
 			F := output.F
@@ -108,6 +172,9 @@ Examples:
 			F.Println()
 
 			headers := []string{"Section", repo1, repo2}
+			if hashes {
+				headers = append(headers, "Content")
+			}
 			var rows [][]string
 
 			allSections := make(map[string]bool)
@@ -126,13 +193,156 @@ Examples:
 				if set2[s] {
 					b = "yes"
 				}
-				rows = append(rows, []string{s, a, b})
+				row := []string{s, a, b}
+				if hashes {
+					content := ""
+					if set1[s] && set2[s] {
+						h := sectionHashes[s]
+						switch {
+						case h[0] == "" || h[1] == "":
+							content = "?"
+						case h[0] == h[1]:
+							content = "same"
+						default:
+							content = output.Yellow("differs")
+						}
+					}
+					row = append(row, content)
+				}
+				rows = append(rows, row)
 			}
 			F.Table(headers, rows)
 			F.Println()
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&matrix, "matrix", false, "Compare section coverage across all repos")
+	cmd.Flags().BoolVar(&matrix, "all", false, "Alias for --matrix")
+	cmd.Flags().BoolVar(&hashes, "hashes", false, "Compare shared sections by content hash")
+	addOutputFileFlag(cmd)
+	return cmd
+}
+
+// printDiffMatrix prints which sections every repo with results has.
+// JSON output is a nested map: repo -> section -> present.
+func printDiffMatrix(client *api.Client) error {
+	var repoList api.WikiReposResponse
+	if err := getWikiRepos(client, &repoList); err != nil {
+		return err
+	}
+	if len(repoList.Repos) == 0 {
+		output.F.Info("No repos with results")
+		return nil
+	}
+	sort.Slice(repoList.Repos, func(i, j int) bool { return repoList.Repos[i].Name < repoList.Repos[j].Name })
+
+	var repos []string
+	sets := map[string]map[string]bool{}
+	coverage := map[string]int{}
+	indexes, _ := fetchWikiIndexes(client, repoList.Repos, 8, nil)
+	for i, r := range repoList.Repos {
+		if indexes[i] == nil {
+			warning(fmt.Sprintf("Could not read %s, skipping", r.Name))
+			continue
+		}
+		set := make(map[string]bool)
+		for _, s := range indexes[i].Sections {
+			set[s.ID] = true
+		}
+		for s := range set {
+			coverage[s]++
+		}
+		repos = append(repos, r.Name)
+		sets[r.Name] = set
+	}
+
+	var sections []string
+	for s := range coverage {
+		sections = append(sections, s)
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if coverage[sections[i]] != coverage[sections[j]] {
+			return coverage[sections[i]] > coverage[sections[j]]
+		}
+		return sections[i] < sections[j]
+	})
+
+	if flagJSON {
+		m := make(map[string]map[string]bool, len(repos))
+		for _, repo := range repos {
+			row := make(map[string]bool, len(sections))
+			for _, s := range sections {
+				row[s] = sets[repo][s]
+			}
+			m[repo] = row
+		}
+		return output.JSON(m)
+	}
+
+	F := output.F
+	F.Section(fmt.Sprintf("Section Coverage (%d repos, %d sections)", len(repos), len(sections)))
+	headers := append([]string{"Repo"}, sections...)
+	var rows [][]string
+	for _, repo := range repos {
+		row := []string{repo}
+		for _, s := range sections {
+			if sets[repo][s] {
+				row = append(row, "✓")
+			} else {
+				row = append(row, "—")
+			}
+		}
+		rows = append(rows, row)
+	}
+	total := []string{"coverage"}
+	for _, s := range sections {
+		total = append(total, fmt.Sprintf("%d/%d", coverage[s], len(repos)))
+	}
+	rows = append(rows, total)
+	F.Table(headers, rows)
+	F.Println()
+	return nil
+}
+
+// fetchSectionHashes fetches each section from both repos and returns the
+// content hashes, keyed by section, and the fetches that failed. A hash is
+// "" if its fetch failed.
+func fetchSectionHashes(client *api.Client, repo1, repo2 string, sections []string) (map[string][2]string, []fetchError) {
+	hashes := make(map[string][2]string, len(sections))
+	var errs []fetchError
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for _, s := range sections {
+		for i, repo := range []string{repo1, repo2} {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(s, repo string, i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				var c api.WikiContent
+				if err := client.Get(ctx(), "/wiki/"+repo+"/"+s, &c); err != nil {
+					mu.Lock()
+					errs = append(errs, fetchError{Repo: repo, Section: s, Error: err.Error()})
+					mu.Unlock()
+					return
+				}
+				mu.Lock()
+				h := hashes[s]
+				h[i] = c.ContentHash()
+				hashes[s] = h
+				mu.Unlock()
+			}(s, repo, i)
+		}
+	}
+	wg.Wait()
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].Repo != errs[j].Repo {
+			return errs[i].Repo < errs[j].Repo
+		}
+		return errs[i].Section < errs[j].Section
+	})
+	return hashes, errs
 }
 
 func diffSets(a, b map[string]bool) (only1, only2, both []string) {
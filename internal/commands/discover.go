@@ -1,7 +1,13 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
@@ -9,33 +15,412 @@ import (
 )
 
 func newDiscoverCmd() *cobra.Command {
-	return &cobra.Command{
+	var source, org string
+	var match, prefix string
+	var keep []string
+	var dryRun, yes, force bool
+
+	cmd := &cobra.Command{
 		Use:   "discover",
-		Short: "Auto-discover repositories from CodeCommit",
-		Long:  "Triggers server-side discovery of CodeCommit repositories and adds new ones to tracking.",
+		Short: "Auto-discover repositories (CodeCommit by default)",
+		Long: `Triggers server-side discovery of repositories and adds new ones to tracking.
+
+--source selects where to discover from (default CodeCommit). Other sources
+such as GitHub depend on server support and take the organization via --org.
+
+Discovery itself runs on the server and always considers every repo. With
+--match (a glob, e.g. 'team-a-*') or --prefix, newly added repos that don't
+match are removed again right after discovery. Repos that were already
+tracked are never touched, and --keep exempts further names or globs.
+
+The repos to be removed are listed for confirmation first (skip with -y;
+required when stdin is not a terminal, checked before discovery runs). If
+none of the new repos match, the pattern is probably wrong, so nothing is
+removed unless --force is given.
+
+--dry-run changes nothing. It asks the server for the discovery list
+(GET /repos/discover) and shows the delta against tracked repos: which would
+be added, which are kept, which new repos --match/--prefix would filter out
+again, and which tracked repos are external (not in the discovery source;
+left untouched). On servers that can't preview discovery (no such endpoint,
+or a response without a repositories list) it falls back to checking
+--match/--prefix against the tracked repos.
+
+Examples:
+  reposwarm repos discover
+  reposwarm repos discover --source GitHub --org my-org
+  reposwarm repos discover --prefix team-a-
+  reposwarm repos discover --dry-run
+  reposwarm repos discover --match 'team-a-*' --dry-run
+  reposwarm repos discover --prefix team-a- --keep shared-lib -y`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			pattern, err := discoverPattern(match, prefix)
+			if err != nil {
+				return err
+			}
+			body, err := discoverBody(source, org)
+			if err != nil {
+				return err
+			}
+
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			if dryRun {
+				return planDiscover(client, source, org, pattern, keep)
+			}
+
+			// Removing unmatched repos needs confirmation; find out before
+			// discovery adds them, not after
+			if pattern != "" && !yes && !flagJSON && !output.StdinIsTerminal() {
+				return fmt.Errorf("--match/--prefix may remove newly discovered repos, which needs confirmation: pass -y (stdin is not a terminal)")
+			}
+
+			// Snapshot tracked repos so only newly added ones can be filtered out
+			var before []api.Repository
+			if pattern != "" {
+				if err := client.Get(ctx(), "/repos", &before); err != nil {
+					return err
+				}
+			}
+
 			var result api.DiscoverResult
-			if err := client.Post(ctx(), "/repos/discover", nil, &result); err != nil {
+			status := startStatus("Discovering repositories...")
+			err = client.Post(ctx(), "/repos/discover", body, &result)
+			status.Stop()
+			if err != nil {
 				return err
 			}
+			// A failure inside the data envelope only shows up as an error
+			// field; a response without a success field counts as success
+			if result.Error != "" {
+				return fmt.Errorf("discovery failed: %s", result.Error)
+			}
+			result.Success = true
+
+			var excluded []string
+			if pattern != "" {
+				candidates, added, err := unmatchedNewRepos(client, pattern, keep, before)
+				if err != nil {
+					return err
+				}
+				if len(candidates) > 0 && len(candidates) == added && !force {
+					return fmt.Errorf("none of the %d new repos match %q; refusing to remove them all (check the pattern, or pass --force)", added, pattern)
+				}
+				if len(candidates) > 0 && !yes && !flagJSON {
+					fmt.Printf("  %d new repos don't match %q and will be removed:\n", len(candidates), pattern)
+					for _, name := range candidates {
+						fmt.Printf("    - %s\n", name)
+					}
+					ok, err := output.Confirm("Remove them?")
+					if err != nil {
+						return err
+					}
+					if !ok {
+						output.F.Info("Kept all discovered repos")
+						candidates = nil
+					}
+				}
+				excluded, err = removeRepos(client, candidates)
+				if err != nil {
+					return err
+				}
+				result.Added -= len(excluded)
+				var kept []string
+				for _, name := range result.Repositories {
+					if matchesAny(name, append([]string{pattern}, keep...)) {
+						kept = append(kept, name)
+					}
+				}
+				result.Repositories = kept
+			}
 
 			if flagJSON {
-				return output.JSON(result)
+				if pattern == "" {
+					return output.JSON(result)
+				}
+				if excluded == nil {
+					excluded = []string{}
+				}
+				return output.JSON(struct {
+					api.DiscoverResult
+					Match    string   `json:"match"`
+					Excluded []string `json:"excluded"`
+				}{result, pattern, excluded})
 			}
 
 			F := output.F
-			F.Success(fmt.Sprintf("Discovered %d CodeCommit repos", result.Discovered))
+			F.Success(fmt.Sprintf("Discovered %d %s repos", result.Discovered, source))
 			if result.Added > 0 {
 				F.Success(fmt.Sprintf("Added %d new repos", result.Added))
 			} else {
 				F.Info(fmt.Sprintf("All repos already tracked (%d skipped)", result.Skipped))
 			}
+			if len(excluded) > 0 {
+				F.Info(fmt.Sprintf("Excluded %d new repos not matching %q", len(excluded), pattern))
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&source, "source", "CodeCommit", "Discovery source (CodeCommit, GitHub)")
+	cmd.Flags().StringVar(&org, "org", "", "Organization to discover from (e.g. GitHub org)")
+	cmd.Flags().StringVar(&match, "match", "", "Only keep newly discovered repos matching this glob (e.g. 'team-a-*')")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only keep newly discovered repos with this name prefix")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which repos discovery would add, keep and remove, without changing anything")
+	cmd.Flags().StringArrayVar(&keep, "keep", nil, "Never remove repos matching this name or glob (repeatable)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the removal confirmation")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow removing every new repo when none match")
+	return cmd
+}
+
+// discoverBody builds the POST /repos/discover body. Plain CodeCommit
+// discovery sends no body, as before, so older servers keep working.
+func discoverBody(source, org string) (any, error) {
+	if strings.EqualFold(source, "CodeCommit") && org == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(source, "GitHub") && org == "" {
+		return nil, fmt.Errorf("--source GitHub requires --org")
+	}
+	body := map[string]string{"source": source}
+	if org != "" {
+		body["org"] = org
+	}
+	return body, nil
+}
+
+// discoverPattern turns --match/--prefix into a single glob ("" = no filter).
+func discoverPattern(match, prefix string) (string, error) {
+	if match != "" && prefix != "" {
+		return "", fmt.Errorf("--match and --prefix are mutually exclusive")
+	}
+	pattern := match
+	if prefix != "" {
+		pattern = prefix + "*"
+	}
+	if pattern == "" {
+		return "", nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", fmt.Errorf("invalid --match pattern %q: %w", pattern, err)
+	}
+	return pattern, nil
+}
+
+// unmatchedNewRepos lists repos that appeared since the before snapshot and
+// match neither pattern nor any keep entry. It also returns how many repos
+// were added in total.
+func unmatchedNewRepos(client *api.Client, pattern string, keep []string, before []api.Repository) ([]string, int, error) {
+	known := make(map[string]bool, len(before))
+	for _, r := range before {
+		known[r.Name] = true
+	}
+
+	var after []api.Repository
+	if err := client.Get(ctx(), "/repos", &after); err != nil {
+		return nil, 0, fmt.Errorf("listing repos after discovery: %w", err)
+	}
+
+	var unmatched []string
+	added := 0
+	for _, r := range after {
+		if known[r.Name] {
+			continue
+		}
+		added++
+		if matchesAny(r.Name, append([]string{pattern}, keep...)) {
+			continue
+		}
+		unmatched = append(unmatched, r.Name)
+	}
+	return unmatched, added, nil
+}
+
+// matchesAny reports whether name equals or glob-matches any of patterns.
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok || p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// removeRepos deletes the named repos, returning those removed so far on error.
+func removeRepos(client *api.Client, names []string) ([]string, error) {
+	var removed []string
+	for _, name := range names {
+		var resp any
+		if err := client.Delete(ctx(), "/repos/"+name, &resp); err != nil {
+			return removed, fmt.Errorf("removing %s: %w", name, err)
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// previewDiscoverFilter shows which tracked repos pattern includes/excludes.
+func previewDiscoverFilter(client *api.Client, pattern string) error {
+	var repos []api.Repository
+	if err := client.Get(ctx(), "/repos", &repos); err != nil {
+		return err
+	}
+
+	include, exclude := []string{}, []string{}
+	for _, r := range repos {
+		if ok, _ := path.Match(pattern, r.Name); ok {
+			include = append(include, r.Name)
+		} else {
+			exclude = append(exclude, r.Name)
+		}
+	}
+
+	if flagJSON {
+		return output.JSON(map[string]any{
+			"dryRun":  true,
+			"match":   pattern,
+			"include": include,
+			"exclude": exclude,
+		})
+	}
+
+	F := output.F
+	F.Section(fmt.Sprintf("Filter %q against %d tracked repos (dry run)", pattern, len(repos)))
+	for _, name := range include {
+		F.Printf("  %s %s\n", output.Green("+"), name)
+	}
+	for _, name := range exclude {
+		F.Printf("  %s %s\n", output.Dim("-"), name)
+	}
+	F.Println()
+	F.Info(fmt.Sprintf("%d included, %d excluded. Run without --dry-run to discover.", len(include), len(exclude)))
+	return nil
+}
+
+// planDiscover previews discovery without mutating anything: it diffs the
+// server's discovery list (GET /repos/discover, which not every server has)
+// against the tracked repos.
+func planDiscover(client *api.Client, source, org, pattern string, keep []string) error {
+	var tracked []api.Repository
+	if err := client.Get(ctx(), "/repos", &tracked); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	if body, _ := discoverBody(source, org); body != nil {
+		q.Set("source", source)
+		if org != "" {
+			q.Set("org", org)
+		}
+	}
+	endpoint := "/repos/discover"
+	if len(q) > 0 {
+		endpoint += "?" + q.Encode()
+	}
+	var result api.DiscoverResult
+	status := startStatus("Listing discoverable repositories...")
+	err := client.Get(ctx(), endpoint, &result)
+	status.Stop()
+	var apiErr *api.APIError
+	unsupported := errors.Is(err, api.ErrNotFound) || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed)
+	// A server that routes GET /repos/:name answers with a repo, not a
+	// discovery list; without repositories every tracked repo would look
+	// external
+	if err == nil && result.Repositories == nil {
+		unsupported = true
+	}
+	if unsupported {
+		if pattern == "" {
+			return fmt.Errorf("this server can't preview discovery; pass --match or --prefix to check a filter against the tracked repos instead")
+		}
+		warning("This server can't preview discovery; checking the filter against tracked repos instead")
+		return previewDiscoverFilter(client, pattern)
+	}
+	if err != nil {
+		return err
+	}
+
+	plan := diffDiscovery(tracked, result.Repositories, pattern, keep)
+
+	if flagJSON {
+		out := map[string]any{
+			"dryRun":   true,
+			"source":   source,
+			"add":      plan.add,
+			"keep":     plan.keep,
+			"filtered": plan.filtered,
+			"external": plan.external,
+		}
+		if pattern != "" {
+			out["match"] = pattern
+		}
+		return output.JSON(out)
+	}
+
+	F := output.F
+	F.Section(fmt.Sprintf("Discovery plan for %s (dry run)", source))
+	var rows [][]string
+	for _, group := range []struct {
+		action string
+		names  []string
+	}{{"add", plan.add}, {"keep", plan.keep}, {"filtered", plan.filtered}, {"external", plan.external}} {
+		for _, name := range group.names {
+			rows = append(rows, []string{group.action, name})
+		}
+	}
+	if len(rows) == 0 {
+		F.Info("Nothing discovered and nothing tracked")
+		return nil
+	}
+	F.Table([]string{"Action", "Repo"}, rows)
+	F.Println()
+	summary := fmt.Sprintf("%d to add, %d already tracked, %d external", len(plan.add), len(plan.keep), len(plan.external))
+	if pattern != "" {
+		summary += fmt.Sprintf(", %d new filtered out by %q", len(plan.filtered), pattern)
+	}
+	F.Info(summary + ". Run without --dry-run to discover.")
+	return nil
+}
+
+// discoveryPlan is the delta between tracked repos and a discovery list.
+type discoveryPlan struct {
+	add      []string // discovered, not tracked, passes the filter
+	keep     []string // discovered and already tracked
+	filtered []string // discovered, not tracked, dropped by the filter
+	external []string // tracked but not in the discovery source
+}
+
+func diffDiscovery(tracked []api.Repository, discovered []string, pattern string, keep []string) discoveryPlan {
+	plan := discoveryPlan{add: []string{}, keep: []string{}, filtered: []string{}, external: []string{}}
+	known := make(map[string]bool, len(tracked))
+	for _, r := range tracked {
+		known[r.Name] = true
+	}
+	seen := make(map[string]bool, len(discovered))
+	for _, name := range discovered {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		switch {
+		case known[name]:
+			plan.keep = append(plan.keep, name)
+		case pattern == "" || matchesAny(name, append([]string{pattern}, keep...)):
+			plan.add = append(plan.add, name)
+		default:
+			plan.filtered = append(plan.filtered, name)
+		}
+	}
+	for _, r := range tracked {
+		if !seen[r.Name] {
+			plan.external = append(plan.external, r.Name)
+		}
+	}
+	for _, names := range [][]string{plan.add, plan.keep, plan.filtered, plan.external} {
+		sort.Strings(names)
+	}
+	return plan
 }
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// discoverServer simulates discovery adding newRepos to the tracked list and
+// records DELETE /repos/:name calls.
+func discoverServer(t *testing.T, newRepos []string) (deleted *[]string, cleanup func()) {
+	t.Helper()
+	server, cleanup := testServer(t, nil)
+	tracked := []string{"old-repo"}
+	var dels []string
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data any
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos":
+			var repos []map[string]any
+			for _, name := range tracked {
+				repos = append(repos, map[string]any{"name": name})
+			}
+			data = repos
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/discover":
+			tracked = append(tracked, newRepos...)
+			data = map[string]any{"success": true, "discovered": len(tracked), "added": len(newRepos), "repositories": tracked}
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/repos/"):
+			dels = append(dels, strings.TrimPrefix(r.URL.Path, "/repos/"))
+			data = map[string]any{"success": true}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	})
+	return &dels, cleanup
+}
+
+func TestDiscoverCmdMatchKeep(t *testing.T) {
+	deleted, cleanup := discoverServer(t, []string{"team-a-api", "team-b-api", "shared-lib"})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--prefix", "team-a-", "--keep", "shared-*", "-y", "--json")
+	if err != nil {
+		t.Fatalf("repos discover: %v", err)
+	}
+	var result struct {
+		Added    int      `json:"added"`
+		Excluded []string `json:"excluded"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if len(*deleted) != 1 || (*deleted)[0] != "team-b-api" {
+		t.Errorf("deleted = %v, want [team-b-api]", *deleted)
+	}
+	if result.Added != 2 || len(result.Excluded) != 1 {
+		t.Errorf("added = %d, excluded = %v", result.Added, result.Excluded)
+	}
+}
+
+func TestDiscoverCmdRefusesToRemoveAll(t *testing.T) {
+	deleted, cleanup := discoverServer(t, []string{"team-b-api", "team-b-ui"})
+	defer cleanup()
+
+	_, err := runCmd(t, "repos", "discover", "--prefix", "team-a-", "-y", "--json")
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected refusal mentioning --force, got %v", err)
+	}
+	if len(*deleted) != 0 {
+		t.Errorf("deleted = %v, want none", *deleted)
+	}
+
+}
+
+func TestDiscoverCmdNeedsYesBeforeDiscovering(t *testing.T) {
+	_, cleanup := discoverServer(t, []string{"team-a-api", "team-b-api"})
+	defer cleanup()
+
+	// Tests don't run on a terminal, so the removal can't be confirmed
+	if _, err := runCmd(t, "repos", "discover", "--prefix", "team-a-"); err == nil || !strings.Contains(err.Error(), "-y") {
+		t.Fatalf("expected an error asking for -y, got %v", err)
+	}
+	out, err := runCmd(t, "repos", "list", "--json")
+	if err != nil {
+		t.Fatalf("repos list: %v", err)
+	}
+	var repos []map[string]any
+	json.Unmarshal([]byte(out), &repos)
+	if len(repos) != 1 {
+		t.Errorf("discovery ran before the confirmation check: %s", out)
+	}
+}
+
+func TestDiscoverCmdWithoutSuccessField(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"POST /repos/discover": map[string]any{"discovered": 3, "added": 1},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--json")
+	if err != nil {
+		t.Fatalf("a response without success should not fail: %v", err)
+	}
+	if !strings.Contains(out, `"added": 1`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestDiscoverCmdForceRemovesAll(t *testing.T) {
+	deleted, cleanup := discoverServer(t, []string{"team-b-api", "team-b-ui"})
+	defer cleanup()
+
+	if _, err := runCmd(t, "repos", "discover", "--prefix", "team-a-", "-y", "--force", "--json"); err != nil {
+		t.Fatalf("repos discover --force: %v", err)
+	}
+	if len(*deleted) != 2 {
+		t.Errorf("deleted = %v, want both new repos", *deleted)
+	}
+}
+
+func TestDiscoverBody(t *testing.T) {
+	tests := []struct {
+		source, org string
+		want        string
+		wantErr     bool
+	}{
+		{"CodeCommit", "", "null", false},
+		{"GitHub", "my-org", `{"org":"my-org","source":"GitHub"}`, false},
+		{"github", "", "", true},
+		{"CodeCommit", "acct", `{"org":"acct","source":"CodeCommit"}`, false},
+	}
+	for _, tt := range tests {
+		body, err := discoverBody(tt.source, tt.org)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("discoverBody(%q, %q) error = %v", tt.source, tt.org, err)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		got, _ := json.Marshal(body)
+		if string(got) != tt.want {
+			t.Errorf("discoverBody(%q, %q) = %s, want %s", tt.source, tt.org, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverDryRunPlan(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{{"name": "old-repo"}, {"name": "manual-repo"}},
+		"GET /repos/discover": map[string]any{
+			"success":      true,
+			"repositories": []string{"old-repo", "team-a-api", "team-b-api"},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--dry-run", "--prefix", "team-a-", "--json")
+	if err != nil {
+		t.Fatalf("repos discover --dry-run: %v", err)
+	}
+	var plan map[string]any
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	want := map[string]string{"add": "team-a-api", "keep": "old-repo", "filtered": "team-b-api", "external": "manual-repo"}
+	for k, name := range want {
+		if names, _ := plan[k].([]any); len(names) != 1 || names[0] != name {
+			t.Errorf("%s = %v, want [%s]", k, plan[k], name)
+		}
+	}
+}
+
+func TestDiscoverDryRunFallback(t *testing.T) {
+	deleted, cleanup := discoverServer(t, []string{"team-a-api"})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--dry-run", "--prefix", "team-a-", "--json")
+	if err != nil {
+		t.Fatalf("repos discover --dry-run: %v", err)
+	}
+	if !strings.Contains(out, `"include"`) {
+		t.Errorf("expected the tracked-repo filter preview, got %s", out)
+	}
+	if len(*deleted) != 0 {
+		t.Errorf("deleted = %v, want none", *deleted)
+	}
+
+	if _, err := runCmd(t, "repos", "discover", "--dry-run", "--json"); err == nil {
+		t.Error("expected an error without --match/--prefix on a server that can't preview")
+	}
+}
+
+func TestDiscoverDryRunWithoutRepositories(t *testing.T) {
+	// A server routing GET /repos/:name answers with a repo, not a list
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos":          []map[string]any{{"name": "team-a-api"}, {"name": "other"}},
+		"GET /repos/discover": map[string]any{"name": "discover", "enabled": true},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "discover", "--dry-run", "--prefix", "team-a-", "--json")
+	if err != nil {
+		t.Fatalf("repos discover --dry-run: %v", err)
+	}
+	if !strings.Contains(out, `"include"`) || strings.Contains(out, `"external"`) {
+		t.Errorf("expected the tracked-repo filter preview, got %s", out)
+	}
+}
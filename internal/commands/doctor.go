@@ -2,12 +2,14 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"regexp"
+	"runtime"
 	"strings"
 	"time"
 
@@ -40,6 +42,8 @@ func newDoctorCmd(currentVersion string) *cobra.Command {
   - DynamoDB connectivity
   - Worker status
   - Local dependencies (Docker, Node, Python, Git)
+  - AWS CLI credentials, when the AWS CLI is installed (warning only)
+  - Install directory disk space and write permission
   - Network connectivity
   - Provider credentials
 
@@ -66,6 +70,12 @@ Use --fix to attempt automatic fixes for failed checks.`,
 			// 3. Local tools
 			checks = append(checks, checkLocalTools()...)
 
+			// 3a. AWS credentials (CodeCommit discovery)
+			checks = append(checks, checkAWSCredentials()...)
+
+			// 3b. Install directory disk space
+			checks = append(checks, checkInstallDisk()...)
+
 			// 4. Network
 			checks = append(checks, checkNetwork()...)
 
@@ -104,24 +114,28 @@ Use --fix to attempt automatic fixes for failed checks.`,
 			if fail > 0 || warn > 0 {
 				actions := buildRecommendedActions(checks)
 				if len(actions) > 0 {
-					fmt.Println()
+					output.F.Println()
 					output.F.Section("Recommended Actions")
-					fmt.Println()
+					output.F.Println()
 					for i, a := range actions {
-						fmt.Printf("  %d. %s\n", i+1, a.desc)
-						fmt.Printf("     %s\n\n", output.Cyan(a.cmd))
+						output.F.Printf("  %d. %s\n", i+1, a.desc)
+						output.F.Printf("     %s\n\n", output.Cyan(a.cmd))
 					}
 
 					if fixMode {
-						fmt.Println()
+						output.F.Println()
 						output.F.Section("Auto-Fix")
 						runAutoFixes(checks)
 					} else {
-						fmt.Printf("  Run all fixes: %s\n\n", output.Cyan("reposwarm doctor --fix"))
+						output.F.Printf("  Run all fixes: %s\n\n", output.Cyan("reposwarm doctor --fix"))
 					}
 				}
 			}
 
+			// Agent mode: one stable, parseable line to end on
+			if !output.IsHuman {
+				output.F.Printf("RESULT: %s ok=%d warn=%d fail=%d\n", doctorOutcome(warn, fail), ok, warn, fail)
+			}
 			return nil
 		},
 	}
@@ -130,6 +144,17 @@ Use --fix to attempt automatic fixes for failed checks.`,
 	return cmd
 }
 
+// doctorOutcome is the overall result: "pass", "warn" or "fail".
+func doctorOutcome(warn, fail int) string {
+	switch {
+	case fail > 0:
+		return "fail"
+	case warn > 0:
+		return "warn"
+	}
+	return "pass"
+}
+
 func printCheck(c checkResult) {
 	if flagJSON {
 		return
@@ -168,11 +193,10 @@ func runAutoFixes(checks []checkResult) {
 			continue
 		}
 		if fa, ok := fixes[c.Name]; ok {
-			fmt.Printf("  Fixing: %s (%s)... ", c.Name, fa.Desc)
 			if err := fa.Fix(); err != nil {
-				output.F.Error(fmt.Sprintf("failed: %v", err))
+				output.F.Error(fmt.Sprintf("Fixing %s (%s) failed: %v", c.Name, fa.Desc, err))
 			} else {
-				output.Successf("done")
+				output.F.Success(fmt.Sprintf("Fixed %s (%s)", c.Name, fa.Desc))
 				fixedCount++
 			}
 		} else {
@@ -183,8 +207,12 @@ func runAutoFixes(checks []checkResult) {
 				output.F.Info("    reposwarm restart worker")
 			case strings.Contains(c.Name, "Provider") || strings.Contains(c.Name, "Inference"):
 				output.F.Info("    reposwarm config provider setup")
+			case c.Name == "Docker daemon":
+				output.F.Info("    Docker is installed but not running — " + bootstrap.DockerDaemonHint(runtime.GOOS))
 			case strings.Contains(c.Name, "Docker"):
 				output.F.Info("    Install Docker: https://docs.docker.com/get-docker/")
+			case c.Name == "AWS CLI credentials":
+				output.F.Info("    Run 'aws configure' (or 'aws sso login') — only needed for CodeCommit discovery")
 			case strings.Contains(c.Name, "Temporal"):
 				output.F.Info("    reposwarm restart temporal")
 			default:
@@ -194,8 +222,8 @@ func runAutoFixes(checks []checkResult) {
 	}
 
 	if fixedCount > 0 {
-		fmt.Println()
-		output.Successf("%d fix(es) applied — re-run 'reposwarm doctor' to verify", fixedCount)
+		output.F.Println()
+		output.F.Success(fmt.Sprintf("%d fix(es) applied — re-run 'reposwarm doctor' to verify", fixedCount))
 	}
 }
 
@@ -291,10 +319,10 @@ func checkCLIUpdate(currentVersion string) []checkResult {
 	changes, chErr := getChangelog(currentVersion, latestVer)
 	if chErr == nil && len(changes) > 0 {
 		if !flagJSON {
-			fmt.Println()
+			output.F.Println()
 			output.F.Info("What's new:")
 			for _, line := range changes {
-				fmt.Printf("    %s\n", line)
+				output.F.Printf("    %s\n", line)
 			}
 		}
 	}
@@ -335,6 +363,9 @@ func buildRecommendedActions(checks []checkResult) []recommendedAction {
 		case strings.Contains(c.Name, "Worker") && strings.Contains(c.Message, "not running"):
 			cmd = "reposwarm restart worker"
 			desc = "Restart the worker process"
+		case c.Name == "AWS CLI credentials":
+			cmd = "aws configure"
+			desc = "Set up AWS credentials (needed for CodeCommit discovery)"
 		case strings.Contains(c.Name, "Temporal"):
 			cmd = "reposwarm restart temporal"
 			desc = "Restart Temporal server"
@@ -408,10 +439,23 @@ func checkConfig() []checkResult {
 	}
 
 	// API Token
-	if cfg.APIToken == "" {
+	if cfg.TokenCommand != "" {
+		c := checkResult{"API token", "ok", "from tokenCommand: " + cfg.TokenCommand}
+		printCheck(c)
+		results = append(results, c)
+	} else if cfg.APIToken == "" {
 		c := checkResult{"API token", "fail", "not configured — run 'reposwarm config init'"}
 		printCheck(c)
 		results = append(results, c)
+	} else if strings.HasPrefix(cfg.APIToken, config.TokenFilePrefix) {
+		c := checkResult{"API token", "ok", "from " + cfg.APIToken}
+		if token, err := config.ResolveToken(cfg.APIToken); err != nil {
+			c = checkResult{"API token", "fail", err.Error()}
+		} else {
+			c.Message += " (" + config.MaskedToken(token) + ")"
+		}
+		printCheck(c)
+		results = append(results, c)
 	} else {
 		c := checkResult{"API token", "ok", config.MaskedToken(cfg.APIToken)}
 		printCheck(c)
@@ -436,7 +480,11 @@ func checkAPI() []checkResult {
 	latency := time.Since(start)
 
 	if err != nil {
-		c := checkResult{"API connection", "fail", fmt.Sprintf("unreachable: %s", err)}
+		msg := fmt.Sprintf("unreachable: %s", err)
+		if errors.Is(err, api.ErrUnauthorized) {
+			msg = "token rejected (401) — run 'reposwarm config init'"
+		}
+		c := checkResult{"API connection", "fail", msg}
 		printCheck(c)
 		results = append(results, c)
 		return results
@@ -489,12 +537,13 @@ func checkLocalTools() []checkResult {
 		args       []string
 		level      string // "fail" or "warn" if missing
 		dockerSkip bool   // skip this check for Docker installs
+		minVersion string // warn below this version, if set
 	}{
-		{"Git", "git", []string{"--version"}, "warn", false},
-		{"Docker", "docker", []string{"--version"}, "warn", false},
-		{"Node.js", "node", []string{"--version"}, "warn", true},
-		{"Python", "python3", []string{"--version"}, "warn", true},
-		{"AWS CLI", "aws", []string{"--version"}, "warn", true},
+		{"Git", "git", []string{"--version"}, "warn", false, ""},
+		{"Docker", "docker", []string{"--version"}, "warn", false, ""},
+		{"Node.js", "node", []string{"--version"}, "warn", true, bootstrap.MinNodeVersion},
+		{"Python", "python3", []string{"--version"}, "warn", true, bootstrap.MinPythonVersion},
+		{"AWS CLI", "aws", []string{"--version"}, "warn", true, ""},
 	}
 
 	for _, t := range tools {
@@ -515,14 +564,83 @@ func checkLocalTools() []checkResult {
 				ver = ver[:60] + "..."
 			}
 			c := checkResult{t.name, "ok", ver}
+			if t.minVersion != "" && !bootstrap.VersionAtLeast(ver, t.minVersion) {
+				c = checkResult{t.name, "warn", fmt.Sprintf("%s — %s+ required to build from source", ver, t.minVersion)}
+			}
 			printCheck(c)
 			results = append(results, c)
+			if t.cmd == "docker" {
+				results = append(results, checkDockerDaemon(isDocker))
+			}
 		}
 	}
 
 	return results
 }
 
+// checkDockerDaemon tells "docker installed" apart from "daemon actually up".
+// A stopped daemon is fatal for Docker installs, since every service runs in it.
+func checkDockerDaemon(isDocker bool) checkResult {
+	var c checkResult
+	if err := bootstrap.CheckDockerDaemon(); err != nil {
+		level := "warn"
+		if isDocker {
+			level = "fail"
+		}
+		c = checkResult{"Docker daemon", level, fmt.Sprintf("%v — %s", err, bootstrap.DockerDaemonHint(runtime.GOOS))}
+	} else {
+		c = checkResult{"Docker daemon", "ok", "running"}
+	}
+	printCheck(c)
+	return c
+}
+
+// checkAWSCredentials reports whether the AWS CLI's credentials resolve.
+// Only discovery from CodeCommit needs them, so problems are warnings.
+func checkAWSCredentials() []checkResult {
+	if _, err := exec.LookPath("aws"); err != nil {
+		return nil
+	}
+	region := orDefault(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	if region == "" {
+		region = "not set"
+	}
+	profile := orDefault(os.Getenv("AWS_PROFILE"), "default")
+
+	var c checkResult
+	if account, err := bootstrap.AWSCallerAccount(""); err != nil {
+		c = checkResult{"AWS CLI credentials", "warn", fmt.Sprintf("%v (profile %s) — 'reposwarm repos discover' from CodeCommit will fail; fine for local-only use", err, profile)}
+	} else {
+		c = checkResult{"AWS CLI credentials", "ok", fmt.Sprintf("account %s, region %s, profile %s", account, region, profile)}
+	}
+	printCheck(c)
+	return []checkResult{c}
+}
+
+// checkInstallDisk verifies the install directory is writable and has room
+// for the local stack.
+func checkInstallDisk() []checkResult {
+	cfg, _ := config.Load()
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	dir := cfg.EffectiveInstallDir()
+
+	var c checkResult
+	if err := bootstrap.CheckWritable(dir); err != nil {
+		c = checkResult{"Install dir", "fail", err.Error()}
+	} else if avail, err := bootstrap.AvailableDiskBytes(dir); err != nil {
+		c = checkResult{"Disk space", "warn", err.Error()}
+	} else if avail < bootstrap.MinFreeDiskBytes {
+		c = checkResult{"Disk space", "warn", fmt.Sprintf("%s available in %s, %s recommended",
+			bootstrap.FormatBytes(avail), dir, bootstrap.FormatBytes(bootstrap.MinFreeDiskBytes))}
+	} else {
+		c = checkResult{"Disk space", "ok", fmt.Sprintf("%s available in %s", bootstrap.FormatBytes(avail), dir)}
+	}
+	printCheck(c)
+	return []checkResult{c}
+}
+
 func checkNetwork() []checkResult {
 	var results []checkResult
 
@@ -724,7 +842,7 @@ func checkWorkerEnv() []checkResult {
 			printCheck(c)
 			results = append(results, c)
 			if !flagJSON {
-				fmt.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", key)))
+				output.F.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", key)))
 			}
 		}
 	}
@@ -772,9 +890,9 @@ func checkWorkerEnv() []checkResult {
 				results = append(results, c)
 				if !flagJSON {
 					if gitTokenKeys[req.Key] {
-						fmt.Printf("     Configure: %s\n", output.Cyan("reposwarm config git setup"))
+						output.F.Printf("     Configure: %s\n", output.Cyan("reposwarm config git setup"))
 					} else {
-						fmt.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", req.Key)))
+						output.F.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", req.Key)))
 					}
 				}
 			} else {
@@ -1020,10 +1138,6 @@ func checkProviderCredentials(priorChecks []checkResult) []checkResult {
 			results = append(results, c)
 		}
 	} else {
-	if !flagJSON {
-		fmt.Print("  Inference check: ")
-	}
-
 	var inferenceResp struct {
 		Success     bool   `json:"success"`
 		Provider    string `json:"provider"`
@@ -1035,12 +1149,10 @@ func checkProviderCredentials(priorChecks []checkResult) []checkResult {
 		Hint        string `json:"hint"`
 	}
 
-	if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil {
+	if err := client.Post(ctx(), "/workers/worker-1/inference-check", nil, &inferenceResp); err != nil && !isBodyFailure(err) {
 		// API endpoint might not exist yet
 		c := checkResult{"Inference check", "warn", "endpoint not available"}
-		if !flagJSON {
-			fmt.Println("endpoint not available")
-		}
+		printCheck(c)
 		results = append(results, c)
 	} else {
 		if inferenceResp.Success {
@@ -1049,9 +1161,7 @@ func checkProviderCredentials(priorChecks []checkResult) []checkResult {
 				desc = fmt.Sprintf("working via %s (%dms)", inferenceResp.AuthMethod, inferenceResp.LatencyMs)
 			}
 			c := checkResult{"Inference check", "ok", desc}
-			if !flagJSON {
-				output.Successf("%s", desc)
-			}
+			printCheck(c)
 			results = append(results, c)
 		} else {
 			errorMsg := inferenceResp.Error
@@ -1062,9 +1172,7 @@ func checkProviderCredentials(priorChecks []checkResult) []checkResult {
 				errorMsg += " — " + inferenceResp.Hint
 			}
 			c := checkResult{"Inference check", "fail", errorMsg}
-			if !flagJSON {
-				output.F.Error(errorMsg)
-			}
+			printCheck(c)
 			results = append(results, c)
 		}
 	}
@@ -1084,9 +1192,9 @@ func checkProviderCredentials(priorChecks []checkResult) []checkResult {
 				if !flagJSON {
 					if missing.Key == "ANTHROPIC_API_KEY" || missing.Key == "AWS_BEARER_TOKEN_BEDROCK" {
 						// Sensitive — point to provider setup instead of direct set
-						fmt.Printf("     Configure: %s\n", output.Cyan("reposwarm config provider setup"))
+						output.F.Printf("     Configure: %s\n", output.Cyan("reposwarm config provider setup"))
 					} else {
-						fmt.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", missing.Key)))
+						output.F.Printf("     Set it: %s\n", output.Cyan(fmt.Sprintf("reposwarm config worker-env set %s <value>", missing.Key)))
 					}
 				}
 			}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/bootstrap"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newEnvCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "env",
+		Short: "Show the detected local environment (OS, runtimes, agents)",
+		Long: `Print what the CLI detects about this machine: OS, shell, Docker, runtimes,
+coding agents and AWS settings. This is the same detection 'reposwarm new'
+uses to tailor the install guide; 'env' also checks that the AWS CLI's
+credentials resolve (aws sts get-caller-identity), which 'new' skips.
+
+When reporting a bug, paste the output of 'reposwarm env --json'.
+
+Examples:
+  reposwarm env
+  reposwarm env --json
+  reposwarm doctor env --json`,
+		Args: friendlyMaxArgs(0, "reposwarm env"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			env := bootstrap.Detect()
+			env.CheckAWSCredentials()
+			if flagJSON {
+				return output.JSON(env)
+			}
+
+			F := output.F
+			F.Section("Environment")
+			F.Println(env.Summary())
+			if missing := env.MissingDeps(); len(missing) > 0 {
+				F.Warning("Missing for local setup: " + strings.Join(missing, ", "))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// addExcludeFlag adds a repeatable --exclude <pattern> flag filling exclude.
+// Use repoExclusions to combine it with the excludeRepos config key.
+func addExcludeFlag(cmd *cobra.Command, exclude *[]string) {
+	cmd.Flags().StringArrayVar(exclude, "exclude", nil, "Skip repos matching this name or glob (repeatable; adds to the excludeRepos config key)")
+}
+
+// repoExclusions returns the excludeRepos config patterns plus those given
+// with --exclude (each may itself be comma-separated).
+func repoExclusions(flagValues []string) ([]string, error) {
+	var patterns []string
+	if cfg, err := config.Load(); err == nil {
+		patterns = append(patterns, cfg.ExcludeRepos...)
+	}
+	for _, v := range flagValues {
+		for _, p := range splitCSV(v) {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("invalid --exclude pattern %q: %w", p, err)
+			}
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// excludeRepos drops the names matching any of patterns, keeping order.
+// It also returns how many were dropped.
+func excludeRepos[T any](items []T, name func(T) string, patterns []string) ([]T, int) {
+	if len(patterns) == 0 {
+		return items, 0
+	}
+	var kept []T
+	for _, it := range items {
+		if !matchesAny(name(it), patterns) {
+			kept = append(kept, it)
+		}
+	}
+	return kept, len(items) - len(kept)
+}
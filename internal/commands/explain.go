@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/bootstrap"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagExplain bool
+
+// oneOf lists the shapes of a command whose --json output depends on what
+// the server returns.
+type oneOf []any
+
+// outputShapes maps a command path (without the root name) to a value of the
+// type it prints with --json, or a oneOf of them. Keep in sync when a
+// command's output changes.
+var outputShapes = map[string]any{
+	"repos list": []api.Repository{},
+	"repos show": struct {
+		api.Repository
+		Results *repoResultsSummary `json:"results,omitempty"`
+	}{},
+	"workflows list":   []api.WorkflowExecution{},
+	"workflows status": api.WorkflowExecution{},
+	"results list":     []api.WikiRepoSummary{},
+	"results sections": api.WikiIndex{},
+	"results read":     api.WikiContent{},
+	"results tree":     []treeRepo{},
+	"prompts list":     oneOf{[]api.Prompt{}, derivedSections{}},
+	"prompts show":     api.Prompt{},
+	"prompts versions": []api.PromptVersion{},
+	"prompts types":    []api.PromptType{},
+	"new":              newOutput{},
+	"env":              bootstrap.Environment{},
+	"doctor env":       bootstrap.Environment{},
+	"summary":          fleetSummary{},
+}
+
+// addExplain makes --explain on any runnable command print the JSON schema
+// of its --json output instead of running it (and skips argument checks).
+func addExplain(root *cobra.Command) {
+	root.PersistentFlags().BoolVar(&flagExplain, "explain", false, "Print the JSON schema of this command's --json output instead of running it")
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, c := range cmd.Commands() {
+			walk(c)
+		}
+		// Run commands are turned into RunE ones so the flag isn't ignored there
+		if cmd.RunE == nil && cmd.Run != nil {
+			plain := cmd.Run
+			cmd.Run = nil
+			cmd.RunE = func(cmd *cobra.Command, a []string) error {
+				plain(cmd, a)
+				return nil
+			}
+		}
+		if cmd.RunE == nil {
+			return
+		}
+		run, args := cmd.RunE, cmd.Args
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			if flagExplain || args == nil {
+				return nil
+			}
+			return args(cmd, a)
+		}
+		cmd.RunE = func(cmd *cobra.Command, a []string) error {
+			if flagExplain {
+				return explainOutput(cmd)
+			}
+			return run(cmd, a)
+		}
+	}
+	walk(root)
+}
+
+func explainOutput(cmd *cobra.Command) error {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	shape, ok := outputShapes[path]
+	if !ok {
+		var known []string
+		for k := range outputShapes {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("no documented --json shape for '%s' (documented: %s)", path, strings.Join(known, ", "))
+	}
+	var schema map[string]any
+	if shapes, ok := shape.(oneOf); ok {
+		var alternatives []any
+		for _, s := range shapes {
+			alternatives = append(alternatives, jsonSchema(reflect.TypeOf(s)))
+		}
+		schema = map[string]any{"oneOf": alternatives}
+	} else {
+		schema = jsonSchema(reflect.TypeOf(shape))
+	}
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "reposwarm " + path + " --json"
+	return output.JSON(schema)
+}
+
+// jsonSchema describes t the way encoding/json would serialize it.
+func jsonSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchema(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		props := map[string]any{}
+		required := []string{}
+		addStructFields(t, props, &required)
+		schema := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return map[string]any{}
+}
+
+// addStructFields adds t's JSON fields to props, flattening embedded structs.
+func addStructFields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addStructFields(f.Type, props, required)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = jsonSchema(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
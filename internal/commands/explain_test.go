@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type inner struct {
+		ID string `json:"id"`
+	}
+	type shape struct {
+		inner
+		Count   int             `json:"count"`
+		Note    string          `json:"note,omitempty"`
+		Tags    []string        `json:"tags"`
+		Labels  map[string]bool `json:"labels,omitempty"`
+		Nested  *inner          `json:"nested,omitempty"`
+		Skipped string          `json:"-"`
+	}
+
+	schema := jsonSchema(reflect.TypeOf(shape{}))
+	props := schema["properties"].(map[string]any)
+	for _, name := range []string{"id", "count", "note", "tags", "labels", "nested"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("missing property %q in %v", name, props)
+		}
+	}
+	if _, ok := props["Skipped"]; ok {
+		t.Error(`json:"-" field should be skipped`)
+	}
+	if got := props["tags"].(map[string]any)["type"]; got != "array" {
+		t.Errorf("tags type = %v, want array", got)
+	}
+	if got := strings.Join(schema["required"].([]string), ","); got != "id,count,tags" {
+		t.Errorf("required = %s, want id,count,tags", got)
+	}
+}
+
+func TestExplainFlag(t *testing.T) {
+	// No server: --explain must not run the command or check its arguments
+	out, err := runCmd(t, "results", "read", "--explain")
+	if err != nil {
+		t.Fatalf("results read --explain: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(out), &schema); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	props, _ := schema["properties"].(map[string]any)
+	if _, ok := props["content"]; !ok {
+		t.Errorf("expected WikiContent fields, got %v", schema)
+	}
+
+	if _, err := runCmd(t, "ping", "--explain"); err == nil || !strings.Contains(err.Error(), "repos list") {
+		t.Errorf("expected an error listing documented commands, got %v", err)
+	}
+	// Commands with a fallback output document every shape
+	if out, err := runCmd(t, "prompts", "list", "--explain"); err != nil || !strings.Contains(out, `"oneOf"`) || !strings.Contains(out, `"frequency"`) {
+		t.Errorf("prompts list --explain should list both shapes: err=%v\n%s", err, out)
+	}
+
+	// Run (not RunE) commands honour it too
+	if out, err := runCmd(t, "version", "--explain"); err == nil || strings.Contains(out, "reposwarm version") {
+		t.Errorf("version --explain ran the command: out=%q err=%v", out, err)
+	}
+}
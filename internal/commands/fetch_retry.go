@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+)
+
+// fetchRetryDelays are the waits between attempts of a fleet-wide wiki
+// fetch (results search/audit/export), so one flaky request doesn't drop a
+// section from the results.
+var fetchRetryDelays = []time.Duration{500 * time.Millisecond, 2 * time.Second}
+
+// fetchError is a repo or section that still couldn't be read after retrying.
+type fetchError struct {
+	Repo    string `json:"repo"`
+	Section string `json:"section,omitempty"`
+	Error   string `json:"error"`
+}
+
+// retryableFetch reports whether err may go away on its own: connection
+// failures, 429s and 5xx responses.
+func retryableFetch(err error) bool {
+	if errors.Is(err, api.ErrConnectionFailed) {
+		return true
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 429 || apiErr.StatusCode >= 500
+	}
+	return false
+}
+
+// getWithRetry is client.Get retried on transient failures.
+func getWithRetry(client *api.Client, path string, result any) error {
+	err := client.Get(ctx(), path, result)
+	for _, d := range fetchRetryDelays {
+		if err == nil || !retryableFetch(err) {
+			break
+		}
+		time.Sleep(d)
+		err = client.Get(ctx(), path, result)
+	}
+	return err
+}
+
+// reportFetchErrors warns about sections that could not be read, so partial
+// results don't pass for complete ones. With strict it returns an error.
+func reportFetchErrors(errs []fetchError, strict bool) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, e := range errs {
+		target := e.Repo
+		if e.Section != "" {
+			target += "/" + e.Section
+		}
+		warning(fmt.Sprintf("Could not read %s: %s", target, e.Error))
+	}
+	noun := "section"
+	if countRepoFetchErrors(errs) == len(errs) {
+		noun = "repo"
+	}
+	msg := pluralizeCount(len(errs), noun) + " could not be read; results are incomplete"
+	if strict {
+		return errors.New(msg)
+	}
+	warning(msg)
+	return nil
+}
+
+// addFetchError records err for repo (and section, if set). A 404 isn't
+// recorded: the section is simply gone, not unreadable.
+func addFetchError(errs []fetchError, repo, section string, err error) []fetchError {
+	if errors.Is(err, api.ErrNotFound) {
+		return errs
+	}
+	return append(errs, fetchError{Repo: repo, Section: section, Error: err.Error()})
+}
+
+// countRepoFetchErrors counts the errors for a whole repo index rather than
+// a single section.
+func countRepoFetchErrors(errs []fetchError) int {
+	n := 0
+	for _, e := range errs {
+		if e.Section == "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addFieldFilterFlag registers the repeatable --filter key=value flag shared
+// by list commands.
+func addFieldFilterFlag(cmd *cobra.Command, filters *[]string) {
+	cmd.Flags().StringArrayVar(filters, "filter", nil, "Only show items whose field equals value, e.g. status=active (repeatable; filters AND together)")
+}
+
+// parseFieldFilters turns key=value pairs into a map.
+func parseFieldFilters(vals []string) (map[string]string, error) {
+	filters := map[string]string{}
+	for _, v := range vals {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --filter %q: expected key=value", v)
+		}
+		filters[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return filters, nil
+}
+
+// filterByFields keeps items whose JSON fields match every filter. Values are
+// compared as strings, case-insensitively; a missing field never matches.
+func filterByFields[T any](items []T, filters map[string]string) ([]T, error) {
+	if len(filters) == 0 {
+		return items, nil
+	}
+	var kept []T
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var fields map[string]any
+		if err := dec.Decode(&fields); err != nil {
+			return nil, err
+		}
+		if matchesFields(fields, filters) {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
+
+func matchesFields(fields map[string]any, filters map[string]string) bool {
+	for key, want := range filters {
+		got, ok := fields[key]
+		if !ok || got == nil || !strings.EqualFold(fmt.Sprint(got), want) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+)
+
+func TestFilterByFields(t *testing.T) {
+	repos := []api.Repository{
+		{Name: "a", Source: "GitHub", Enabled: true, Status: "active"},
+		{Name: "b", Source: "CodeCommit", Enabled: false, Status: "active"},
+		{Name: "c", Source: "GitHub", Enabled: false, Status: "archived"},
+	}
+	tests := []struct {
+		filters []string
+		want    []string
+	}{
+		{nil, []string{"a", "b", "c"}},
+		{[]string{"status=active"}, []string{"a", "b"}},
+		{[]string{"status=active", "source=github"}, []string{"a"}},
+		{[]string{"enabled=false"}, []string{"b", "c"}},
+		{[]string{"nosuchfield=x"}, nil},
+	}
+	for _, tt := range tests {
+		filters, err := parseFieldFilters(tt.filters)
+		if err != nil {
+			t.Fatalf("parseFieldFilters(%v): %v", tt.filters, err)
+		}
+		got, err := filterByFields(repos, filters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, r := range got {
+			names = append(names, r.Name)
+		}
+		if len(names) != len(tt.want) {
+			t.Errorf("filters %v = %v, want %v", tt.filters, names, tt.want)
+			continue
+		}
+		for i := range names {
+			if names[i] != tt.want[i] {
+				t.Errorf("filters %v = %v, want %v", tt.filters, names, tt.want)
+				break
+			}
+		}
+	}
+
+	if _, err := parseFieldFilters([]string{"status"}); err == nil {
+		t.Error("expected error for filter without '='")
+	}
+}
+
+func TestReposListFieldFilter(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /repos": []map[string]any{
+			{"name": "api-server", "source": "GitHub", "enabled": true, "status": "active"},
+			{"name": "api-client", "source": "GitHub", "enabled": true, "status": "archived"},
+			{"name": "web", "source": "GitHub", "enabled": true, "status": "active"},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "repos", "list", "--json", "--filter", "status=active", "--filter", "api")
+	if err != nil {
+		t.Fatalf("repos list --filter: %v", err)
+	}
+	var repos []map[string]any
+	if err := json.Unmarshal([]byte(out), &repos); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(repos) != 1 || repos[0]["name"] != "api-server" {
+		t.Errorf("got %v, want only api-server", repos)
+	}
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
@@ -12,7 +11,7 @@ import (
 )
 
 func newInvestigateCmd() *cobra.Command {
-	var model string
+	var model, stale string
 	var chunkSize, parallel int
 	var all, force, replace, dryRun, wait bool
 
@@ -21,11 +20,26 @@ func newInvestigateCmd() *cobra.Command {
 		Short: "Trigger architecture investigation",
 		Long: `Trigger an AI-powered architecture investigation for one or all repos.
 
+With --wait (or --watch) the command follows the workflow ID returned by the
+server, falling back to the repo's most recent investigate-single workflow
+when the response has none.
+
+--stale only investigates enabled repos whose results are missing or older
+than the given age (default 7d; pass another as --stale=30d).
+
 Examples:
   reposwarm investigate is-odd              # Single repo
   reposwarm investigate --all               # All enabled repos
+  reposwarm investigate --all --dry-run     # Show the plan without starting anything
+  reposwarm investigate --stale             # Repos with no results or results older than 7d
+  reposwarm investigate --stale=30d --dry-run
+  reposwarm investigate is-odd --watch      # Start and follow progress
   reposwarm investigate is-odd --model us.anthropic.claude-opus-4-6`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if stale != "" && len(args) > 0 && isDurationArg(args[0]) {
+				return fmt.Errorf("%q was read as a repo name, not the --stale age; use --stale=%s", args[0], args[0])
+			}
+
 			client, err := getClient()
 			if err != nil {
 				return err
@@ -130,45 +144,68 @@ Examples:
 				if err := client.Post(ctx(), "/investigate/single", req, &result); err != nil {
 					return err
 				}
+				if err := operationError(result); err != nil {
+					return fmt.Errorf("investigation not started for %s: %w", repoArg, err)
+				}
+				workflowID := startedWorkflowID(result)
 				if flagJSON && !wait {
 					return output.JSON(result)
 				}
 				if !flagJSON {
 					output.Successf("Investigation started for %s", output.Bold(repoArg))
+					if workflowID != "" {
+						output.F.KeyValue("Workflow", workflowID)
+					}
 				}
 
-				// If --wait, watch the progress
+				// If --wait, watch the workflow that was just started; without
+				// an ID in the response, the repo's most recent one is used
 				if wait {
 					if !flagJSON {
 						output.F.Info("Watching progress...")
 						output.F.Println()
 					}
-					// Call the existing showRepoProgress function with wait=true
-					return showRepoProgress(repoArg, true)
+					return showWorkflowProgress(repoArg, workflowID, true)
+				}
+				if !flagJSON && workflowID != "" {
+					output.F.Info("Follow it with: reposwarm workflows watch " + workflowID)
 				}
 				return nil
 			}
 
-			if all {
-				// Fetch enabled repos from API (not the worker's internal repos.json)
-				var enabledReposList []struct {
-					Name    string `json:"name"`
-					Enabled bool   `json:"enabled"`
-				}
-				if err := client.Get(ctx(), "/repos", &enabledReposList); err != nil {
-					return fmt.Errorf("fetching repos: %w", err)
+			if all || stale != "" {
+				// Enabled repos from the API (not the worker's internal repos.json),
+				// the same set the server's daily run covers
+				names, err := enabledRepos(client)
+				if err != nil {
+					return err
 				}
 
-				// Filter to enabled repos
-				var enabledRepos []string
-				for _, r := range enabledReposList {
-					if r.Enabled {
-						enabledRepos = append(enabledRepos, r.Name)
-					}
+				if len(names) == 0 {
+					return fmt.Errorf("no enabled repos found\n  Add repos first: reposwarm repos add <name> --url <url> --source GitHub")
 				}
 
-				if len(enabledRepos) == 0 {
-					return fmt.Errorf("no enabled repos found\n  Add repos first: reposwarm repos add <name> --url <url> --source GitHub")
+				// --stale: narrow down to repos with missing or outdated results
+				var staleReasons map[string]string
+				if stale != "" {
+					total := len(names)
+					if names, staleReasons, err = selectStaleRepos(client, names, stale); err != nil {
+						return err
+					}
+					if len(names) == 0 {
+						if flagJSON {
+							return output.JSON(map[string]any{"started": 0, "skipped": 0, "total": 0, "repos": []string{}, "reasons": staleReasons})
+						}
+						output.Successf("All %d enabled repos have results newer than %s", total, stale)
+						return nil
+					}
+					if !flagJSON && !dryRun {
+						output.F.Printf("  %d of %d enabled repos are stale:\n", len(names), total)
+						for _, name := range names {
+							output.F.Printf("    - %s (%s)\n", name, staleReasons[name])
+						}
+						output.F.Println()
+					}
 				}
 
 				// Pre-flight (check once, not per repo)
@@ -210,23 +247,19 @@ Examples:
 				}
 
 				if dryRun {
-					if flagJSON {
-						return output.JSON(map[string]any{"dryRun": true, "repos": enabledRepos})
-					}
-					output.Successf("Dry run: would investigate %d repos: %s", len(enabledRepos), strings.Join(enabledRepos, ", "))
-					return nil
+					return printInvestigatePlan(client, names, staleReasons, model, chunkSize, force)
 				}
 
 				// Check for recent investigations (unless --force)
 				var recentlyInvestigated map[string]string // repoName -> time ago string
 				if !force {
-					recentlyInvestigated = checkRecentInvestigations(client, enabledRepos)
+					recentlyInvestigated = checkRecentInvestigations(client, names)
 				}
 
 				// Start individual investigations for each enabled repo
 				started := 0
 				skipped := 0
-				for _, repoName := range enabledRepos {
+				for _, repoName := range names {
 					// Skip if recently investigated (unless --force)
 					if timeAgo, wasRecent := recentlyInvestigated[repoName]; wasRecent {
 						skipped++
@@ -244,7 +277,11 @@ Examples:
 						Force:     force,
 					}
 					var result any
-					if err := client.Post(ctx(), "/investigate/single", req, &result); err != nil {
+					err := client.Post(ctx(), "/investigate/single", req, &result)
+					if err == nil {
+						err = operationError(result)
+					}
+					if err != nil {
 						if !flagJSON {
 							output.F.Warning(fmt.Sprintf("Failed to start %s: %v", repoName, err))
 						}
@@ -257,12 +294,16 @@ Examples:
 				}
 
 				if flagJSON && !wait {
-					return output.JSON(map[string]any{
+					out := map[string]any{
 						"started": started,
 						"skipped": skipped,
-						"total":   len(enabledRepos),
-						"repos":   enabledRepos,
-					})
+						"total":   len(names),
+						"repos":   names,
+					}
+					if staleReasons != nil {
+						out["reasons"] = staleReasons
+					}
+					return output.JSON(out)
 				}
 				if started == 0 && skipped == 0 {
 					return fmt.Errorf("failed to start any investigations")
@@ -270,9 +311,9 @@ Examples:
 				if !flagJSON {
 					output.F.Println()
 					if skipped > 0 {
-						output.Successf("Started %d/%d investigations (%d skipped, use --force to override)", started, len(enabledRepos), skipped)
+						output.Successf("Started %d/%d investigations (%d skipped, use --force to override)", started, len(names), skipped)
 					} else {
-						output.Successf("Started %d/%d investigations", started, len(enabledRepos))
+						output.Successf("Started %d/%d investigations", started, len(names))
 					}
 				}
 
@@ -320,11 +361,13 @@ Examples:
 				return nil
 			}
 
-			return fmt.Errorf("specify a repo name or use --all\n\nExamples:\n  reposwarm investigate my-repo\n  reposwarm investigate --all")
+			return fmt.Errorf("specify a repo name or use --all\n\nExamples:\n  reposwarm investigate my-repo\n  reposwarm investigate --all\n  reposwarm investigate --stale")
 		},
 	}
 
 	cmd.Flags().BoolVar(&all, "all", false, "Investigate all enabled repos")
+	cmd.Flags().StringVar(&stale, "stale", "", "Investigate enabled repos with results missing or older than this (default 7d)")
+	cmd.Flags().Lookup("stale").NoOptDefVal = "7d"
 	cmd.Flags().StringVar(&model, "model", "", "Model ID (default from config)")
 	cmd.Flags().IntVar(&chunkSize, "chunk-size", 0, "Files per chunk (default from config)")
 	cmd.Flags().IntVar(&parallel, "parallel", 3, "Parallel limit (daily only)")
@@ -332,5 +375,6 @@ Examples:
 	cmd.Flags().BoolVar(&replace, "replace", false, "Terminate existing workflow for this repo before starting")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Run pre-flight only, don't create workflow")
 	cmd.Flags().BoolVar(&wait, "wait", false, "Wait and watch progress until investigation completes")
+	cmd.Flags().BoolVar(&wait, "watch", false, "Alias for --wait")
 	return cmd
 }
@@ -2,11 +2,50 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
 )
 
+// operationError returns an error when a decoded POST response reports
+// {success: false}, using its error or message field.
+func operationError(result any) error {
+	m, ok := result.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if success, ok := m["success"].(bool); !ok || success {
+		return nil
+	}
+	for _, key := range []string{"error", "message"} {
+		if msg, ok := m[key].(string); ok && msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+	}
+	return fmt.Errorf("server reported success=false")
+}
+
+// startedWorkflowID returns the workflow ID from an /investigate/single
+// response, or "" if the server didn't include one.
+func startedWorkflowID(result any) string {
+	m, ok := result.(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"workflowId", "workflowID", "workflow_id"} {
+		if id, ok := m[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	if wf, ok := m["workflow"].(map[string]any); ok {
+		return startedWorkflowID(wf)
+	}
+	return ""
+}
+
 // checkRecentInvestigations returns a map of repo names that have completed
 // investigations within the last 24 hours, along with a human-readable time ago string.
 func checkRecentInvestigations(client *api.Client, repoNames []string) map[string]string {
@@ -99,3 +138,144 @@ func formatTimeAgo(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d days ago", days)
 }
+
+// enabledRepos returns the names of the enabled repos from /repos. The
+// server only investigates these, so previews, --stale selection and
+// progress totals should all start from this list.
+func enabledRepos(client *api.Client) ([]string, error) {
+	var repos []api.Repository
+	if err := client.Get(ctx(), "/repos", &repos); err != nil {
+		return nil, fmt.Errorf("fetching repos: %w", err)
+	}
+	var names []string
+	for _, r := range repos {
+		if r.Enabled {
+			names = append(names, r.Name)
+		}
+	}
+	return names, nil
+}
+
+// isDurationArg reports whether s reads as a --stale age (e.g. "30d", "36h").
+// As a positional argument it means `--stale 30d` was typed: the flag's
+// optional value has to be attached with "=".
+func isDurationArg(s string) bool {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		_, err := strconv.Atoi(days)
+		return err == nil
+	}
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
+
+// selectStaleRepos returns the repos whose results are missing or were last
+// updated longer than maxAge (e.g. "7d") ago, with the reason for each.
+// repos is normally the enabledRepos list.
+func selectStaleRepos(client *api.Client, repos []string, maxAge string) ([]string, map[string]string, error) {
+	age, err := parseDuration(maxAge)
+	if err != nil || age <= 0 {
+		return nil, nil, fmt.Errorf("invalid --stale duration %q (e.g. 7d, 36h)", maxAge)
+	}
+
+	var wiki api.WikiReposResponse
+	if err := getWikiRepos(client, &wiki); err != nil {
+		return nil, nil, fmt.Errorf("fetching results: %w", err)
+	}
+	summaries := make(map[string]api.WikiRepoSummary, len(wiki.Repos))
+	for _, r := range wiki.Repos {
+		summaries[r.Name] = r
+	}
+
+	now := time.Now()
+	var selected []string
+	reasons := map[string]string{}
+	for _, name := range repos {
+		r, ok := summaries[name]
+		switch {
+		case !ok || r.SectionCount == 0:
+			reasons[name] = "no results"
+		case r.LastUpdated == "":
+			reasons[name] = "last update unknown"
+		default:
+			updated, ok := parseTimestamp(r.LastUpdated)
+			if !ok {
+				reasons[name] = "last update unknown"
+			} else if now.Sub(updated) > age {
+				reasons[name] = "updated " + formatTimeAgo(now.Sub(updated))
+			}
+		}
+		if _, ok := reasons[name]; ok {
+			selected = append(selected, name)
+		}
+	}
+	return selected, reasons, nil
+}
+
+// printInvestigatePlan shows what 'investigate --all' would start: the
+// settings, the exact request per repo, and the repos skipped as recently
+// investigated. Nothing is posted. reasons, if set, says why each repo was
+// selected (--stale).
+func printInvestigatePlan(client *api.Client, repos []string, reasons map[string]string, model string, chunkSize int, force bool) error {
+	var recent map[string]string
+	if !force {
+		recent = checkRecentInvestigations(client, repos)
+	}
+
+	type skippedRepo struct {
+		Repo             string `json:"repo"`
+		LastInvestigated string `json:"lastInvestigated"`
+	}
+	requests := []api.InvestigateRequest{}
+	skipped := []skippedRepo{}
+	for _, name := range repos {
+		if ago, ok := recent[name]; ok {
+			skipped = append(skipped, skippedRepo{name, ago})
+			continue
+		}
+		requests = append(requests, api.InvestigateRequest{RepoName: name, Model: model, ChunkSize: chunkSize, Force: force})
+	}
+
+	if flagJSON {
+		toRun := []string{}
+		for _, r := range requests {
+			toRun = append(toRun, r.RepoName)
+		}
+		out := map[string]any{
+			"dryRun":    true,
+			"model":     model,
+			"chunkSize": chunkSize,
+			"force":     force,
+			"repos":     toRun,
+			"skipped":   skipped,
+			"requests":  requests,
+		}
+		if reasons != nil {
+			out["reasons"] = reasons
+		}
+		return output.JSON(out)
+	}
+
+	F := output.F
+	F.Section("Investigation Plan (dry run)")
+	F.KeyValue("Model", orDefault(model, "(server default)"))
+	chunk := "(server default)"
+	if chunkSize > 0 {
+		chunk = fmt.Sprint(chunkSize)
+	}
+	F.KeyValue("Chunk Size", chunk)
+	F.KeyValue("Repos", fmt.Sprintf("%d to start, %d skipped", len(requests), len(skipped)))
+	F.Println()
+	for _, r := range requests {
+		if reason, ok := reasons[r.RepoName]; ok {
+			F.Printf("  %s %s %s\n", output.Green("▶"), r.RepoName, output.Dim("("+reason+")"))
+		} else {
+			F.Printf("  %s %s\n", output.Green("▶"), r.RepoName)
+		}
+	}
+	for _, s := range skipped {
+		F.Printf("  %s %s (investigated %s, use --force to include)\n", output.Dim("⊘"), s.Repo, s.LastInvestigated)
+	}
+	F.Println()
+	F.Info("Dry run: no investigations were started")
+	return nil
+}
@@ -195,3 +195,91 @@ func TestCheckRecentInvestigations_APIError(t *testing.T) {
 		t.Errorf("Expected empty map on API error, got %d entries", len(result))
 	}
 }
+
+func TestOperationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  any
+		wantErr string
+	}{
+		{"success true", map[string]any{"success": true, "workflowId": "wf-1"}, ""},
+		{"no success field", map[string]any{"workflowId": "wf-1"}, ""},
+		{"not a map", []any{"x"}, ""},
+		{"success false with error", map[string]any{"success": false, "error": "no enabled repos"}, "no enabled repos"},
+		{"success false with message", map[string]any{"success": false, "message": "repo disabled"}, "repo disabled"},
+		{"success false bare", map[string]any{"success": false}, "server reported success=false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := operationError(tt.result)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartedWorkflowID(t *testing.T) {
+	tests := []struct {
+		name   string
+		result any
+		want   string
+	}{
+		{"top level", map[string]any{"workflowId": "investigate-single-my-repo-1700000000000"}, "investigate-single-my-repo-1700000000000"},
+		{"nested", map[string]any{"workflow": map[string]any{"workflowId": "wf-1"}}, "wf-1"},
+		{"missing", map[string]any{"success": true}, ""},
+		{"not an object", "started", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startedWorkflowID(tt.result); got != tt.want {
+				t.Errorf("startedWorkflowID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRepoWorkflowMatchesBothIDForms(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := api.WorkflowsResponse{Executions: []api.WorkflowExecution{
+			{WorkflowID: "investigate-single-other-1700000000000", StartTime: "2024-01-03T00:00:00Z"},
+			{WorkflowID: "investigate-single-repo-my-repo", StartTime: "2024-01-02T00:00:00Z"},
+			{WorkflowID: "investigate-single-my-repo-1690000000000", StartTime: "2024-01-01T00:00:00Z"},
+		}}
+		json.NewEncoder(w).Encode(map[string]any{"data": resp})
+	}))
+	defer server.Close()
+
+	id, err := findRepoWorkflow(api.New(server.URL, "test-token"), "my-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "investigate-single-repo-my-repo" {
+		t.Errorf("findRepoWorkflow() = %q, want investigate-single-repo-my-repo", id)
+	}
+}
+
+func TestEnabledRepos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"data": []api.Repository{
+			{Name: "on", Enabled: true},
+			{Name: "off"},
+			{Name: "also-on", Enabled: true},
+		}})
+	}))
+	defer server.Close()
+
+	names, err := enabledRepos(api.New(server.URL, "test-token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "on" || names[1] != "also-on" {
+		t.Errorf("enabledRepos() = %v, want [on also-on]", names)
+	}
+}
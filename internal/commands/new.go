@@ -3,6 +3,7 @@ package commands
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"net/http"
 	"sort"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/reposwarm/reposwarm-cli/internal/bootstrap"
@@ -21,12 +23,23 @@ import (
 func newNewCmd() *cobra.Command {
 	var dir string
 	var agentMode bool
+	var agentCmd string
+	var awsRegion, awsProfile string
+	var jsonStream bool
 	var guideOnly bool
 	var forceMode bool
+	var allowNonEmpty bool
 	var localMode bool
 	var archHubURL string
 	var archHubRepo string
 	var gitToken string
+	var skip string
+	var only string
+	var composeFile string
+	var composeOverride string
+	var temporalTimeout time.Duration
+	var serviceTimeout time.Duration
+	var pollInterval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "new",
@@ -37,16 +50,46 @@ interactive setup.
 
 Use --local to automatically set up and start all services locally
 (Temporal, API, Worker, UI) via Docker Compose using pre-built images.
+Completed steps are recorded in .reposwarm-setup-state.json, so re-running
+after a failure resumes where it stopped; --force redoes every step.
+A --dir that already holds unrelated files (no api/, worker/, temporal/ or
+setup state) needs confirmation, or --allow-non-empty.
+
+Compose customization: --compose-override (config key composeOverride) is copied
+to docker-compose.override.yml, which docker compose merges on top of the
+generated file. --compose-file (config key composeFile) replaces the generated
+file entirely. Published ports must still match the configured apiPort, uiPort,
+temporalPort and temporalUiPort.
+
+--aws-region and --aws-profile pick the AWS account for this setup only: they
+go into the generated .env files and guides instead of the region config key
+and the AWS_REGION/AWS_PROFILE environment variables.
+
+--agent-cmd (config key agentCmd) replaces the built-in launch of Claude Code,
+Codex, Aider or Cursor with your own command, run through the shell in the
+install directory. {guide} and {dir} are replaced with the agent guide path
+and the install directory, shell-quoted, so don't quote them yourself.
+
+With --verbose, output from docker compose and other setup commands is
+streamed to stderr as it runs (it is always captured in the install log).
 
 Examples:
   reposwarm new                    # Interactive setup in ~/.reposwarm
   reposwarm new --local            # Automated local setup (start everything)
+  reposwarm new --local --skip ui,worker   # Leave out the UI and worker
+  reposwarm new --local --only temporal,api  # Just the backend for CLI testing
+  reposwarm new --local --compose-override ./limits.yml  # Merge extra compose settings
   reposwarm new --dir ~/projects   # Custom install directory
   reposwarm new --agent            # Auto-launch coding agent
+  reposwarm new --agent --agent-cmd 'my-agent --guide {guide}'  # Launch a custom wrapper
   reposwarm new --guide-only       # Just generate the guide file`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Detect environment
 			env := bootstrap.Detect()
+			if awsRegion != "" {
+				env.AWSRegion = awsRegion
+			}
+			env.UseAWSProfile(awsProfile)
 
 			if dir == "" {
 				dir = env.InstallDir()
@@ -54,35 +97,67 @@ Examples:
 
 			missing := env.MissingDeps()
 
+			if (skip != "" || only != "") && !localMode {
+				return fmt.Errorf("--skip and --only require --local")
+			}
+			if jsonStream && !localMode {
+				return fmt.Errorf("--json-stream requires --local")
+			}
+			newJSONStream = jsonStream
+
 			// --local mode: automated setup
 			if localMode {
+				skipped, err := bootstrap.ResolveSkip(splitCSV(skip), splitCSV(only))
+				if err != nil {
+					return err
+				}
+				if err := confirmInstallDir(dir, flagJSON || jsonStream, allowNonEmpty); err != nil {
+					return err
+				}
 				// Check if there's already a local install
-				if existing := detectExistingInstall(dir, flagJSON, flagAgent, forceMode); existing {
+				if existing := detectExistingInstall(dir, env, flagJSON || jsonStream, flagAgent, forceMode); existing {
 					return nil
 				}
 				cliCfg, _ := config.Load()
 				bsCfg := &bootstrap.Config{
-					WorkerRepoURL:  cliCfg.EffectiveWorkerRepoURL(),
-					APIRepoURL:     cliCfg.EffectiveAPIRepoURL(),
-					UIRepoURL:      cliCfg.EffectiveUIRepoURL(),
-					DynamoDBTable:  cliCfg.EffectiveDynamoDBTable(),
-					DefaultModel:   cliCfg.EffectiveModel(),
-					TemporalPort:   cliCfg.EffectiveTemporalPort(),
-					TemporalUIPort: cliCfg.EffectiveTemporalUIPort(),
-					APIPort:        cliCfg.EffectiveAPIPort(),
-					UIPort:         cliCfg.EffectiveUIPort(),
-					Region:         cliCfg.Region,
+					WorkerRepoURL:   cliCfg.EffectiveWorkerRepoURL(),
+					APIRepoURL:      cliCfg.EffectiveAPIRepoURL(),
+					UIRepoURL:       cliCfg.EffectiveUIRepoURL(),
+					DynamoDBTable:   cliCfg.EffectiveDynamoDBTable(),
+					DefaultModel:    cliCfg.EffectiveModel(),
+					TemporalPort:    cliCfg.EffectiveTemporalPort(),
+					TemporalUIPort:  cliCfg.EffectiveTemporalUIPort(),
+					APIPort:         cliCfg.EffectiveAPIPort(),
+					UIPort:          cliCfg.EffectiveUIPort(),
+					Region:          orDefault(awsRegion, cliCfg.Region),
+					AWSProfile:      env.AWSProfile,
+					ProxyURL:        effectiveProxy(cliCfg),
+					Force:           forceMode,
+					Skip:            skipped,
+					Verbose:         flagVerbose,
+					ComposeFile:     orDefault(composeFile, cliCfg.ComposeFile),
+					ComposeOverride: orDefault(composeOverride, cliCfg.ComposeOverride),
+					TemporalTimeout: durationOr(temporalTimeout, cliCfg.TemporalTimeout),
+					ServiceTimeout:  durationOr(serviceTimeout, cliCfg.ServiceTimeout),
+					PollInterval:    durationOr(pollInterval, cliCfg.ReadinessPollInterval),
 				}
 				if bsCfg.Region == "" {
 					bsCfg.Region = env.AWSRegion
 				}
 
 				// JSON / agent modes — skip plan, go straight to setup
-				if flagJSON {
-					printer := &jsonPrinter{}
+				if flagJSON || jsonStream {
+					var printer bootstrap.Printer = &jsonPrinter{}
+					if jsonStream {
+						printer = &jsonStreamPrinter{enc: json.NewEncoder(os.Stdout)}
+					}
 					result, err := bootstrap.SetupLocal(env, dir, bsCfg, printer)
 					if err != nil {
-						return output.JSON(result)
+						// Print the step detail, and still exit non-zero
+						if jsonErr := newJSON("local", dir, env, nil, result); jsonErr != nil {
+							return jsonErr
+						}
+						return fmt.Errorf("local setup failed: %w", err)
 					}
 					// Persist installDir + token so 'start/stop/restart' find it
 					cliCfg.InstallDir = dir
@@ -98,7 +173,13 @@ Examples:
 						setupDefaultLocalArchHub(dir)
 					}
 
-					return output.JSON(result)
+					if err := newJSON("local", dir, env, nil, result); err != nil {
+						return err
+					}
+					if !result.Success {
+						return fmt.Errorf("local setup finished with failed steps (see \"steps\")")
+					}
+					return nil
 				}
 				if flagAgent {
 					printer := &fmtPrinter{}
@@ -261,7 +342,7 @@ Examples:
 				return nil
 			}
 
-			// JSON mode — generate guides
+			// JSON mode (with or without --guide-only) — generate guides
 			if flagJSON {
 				cliCfgGuide, _ := config.Load()
 				guideCfg := cfgToBootstrap(cliCfgGuide, env)
@@ -272,14 +353,16 @@ Examples:
 					return err
 				}
 
-				return output.JSON(map[string]any{
-					"environment":    env,
-					"installDir":     dir,
-					"missing":        missing,
+				mode := "guide"
+				if guideOnly {
+					mode = "guide-only"
+				}
+				return newJSON(mode, dir, env, map[string]string{
+					"guide":      filepath.Join(dir, "INSTALL.md"),
+					"agentGuide": filepath.Join(dir, "REPOSWARM_INSTALL.md"),
+				}, map[string]any{
 					"agentAvailable": env.AgentName() != "",
 					"agent":          env.AgentName(),
-					"guidePath":      filepath.Join(dir, "INSTALL.md"),
-					"agentGuidePath": filepath.Join(dir, "REPOSWARM_INSTALL.md"),
 				})
 			}
 
@@ -292,7 +375,7 @@ Examples:
 			if len(missing) > 0 {
 				fmt.Printf("\n  %s Missing dependencies:\n", output.Yellow("⚠"))
 				for _, dep := range missing {
-					output.F.Printf("  %s: missing\n", dep)
+					output.F.Printf("  • %s\n", dep)
 				}
 				fmt.Println()
 			}
@@ -311,11 +394,20 @@ Examples:
 				return err
 			}
 
-			// Check for coding agent
+			// Check for coding agent; a custom command works without a detected one
 			agent := env.AgentName()
+			agentCmd = orDefault(agentCmd, cliCfgGuide2.AgentCmd)
+			if agentCmd != "" {
+				agent = customAgent
+			}
 			if agent != "" && !agentMode {
-				fmt.Printf("\n  %s detected! Use it for interactive installation? [Y/n] ",
-					output.Bold(agentDisplayName(agent)))
+				if agent == customAgent {
+					fmt.Printf("\n  Run %s for interactive installation? [Y/n] ",
+						output.Bold(expandAgentCmd(agentCmd, dir)))
+				} else {
+					fmt.Printf("\n  %s detected! Use it for interactive installation? [Y/n] ",
+						output.Bold(agentDisplayName(agent)))
+				}
 				reader := bufio.NewReader(os.Stdin)
 				line, _ := reader.ReadString('\n')
 				line = strings.TrimSpace(strings.ToLower(line))
@@ -325,7 +417,7 @@ Examples:
 			}
 
 			if agentMode && agent != "" {
-				return launchAgent(agent, dir)
+				return launchAgent(agent, dir, agentCmd)
 			}
 
 			// No agent — show manual instructions
@@ -345,6 +437,10 @@ Examples:
 					fmt.Printf("    %s\n", output.Cyan(fmt.Sprintf("cd %s && codex \"Follow REPOSWARM_INSTALL.md step by step\"", dir)))
 				case "aider":
 					fmt.Printf("    %s\n", output.Cyan(fmt.Sprintf("cd %s && aider --read REPOSWARM_INSTALL.md", dir)))
+				case "cursor":
+					fmt.Printf("    %s\n", output.Cyan(fmt.Sprintf("cursor %s %s", dir, filepath.Join(dir, "REPOSWARM_INSTALL.md"))))
+				case customAgent:
+					fmt.Printf("    %s\n", output.Cyan(expandAgentCmd(agentCmd, dir)))
 				}
 			}
 
@@ -355,15 +451,87 @@ Examples:
 
 	cmd.Flags().StringVar(&dir, "dir", "", "Installation directory (default: ~/.reposwarm)")
 	cmd.Flags().BoolVar(&agentMode, "agent", false, "Auto-launch coding agent for installation")
-	cmd.Flags().BoolVar(&forceMode, "force", false, "Destroy existing install without prompting")
+	cmd.Flags().StringVar(&agentCmd, "agent-cmd", "", "Command to launch instead of the detected agent; {guide} and {dir} are substituted (config key agentCmd)")
+	cmd.Flags().BoolVar(&forceMode, "force", false, "Destroy existing install and redo all setup steps without prompting")
+	cmd.Flags().BoolVar(&allowNonEmpty, "allow-non-empty", false, "With --local, install into a --dir that already holds unrelated files")
 	cmd.Flags().BoolVar(&guideOnly, "guide-only", false, "Only generate guide files, don't prompt")
 	cmd.Flags().BoolVar(&localMode, "local", false, "Automated local setup: start Temporal, API, Worker, and UI")
 	cmd.Flags().StringVar(&archHubURL, "arch-hub-url", "", "Architecture hub base URL (e.g. https://github.com/my-org)")
 	cmd.Flags().StringVar(&archHubRepo, "arch-hub-repo", "", "Architecture hub repo name (default: architecture-hub)")
 	cmd.Flags().StringVar(&gitToken, "git-token", "", "GitHub token for repo access and arch-hub pushes")
+	cmd.Flags().BoolVar(&jsonStream, "json-stream", false, "With --local, print one JSON progress event per line as setup runs, then the result")
+	cmd.Flags().StringVar(&awsRegion, "aws-region", "", "AWS region for this setup (overrides the region config key and AWS_REGION)")
+	cmd.Flags().StringVar(&awsProfile, "aws-profile", "", "AWS profile for this setup (overrides AWS_PROFILE)")
+	cmd.Flags().StringVar(&skip, "skip", "", "Services to leave out with --local (comma-separated: worker, ui, api)")
+	cmd.Flags().StringVar(&composeFile, "compose-file", "", "Use this docker-compose.yml instead of the generated one (--local)")
+	cmd.Flags().StringVar(&composeOverride, "compose-override", "", "Merge this file as docker-compose.override.yml (--local)")
+	cmd.Flags().DurationVar(&temporalTimeout, "temporal-timeout", 0, "How long to wait for Temporal with --local (default 5m, config key temporalTimeout)")
+	cmd.Flags().DurationVar(&serviceTimeout, "service-timeout", 0, "How long to wait for the API and UI with --local (default 2m, config key serviceTimeout)")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", 0, "Readiness probe interval with --local (default 2s, config key readinessPollInterval)")
+	cmd.Flags().StringVar(&only, "only", "", "Only start these services with --local (comma-separated: temporal, api, worker, ui)")
 	return cmd
 }
 
+// newOutput is the --json envelope every mode of 'reposwarm new' prints, so
+// tooling can parse any variant the same way.
+type newOutput struct {
+	Mode        string                 `json:"mode"` // local, guide or guide-only
+	InstallDir  string                 `json:"installDir"`
+	Environment *bootstrap.Environment `json:"environment"`
+	Missing     []string               `json:"missing"`
+	GuidePaths  map[string]string      `json:"guidePaths"`
+	Result      any                    `json:"result"`
+}
+
+// newJSONStream makes newJSON print the envelope as a single line, ending
+// a --json-stream event stream.
+var newJSONStream bool
+
+// newJSON prints the 'new' envelope. guidePaths is empty in --local mode;
+// result is the setup result there and agent info in guide modes.
+func newJSON(mode, dir string, env *bootstrap.Environment, guidePaths map[string]string, result any) error {
+	missing := env.MissingDeps()
+	if missing == nil {
+		missing = []string{}
+	}
+	if guidePaths == nil {
+		guidePaths = map[string]string{}
+	}
+	out := newOutput{
+		Mode:        mode,
+		InstallDir:  dir,
+		Environment: env,
+		Missing:     missing,
+		GuidePaths:  guidePaths,
+		Result:      result,
+	}
+	if newJSONStream {
+		return output.JSONL(out)
+	}
+	return output.JSON(out)
+}
+
+// splitCSV splits a comma-separated flag value, dropping empty entries.
+func splitCSV(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// durationOr returns flagVal when set, otherwise the duration in cfgVal
+// (zero when unset, so bootstrap falls back to its defaults).
+func durationOr(flagVal time.Duration, cfgVal string) time.Duration {
+	if flagVal > 0 {
+		return flagVal
+	}
+	d, _ := time.ParseDuration(cfgVal)
+	return d
+}
+
 // fmtPrinter implements bootstrap.Printer using the output formatter.
 type fmtPrinter struct{}
 
@@ -386,6 +554,35 @@ func (p *jsonPrinter) Warning(string)              {}
 func (p *jsonPrinter) Error(string)                {}
 func (p *jsonPrinter) Printf(string, ...any)       {}
 
+// jsonStreamPrinter emits each setup message as a newline-delimited JSON
+// event (--json-stream), tagged with the section it belongs to.
+type jsonStreamPrinter struct {
+	enc  *json.Encoder
+	step string
+}
+
+// setupEvent is one --json-stream progress line.
+type setupEvent struct {
+	Step    string `json:"step"`
+	Status  string `json:"status"` // start, info, ok, warn, fail
+	Message string `json:"message,omitempty"`
+	TS      string `json:"ts"`
+}
+
+func (p *jsonStreamPrinter) emit(status, msg string) {
+	p.enc.Encode(setupEvent{Step: p.step, Status: status, Message: msg, TS: time.Now().UTC().Format(time.RFC3339)})
+}
+
+func (p *jsonStreamPrinter) Section(title string) {
+	p.step = title
+	p.emit("start", "")
+}
+func (p *jsonStreamPrinter) Info(msg string)       { p.emit("info", msg) }
+func (p *jsonStreamPrinter) Success(msg string)    { p.emit("ok", msg) }
+func (p *jsonStreamPrinter) Warning(msg string)    { p.emit("warn", msg) }
+func (p *jsonStreamPrinter) Error(msg string)      { p.emit("fail", msg) }
+func (p *jsonStreamPrinter) Printf(string, ...any) {}
+
 func writeGuidesSilent(dir, guide, agentGuide string) error {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
@@ -421,7 +618,64 @@ func writeGuides(dir, guide, agentGuide string) error {
 	return nil
 }
 
-func launchAgent(agent, dir string) error {
+// confirmInstallDir refuses to set up in a non-empty directory that isn't a
+// previous RepoSwarm install unless the user confirms (or passes
+// --allow-non-empty).
+// jsonMode (--json or --json-stream) fails instead of prompting.
+func confirmInstallDir(dir string, jsonMode, allowNonEmpty bool) error {
+	foreign := bootstrap.ForeignEntries(dir)
+	if len(foreign) == 0 || allowNonEmpty {
+		return nil
+	}
+	shown := foreign
+	if len(shown) > 3 {
+		shown = append(shown[:3:3], fmt.Sprintf("%d more", len(foreign)-3))
+	}
+	msg := fmt.Sprintf("%s isn't empty and doesn't look like a RepoSwarm install (contains %s)", dir, strings.Join(shown, ", "))
+	if jsonMode || flagAgent || !output.StdinIsTerminal() {
+		return fmt.Errorf("%s; choose another --dir or pass --allow-non-empty", msg)
+	}
+	output.F.Warning(msg)
+	ok, err := output.Confirm("Install into it anyway?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("aborted; choose another --dir or pass --allow-non-empty")
+	}
+	return nil
+}
+
+// customAgent is the agent name used when --agent-cmd (or agentCmd) is set.
+const customAgent = "custom"
+
+// expandAgentCmd substitutes {guide} and {dir} in an --agent-cmd template.
+// The paths are shell-quoted, since the result is run through sh -c (or
+// cmd /C on Windows).
+func expandAgentCmd(tmpl, dir string) string {
+	return strings.NewReplacer(
+		"{guide}", shellQuote(filepath.Join(dir, "REPOSWARM_INSTALL.md")),
+		"{dir}", shellQuote(dir),
+	).Replace(tmpl)
+}
+
+// shellQuote quotes s for the platform shell unless it only holds
+// characters that are safe unquoted.
+func shellQuote(s string) string {
+	safe := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@+=:,./-"
+	if runtime.GOOS == "windows" {
+		safe += `\`
+	}
+	if s != "" && strings.Trim(s, safe) == "" {
+		return s
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func launchAgent(agent, dir, agentCmd string) error {
 	guidePath := filepath.Join(dir, "REPOSWARM_INSTALL.md")
 
 	fmt.Printf("\n  %s Launching %s...\n\n",
@@ -441,6 +695,29 @@ func launchAgent(agent, dir string) error {
 	case "aider":
 		cmd = exec.Command("aider", "--read", guidePath)
 		cmd.Dir = dir
+	case "cursor":
+		// Prefer Cursor's agent CLI; otherwise open the editor on the guide,
+		// which returns right away and leaves the rest to the user
+		if _, err := exec.LookPath("cursor-agent"); err == nil {
+			cmd = exec.Command("cursor-agent",
+				fmt.Sprintf("Read %s and follow every step. Install RepoSwarm in %s. Verify each step before moving to the next.", guidePath, dir))
+			cmd.Dir = dir
+			break
+		}
+		if err := exec.Command("cursor", dir, guidePath).Run(); err != nil {
+			fmt.Printf("  Couldn't open Cursor (%v). Open %s in Cursor and ask its agent to follow it step by step.\n\n", err, guidePath)
+			return nil
+		}
+		fmt.Printf("  Opened %s in Cursor. Ask its agent to follow REPOSWARM_INSTALL.md step by step.\n\n", dir)
+		return nil
+	case customAgent:
+		line := expandAgentCmd(agentCmd, dir)
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", line)
+		} else {
+			cmd = exec.Command("sh", "-c", line)
+		}
+		cmd.Dir = dir
 	default:
 		return fmt.Errorf("unsupported agent: %s", agent)
 	}
@@ -464,6 +741,8 @@ func agentDisplayName(agent string) string {
 		"codex":  "Codex",
 		"cursor": "Cursor",
 		"aider":  "Aider",
+
+		customAgent: "your agent",
 	}
 	if n, ok := names[agent]; ok {
 		return n
@@ -483,6 +762,7 @@ func cfgToBootstrap(cliCfg *config.Config, env *bootstrap.Environment) *bootstra
 		APIPort:        cliCfg.EffectiveAPIPort(),
 		UIPort:         cliCfg.EffectiveUIPort(),
 		Region:         cliCfg.Region,
+		ProxyURL:       effectiveProxy(cliCfg),
 	}
 	if cfg.Region == "" {
 		cfg.Region = env.AWSRegion
@@ -492,7 +772,7 @@ func cfgToBootstrap(cliCfg *config.Config, env *bootstrap.Environment) *bootstra
 
 // detectExistingInstall checks for an existing local installation and prompts
 // the user on what to do. Returns true if we should abort (user chose to keep existing).
-func detectExistingInstall(dir string, jsonMode, agentMode, forceMode bool) bool {
+func detectExistingInstall(dir string, env *bootstrap.Environment, jsonMode, agentMode, forceMode bool) bool {
 	// Check if the install directory exists with content
 	entries, err := os.ReadDir(dir)
 	if err != nil || len(entries) == 0 {
@@ -519,12 +799,11 @@ func detectExistingInstall(dir string, jsonMode, agentMode, forceMode bool) bool
 	}
 
 	if jsonMode {
-		output.JSON(map[string]any{
-			"error":      "existing_install",
-			"message":    "A local RepoSwarm installation is already running",
-			"installDir": dir,
-			"temporal":   temporalUp,
-			"api":        apiUp,
+		newJSON("local", dir, env, nil, map[string]any{
+			"error":    "existing_install",
+			"message":  "A local RepoSwarm installation is already running",
+			"temporal": temporalUp,
+			"api":      apiUp,
 		})
 		return true
 	}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addOutputFileFlag adds -o/--output to cmd. When set, everything the command
+// writes to stdout (human, --json or raw output) goes to that file instead.
+func addOutputFileFlag(cmd *cobra.Command) {
+	var path string
+	cmd.Flags().StringVarP(&path, "output", "o", "", "Write output to this file instead of stdout")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if path == "" {
+			return run(cmd, args)
+		}
+		return writeOutputFile(path, func() error { return run(cmd, args) })
+	}
+}
+
+// writeOutputFile runs fn with stdout redirected to path, creating parent
+// directories as needed, and reports the bytes written on stderr.
+func writeOutputFile(path string, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+
+	restore := output.Redirect(f)
+	runErr := fn()
+	restore()
+
+	info, statErr := f.Stat()
+	if err := f.Close(); err != nil && runErr == nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if !flagQuiet && statErr == nil {
+		fmt.Fprintf(os.Stderr, "Wrote %d bytes to %s\n", info.Size(), path)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutputFileFlag(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki/is-odd/hl_overview": map[string]any{
+			"repo": "is-odd", "section": "hl_overview",
+			"content": "# Overview\nThis is is-odd.", "createdAt": "2026-01-01",
+		},
+	})
+	defer cleanup()
+
+	dir := t.TempDir()
+
+	t.Run("raw", func(t *testing.T) {
+		dest := filepath.Join(dir, "nested", "overview.md")
+		out, err := runCmd(t, "results", "read", "is-odd", "hl_overview", "--raw", "-o", dest)
+		if err != nil {
+			t.Fatalf("results read -o: %v", err)
+		}
+		if strings.Contains(out, "# Overview") {
+			t.Errorf("content should not go to stdout: %s", out)
+		}
+		data, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatalf("output file: %v", err)
+		}
+		if !strings.Contains(string(data), "# Overview") {
+			t.Errorf("file should contain markdown: %s", data)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		dest := filepath.Join(dir, "overview.json")
+		if _, err := runCmd(t, "results", "read", "is-odd", "hl_overview", "--json", "--output", dest); err != nil {
+			t.Fatalf("results read --json -o: %v", err)
+		}
+		data, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatalf("output file: %v", err)
+		}
+		var v map[string]any
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Errorf("file should hold JSON: %v\n%s", err, data)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// pingResult is one GET /health attempt.
+type pingResult struct {
+	Seq       int     `json:"seq"`
+	OK        bool    `json:"ok"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func newPingCmd() *cobra.Command {
+	var count int
+	var interval int
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Lightweight API connectivity check",
+		Long: `Send GET /health with a short timeout and print OK/FAIL with latency.
+
+Exits non-zero if any attempt fails, which makes it suitable for scripts and
+readiness loops. Use 'status' for the full health view and 'doctor' for a
+complete diagnosis.
+
+Examples:
+  reposwarm ping
+  reposwarm ping --count 5 --interval 2
+  until reposwarm ping; do sleep 1; done`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 1 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var results []pingResult
+			failed := 0
+			for i := 1; i <= count; i++ {
+				if i > 1 {
+					time.Sleep(time.Duration(interval) * time.Second)
+				}
+
+				c, cancel := context.WithTimeout(ctx(), time.Duration(timeout)*time.Second)
+				start := time.Now()
+				_, err := client.Health(c)
+				latency := time.Since(start)
+				cancel()
+
+				r := pingResult{Seq: i, OK: err == nil, LatencyMs: float64(latency.Microseconds()) / 1000}
+				if err != nil {
+					r.Error = err.Error()
+					failed++
+				}
+				results = append(results, r)
+
+				if flagJSON {
+					continue
+				}
+				if err != nil {
+					output.F.Printf("  %s %s/health seq=%d: %s\n", output.Red("FAIL"), client.BaseURL, i, err)
+				} else {
+					output.F.Printf("  %s %s/health seq=%d time=%s\n", output.Green("OK"), client.BaseURL, i, latency.Round(time.Millisecond))
+				}
+			}
+
+			if flagJSON {
+				if err := output.JSON(map[string]any{
+					"url":     client.BaseURL,
+					"ok":      failed == 0,
+					"sent":    count,
+					"failed":  failed,
+					"results": results,
+				}); err != nil {
+					return err
+				}
+			} else if count > 1 {
+				output.F.Printf("\n  %d sent, %d ok, %d failed\n", count, count-failed, failed)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("ping failed (%d of %d)", failed, count)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "c", 1, "Number of pings to send")
+	cmd.Flags().IntVar(&interval, "interval", 1, "Seconds between pings")
+	cmd.Flags().IntVar(&timeout, "timeout", 3, "Per-ping timeout in seconds")
+	return cmd
+}
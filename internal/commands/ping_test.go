@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPingCmd(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/health": map[string]any{"status": "healthy"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "ping", "--count", "2", "--interval", "0", "--json")
+	if err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, out)
+	}
+	if result["ok"] != true || result["sent"] != float64(2) || result["failed"] != float64(0) {
+		t.Errorf("result = %v", result)
+	}
+}
+
+func TestPingCmdFailure(t *testing.T) {
+	server, cleanup := testServer(t, nil)
+	defer cleanup()
+	server.Close()
+
+	if _, err := runCmd(t, "ping", "--timeout", "1"); err == nil {
+		t.Fatal("expected ping to fail against a closed server")
+	}
+}
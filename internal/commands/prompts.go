@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -22,11 +22,14 @@ Prompts are JSON-configured with markdown templates. Each prompt has a type
 	}
 	cmd.AddCommand(newPromptsListCmd())
 	cmd.AddCommand(newPromptsShowCmd())
+	cmd.AddCommand(newPromptsPreviewCmd())
+	cmd.AddCommand(newPromptsSearchCmd())
 	cmd.AddCommand(newPromptsCreateCmd())
 	cmd.AddCommand(newPromptsUpdateCmd())
 	cmd.AddCommand(newPromptsDeleteCmd())
 	cmd.AddCommand(newPromptsToggleCmd())
 	cmd.AddCommand(newPromptsOrderCmd())
+	cmd.AddCommand(newPromptsReorderCmd())
 	cmd.AddCommand(newPromptsContextCmd())
 	cmd.AddCommand(newPromptsVersionsCmd())
 	cmd.AddCommand(newPromptsRollbackCmd())
@@ -38,7 +41,8 @@ Prompts are JSON-configured with markdown templates. Each prompt has a type
 
 func newPromptsListCmd() *cobra.Command {
 	var promptType string
-	var enabledOnly, disabledOnly bool
+	var enabledOnly, disabledOnly, all bool
+	var filters []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -49,6 +53,21 @@ func newPromptsListCmd() *cobra.Command {
 				return err
 			}
 
+			byField, err := parseFieldFilters(filters)
+			if err != nil {
+				return err
+			}
+			if enabledOnly && disabledOnly || all && (enabledOnly || disabledOnly) {
+				return fmt.Errorf("--enabled, --disabled and --all are mutually exclusive")
+			}
+			// Without an explicit flag, fall back to the configured default
+			if !enabledOnly && !disabledOnly && !all {
+				if cfg, err := config.Load(); err == nil {
+					enabledOnly = cfg.PromptListDefault == "enabled"
+					disabledOnly = cfg.PromptListDefault == "disabled"
+				}
+			}
+
 			path := "/prompts"
 			if promptType != "" {
 				path = "/prompts/types/" + promptType
@@ -58,10 +77,12 @@ func newPromptsListCmd() *cobra.Command {
 			if err := client.Get(ctx(), path, &prompts); err != nil {
 				return err
 			}
-
 			// If API has prompts, filter and show
 			if len(prompts) > 0 {
-				var filtered []api.Prompt
+				if prompts, err = filterByFields(prompts, byField); err != nil {
+					return err
+				}
+				filtered := []api.Prompt{}
 				for _, p := range prompts {
 					if enabledOnly && !p.Enabled {
 						continue
@@ -72,7 +93,7 @@ func newPromptsListCmd() *cobra.Command {
 					filtered = append(filtered, p)
 				}
 				if flagJSON {
-					return output.JSON(filtered)
+					return outputList(filtered)
 				}
 				fmt.Printf("\n  %s (%d prompts)\n\n", output.Bold("Prompts"), len(filtered))
 			headers := []string{"Name", "Type", "Enabled", "Order", "Version"}
@@ -130,15 +151,11 @@ func newPromptsListCmd() *cobra.Command {
 			}
 
 			if flagJSON {
-				type ds struct {
-					Name  string `json:"name"`
-					Count int    `json:"frequency"`
-				}
-				var derived []ds
+				out := derivedSections{Source: "derived_from_results"}
 				for _, s := range index.Sections {
-					derived = append(derived, ds{Name: s.Name(), Count: sectionFreq[s.Name()]})
+					out.Sections = append(out.Sections, derivedSection{Name: s.Name(), Count: sectionFreq[s.Name()]})
 				}
-				return output.JSON(map[string]any{"source": "derived_from_results", "sections": derived})
+				return output.JSON(out)
 			}
 
 			F := output.F
@@ -162,6 +179,8 @@ func newPromptsListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&promptType, "type", "", "Filter by prompt type")
 	cmd.Flags().BoolVar(&enabledOnly, "enabled", false, "Show only enabled")
 	cmd.Flags().BoolVar(&disabledOnly, "disabled", false, "Show only disabled")
+	cmd.Flags().BoolVar(&all, "all", false, "Show all prompts, ignoring the promptListDefault config key")
+	addFieldFilterFlag(cmd, &filters)
 	return cmd
 }
 
@@ -319,10 +338,11 @@ func newPromptsDeleteCmd() *cobra.Command {
 		Args:  friendlyExactArgs(1, "reposwarm prompts delete <name>\n\nExample:\n  reposwarm prompts delete hl_overview"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !yes {
-				fmt.Printf("  Delete prompt %s? [y/N] ", output.Bold(args[0]))
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm(fmt.Sprintf("Delete prompt %s?", output.Bold(args[0])))
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.Infof("Cancelled")
 					return nil
 				}
@@ -347,11 +367,37 @@ func newPromptsDeleteCmd() *cobra.Command {
 }
 
 func newPromptsToggleCmd() *cobra.Command {
-	return &cobra.Command{
+	var promptType string
+	var on, off, yes bool
+
+	cmd := &cobra.Command{
 		Use:   "toggle <name>",
 		Short: "Toggle enabled/disabled",
-		Args:  friendlyExactArgs(1, "reposwarm prompts toggle <name>\n\nExample:\n  reposwarm prompts toggle hl_overview"),
+		Long: `Toggle a prompt between enabled and disabled.
+
+With --type and --on or --off, every prompt of that type is switched to the
+given state; prompts already in that state are left alone.
+
+Examples:
+  reposwarm prompts toggle hl_overview
+  reposwarm prompts toggle --type detection --off
+  reposwarm prompts toggle --type detection --on -y`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if promptType != "" {
+				return friendlyMaxArgs(0, "reposwarm prompts toggle --type <type> --on|--off")(cmd, args)
+			}
+			return friendlyExactArgs(1, "reposwarm prompts toggle <name>\n\nExample:\n  reposwarm prompts toggle hl_overview")(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if promptType != "" {
+				if !on && !off {
+					return fmt.Errorf("--type needs --on or --off")
+				}
+				return togglePromptType(promptType, on, yes)
+			}
+			if on || off {
+				return fmt.Errorf("--on/--off are only used with --type")
+			}
 			client, err := getClient()
 			if err != nil {
 				return err
@@ -371,6 +417,91 @@ func newPromptsToggleCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&promptType, "type", "", "Switch every prompt of this type (with --on or --off)")
+	cmd.Flags().BoolVar(&on, "on", false, "With --type, enable the prompts")
+	cmd.Flags().BoolVar(&off, "off", false, "With --type, disable the prompts")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation")
+	cmd.MarkFlagsMutuallyExclusive("on", "off")
+	return cmd
+}
+
+// togglePromptType toggles every prompt of promptType whose state differs
+// from enable.
+func togglePromptType(promptType string, enable, yes bool) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+	var prompts []api.Prompt
+	if err := client.Get(ctx(), "/prompts/types/"+promptType, &prompts); err != nil {
+		return err
+	}
+	if len(prompts) == 0 {
+		return fmt.Errorf("no prompts of type %q", promptType)
+	}
+
+	state := "disabled"
+	if enable {
+		state = "enabled"
+	}
+	var pending []string
+	for _, p := range prompts {
+		if p.Enabled != enable {
+			pending = append(pending, p.Name)
+		}
+	}
+
+	if len(pending) > 0 && !yes && !flagJSON {
+		output.F.Section(fmt.Sprintf("%d %s prompt(s) to be %s", len(pending), promptType, state))
+		output.F.List(pending)
+		ok, err := output.Confirm(fmt.Sprintf("Switch %d prompt(s)?", len(pending)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			output.F.Info("Cancelled")
+			return nil
+		}
+	}
+
+	changed := []string{}
+	failed := map[string]string{}
+	for _, name := range pending {
+		var result api.Prompt
+		if err := client.Patch(ctx(), "/prompts/"+name+"/toggle", nil, &result); err != nil {
+			failed[name] = err.Error()
+			continue
+		}
+		changed = append(changed, name)
+	}
+
+	if flagJSON {
+		if err := output.JSON(map[string]any{
+			"type":      promptType,
+			"enabled":   enable,
+			"changed":   changed,
+			"unchanged": len(prompts) - len(pending),
+			"failed":    failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		if len(pending) == 0 {
+			output.F.Info(fmt.Sprintf("All %d %s prompt(s) are already %s", len(prompts), promptType, state))
+		} else {
+			output.Successf("%d %s prompt(s) now %s", len(changed), promptType, state)
+		}
+		for _, name := range pending {
+			if msg, ok := failed[name]; ok {
+				output.F.Error(fmt.Sprintf("%s: %s", name, msg))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d prompt(s) could not be toggled", len(failed), len(pending))
+	}
+	return nil
 }
 
 func newPromptsOrderCmd() *cobra.Command {
@@ -484,6 +615,18 @@ func newPromptsRollbackCmd() *cobra.Command {
 	}
 }
 
+// derivedSections is what 'prompts list --json' prints instead of prompts
+// when the server has none and sections are derived from results.
+type derivedSections struct {
+	Source   string           `json:"source"`
+	Sections []derivedSection `json:"sections"`
+}
+
+type derivedSection struct {
+	Name  string `json:"name"`
+	Count int    `json:"frequency"`
+}
+
 func newPromptsTypesCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "types",
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// placeholderRe matches template placeholders like {{repo}} or {{ repo_url }}.
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.]*)\s*\}\}`)
+
+func newPromptsPreviewCmd() *cobra.Command {
+	var repo string
+
+	cmd := &cobra.Command{
+		Use:   "preview <name>",
+		Short: "Render a prompt template with a repo's values filled in",
+		Long: `Fetch a prompt and fill in its {{placeholders}} locally with the metadata of
+--repo, so you can see what the model will be asked before investigating.
+
+Known placeholders: ` + strings.Join(previewPlaceholderNames(), ", ") + `.
+Anything else is left in place and highlighted.
+
+Examples:
+  reposwarm prompts preview hl_overview --repo my-repo
+  reposwarm prompts preview hl_overview --repo my-repo --json`,
+		Args: friendlyExactArgs(1, "reposwarm prompts preview <name> --repo <repo>\n\nExample:\n  reposwarm prompts preview hl_overview --repo my-repo"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var prompt api.Prompt
+			if err := client.Get(ctx(), "/prompts/"+args[0], &prompt); err != nil {
+				return err
+			}
+			var r api.Repository
+			if err := client.Get(ctx(), "/repos/"+repo, &r); err != nil {
+				return err
+			}
+
+			values := previewValues(prompt, r)
+			rendered, unresolved := renderPromptTemplate(prompt.Template, values, func(s string) string { return s })
+
+			if flagJSON {
+				if unresolved == nil {
+					unresolved = []string{}
+				}
+				return output.JSON(map[string]any{
+					"prompt":     prompt.Name,
+					"repo":       r.Name,
+					"rendered":   rendered,
+					"unresolved": unresolved,
+				})
+			}
+
+			// Highlight what couldn't be filled in
+			highlighted, _ := renderPromptTemplate(prompt.Template, values, func(s string) string {
+				return output.Yellow(s)
+			})
+			F := output.F
+			F.Section(fmt.Sprintf("Prompt %s for %s", prompt.Name, r.Name))
+			F.Println(highlighted)
+			if len(unresolved) > 0 {
+				F.Println()
+				F.Warning(fmt.Sprintf("Unresolved placeholders: %s", strings.Join(unresolved, ", ")))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "Repository whose metadata fills the template")
+	cmd.MarkFlagRequired("repo")
+	return cmd
+}
+
+// previewValues maps the placeholders prompts use to a repo's metadata.
+func previewValues(p api.Prompt, r api.Repository) map[string]string {
+	return map[string]string{
+		"repo":        r.Name,
+		"repo_name":   r.Name,
+		"name":        r.Name,
+		"url":         r.URL,
+		"repo_url":    r.URL,
+		"source":      r.Source,
+		"description": r.Description,
+		"context":     p.Context,
+	}
+}
+
+func previewPlaceholderNames() []string {
+	var names []string
+	for k := range previewValues(api.Prompt{}, api.Repository{}) {
+		names = append(names, "{{"+k+"}}")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderPromptTemplate substitutes known placeholders (case-insensitively)
+// and passes unknown ones through mark. It returns the rendered text and
+// the unresolved placeholder names, in order of first appearance.
+func renderPromptTemplate(tmpl string, values map[string]string, mark func(string) string) (string, []string) {
+	var unresolved []string
+	seen := map[string]bool{}
+	rendered := placeholderRe.ReplaceAllStringFunc(tmpl, func(m string) string {
+		name := placeholderRe.FindStringSubmatch(m)[1]
+		if v, ok := values[strings.ToLower(name)]; ok {
+			return v
+		}
+		if !seen[name] {
+			seen[name] = true
+			unresolved = append(unresolved, name)
+		}
+		return mark(m)
+	})
+	return rendered, unresolved
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderPromptTemplate(t *testing.T) {
+	values := map[string]string{"repo": "left-pad", "url": "https://github.com/acme/left-pad"}
+	got, unresolved := renderPromptTemplate("Analyze {{repo}} at {{ URL }}. {{tone}} and {{tone}} {{depth}}", values, func(s string) string {
+		return "<" + s + ">"
+	})
+	want := "Analyze left-pad at https://github.com/acme/left-pad. <{{tone}}> and <{{tone}}> <{{depth}}>"
+	if got != want {
+		t.Errorf("rendered = %q, want %q", got, want)
+	}
+	if strings.Join(unresolved, ",") != "tone,depth" {
+		t.Errorf("unresolved = %v, want [tone depth]", unresolved)
+	}
+}
+
+func TestPromptsPreview(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /prompts/hl_overview": map[string]any{"name": "hl_overview", "template": "Describe {{repo}} ({{source}}). Focus: {{focus}}"},
+		"GET /repos/left-pad":      map[string]any{"name": "left-pad", "source": "GitHub"},
+	})
+	defer cleanup()
+
+	if _, err := runCmd(t, "prompts", "preview", "hl_overview"); err == nil {
+		t.Error("expected --repo to be required")
+	}
+
+	out, err := runCmd(t, "prompts", "preview", "hl_overview", "--repo", "left-pad", "--json")
+	if err != nil {
+		t.Fatalf("prompts preview: %v", err)
+	}
+	var result struct {
+		Rendered   string   `json:"rendered"`
+		Unresolved []string `json:"unresolved"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Rendered != "Describe left-pad (GitHub). Focus: {{focus}}" {
+		t.Errorf("rendered = %q", result.Rendered)
+	}
+	if len(result.Unresolved) != 1 || result.Unresolved[0] != "focus" {
+		t.Errorf("unresolved = %v", result.Unresolved)
+	}
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// promptOrderChange is one prompt's position before and after a reorder.
+type promptOrderChange struct {
+	Name     string `json:"name"`
+	OldOrder int    `json:"oldOrder"`
+	Order    int    `json:"order"`
+}
+
+func newPromptsReorderCmd() *cobra.Command {
+	var file string
+	var start int
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "reorder [name1,name2,...]",
+		Short: "Set the execution order of several prompts at once",
+		Long: `Assign sequential order values to prompts in the given sequence.
+
+The list can be given as a comma-separated argument, read from a file with
+--file, or piped on stdin (use "-" or no argument; without an argument stdin
+must not be a terminal). In files and stdin, names may be separated by commas
+or newlines; lines starting with # are ignored.
+
+Enabled prompts missing from the list keep their current order and are
+reported as a warning.
+
+Examples:
+  reposwarm prompts reorder hl_overview,module_deep_dive,dbs
+  reposwarm prompts reorder --file order.txt --dry-run
+  reposwarm prompts list --json | jq -r '.[].name' | reposwarm prompts reorder -`,
+		Args: friendlyMaxArgs(1, "reposwarm prompts reorder [name1,name2,...] [--file <path>] [--dry-run]"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var src string
+			switch {
+			case file != "" && len(args) > 0:
+				return fmt.Errorf("pass the list as an argument or with --file, not both")
+			case file != "":
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("reading %s: %w", file, err)
+				}
+				src = string(data)
+			case len(args) == 0 && output.StdinIsTerminal():
+				return fmt.Errorf("no prompt list given: pass name1,name2,..., --file <path>, or pipe the names on stdin")
+			case len(args) == 0 || args[0] == "-":
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return fmt.Errorf("reading stdin: %w", err)
+				}
+				src = string(data)
+			default:
+				src = args[0]
+			}
+			names := parseNameList(src)
+			if len(names) == 0 {
+				return fmt.Errorf("no prompt names given")
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+			var prompts []api.Prompt
+			if err := client.Get(ctx(), "/prompts", &prompts); err != nil {
+				return err
+			}
+
+			changes, omitted, err := planReorder(prompts, names, start)
+			if err != nil {
+				return err
+			}
+			if !flagJSON {
+				for _, name := range omitted {
+					output.F.Warning(fmt.Sprintf("Enabled prompt %s is not in the list — its order is unchanged", name))
+				}
+			}
+
+			if !dryRun {
+				for _, c := range changes {
+					body := map[string]int{"order": c.Order}
+					var result any
+					if err := client.Patch(ctx(), "/prompts/"+c.Name+"/order", body, &result); err != nil {
+						return fmt.Errorf("setting order for %s: %w", c.Name, err)
+					}
+				}
+			}
+
+			if flagJSON {
+				return output.JSON(map[string]any{"dryRun": dryRun, "changes": changes, "omitted": omitted})
+			}
+			title := "Prompt order"
+			if dryRun {
+				title += " (dry run — nothing changed)"
+			}
+			output.F.Section(title)
+			var rows [][]string
+			for _, c := range changes {
+				rows = append(rows, []string{c.Name, fmt.Sprint(c.OldOrder), fmt.Sprint(c.Order)})
+			}
+			output.F.Table([]string{"Name", "Was", "Now"}, rows)
+			output.F.Println()
+			if !dryRun {
+				output.Successf("Reordered %d prompts", len(changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Read the ordered prompt names from a file")
+	cmd.Flags().IntVar(&start, "start", 1, "Order value for the first prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the resulting order without changing anything")
+	return cmd
+}
+
+// parseNameList splits a comma- and/or newline-separated list of names,
+// skipping blanks and # comments.
+func parseNameList(s string) []string {
+	var names []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		names = append(names, splitCSV(line)...)
+	}
+	return names
+}
+
+// planReorder assigns sequential orders to names, returning the changes and
+// the enabled prompts left out of the list.
+func planReorder(prompts []api.Prompt, names []string, start int) ([]promptOrderChange, []string, error) {
+	byName := map[string]api.Prompt{}
+	for _, p := range prompts {
+		byName[p.Name] = p
+	}
+
+	listed := map[string]bool{}
+	var changes []promptOrderChange
+	for i, name := range names {
+		p, ok := byName[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown prompt %q (see 'reposwarm prompts list')", name)
+		}
+		if listed[name] {
+			return nil, nil, fmt.Errorf("prompt %q is listed more than once", name)
+		}
+		listed[name] = true
+		changes = append(changes, promptOrderChange{Name: name, OldOrder: p.Order, Order: start + i})
+	}
+
+	var omitted []string
+	for _, p := range prompts {
+		if p.Enabled && !listed[p.Name] {
+			omitted = append(omitted, p.Name)
+		}
+	}
+	return changes, omitted, nil
+}
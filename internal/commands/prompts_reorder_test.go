@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+)
+
+func TestParseNameList(t *testing.T) {
+	got := parseNameList("# overview first\nhl_overview, dbs\n\nsecurity,\n")
+	want := []string{"hl_overview", "dbs", "security"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNameList = %v, want %v", got, want)
+	}
+}
+
+func TestPlanReorder(t *testing.T) {
+	prompts := []api.Prompt{
+		{Name: "a", Order: 3, Enabled: true},
+		{Name: "b", Order: 1, Enabled: true},
+		{Name: "c", Order: 2, Enabled: false},
+		{Name: "d", Order: 4, Enabled: true},
+	}
+	changes, omitted, err := planReorder(prompts, []string{"b", "c", "a"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []promptOrderChange{{"b", 1, 1}, {"c", 2, 2}, {"a", 3, 3}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+	if !reflect.DeepEqual(omitted, []string{"d"}) {
+		t.Errorf("omitted = %v, want [d]", omitted)
+	}
+
+	if _, _, err := planReorder(prompts, []string{"a", "zzz"}, 1); err == nil || !strings.Contains(err.Error(), "zzz") {
+		t.Errorf("expected unknown prompt error, got %v", err)
+	}
+	if _, _, err := planReorder(prompts, []string{"a", "a"}, 1); err == nil {
+		t.Error("expected duplicate error")
+	}
+}
+
+func TestPromptsReorderDryRunFromFile(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /prompts": []map[string]any{
+			{"name": "a", "order": 2, "enabled": true},
+			{"name": "b", "order": 1, "enabled": true},
+		},
+	})
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "order.txt")
+	os.WriteFile(path, []byte("a\nb\n"), 0644)
+
+	// No PATCH routes are registered, so any write would fail the command
+	out, err := runCmd(t, "prompts", "reorder", "--file", path, "--dry-run", "--start", "10", "--json")
+	if err != nil {
+		t.Fatalf("prompts reorder --dry-run: %v", err)
+	}
+	var result struct {
+		DryRun  bool                `json:"dryRun"`
+		Changes []promptOrderChange `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !result.DryRun || len(result.Changes) != 2 || result.Changes[0].Order != 10 || result.Changes[1].Order != 11 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// promptSearchHit is one matching line in a prompt field.
+type promptSearchHit struct {
+	Prompt string `json:"prompt"`
+	Field  string `json:"field"` // template, description or context
+	LineNo int    `json:"lineNo"`
+	Line   string `json:"line"`
+}
+
+func newPromptsSearchCmd() *cobra.Command {
+	var useRegex bool
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search prompt templates, descriptions and context",
+		Long: `Search for text across all prompts' template, description and context.
+
+Matching is case-insensitive. With --regex, the query is a Go regular
+expression (prefix with (?-i) for case-sensitive matching).
+
+Examples:
+  reposwarm prompts search "mermaid"
+  reposwarm prompts search --regex "DynamoDB|Postgres"
+  reposwarm prompts search "security" --json`,
+		Args: friendlyExactArgs(1, "reposwarm prompts search <query>\n\nExample:\n  reposwarm prompts search \"mermaid\""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			match, err := promptMatcher(args[0], useRegex)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var prompts []api.Prompt
+			if err := client.Get(ctx(), "/prompts", &prompts); err != nil {
+				return err
+			}
+
+			var hits []promptSearchHit
+			for _, p := range prompts {
+				if p.Template == "" {
+					// Some servers omit templates from the list; fetch the full prompt
+					var full api.Prompt
+					if err := client.Get(ctx(), "/prompts/"+p.Name, &full); err == nil {
+						p = full
+					}
+				}
+				hits = append(hits, searchPrompt(p, match)...)
+			}
+
+			if flagJSON {
+				return outputList(hits)
+			}
+
+			F := output.F
+			F.Section(fmt.Sprintf("Prompt search '%s' (%d hits)", args[0], len(hits)))
+			if len(hits) == 0 {
+				F.Info("No matching prompts")
+				return nil
+			}
+			last := ""
+			for _, h := range hits {
+				if h.Prompt != last {
+					F.Printf("\n%s\n", output.Bold(h.Prompt))
+					last = h.Prompt
+				}
+				F.Printf("  %s %s\n", output.Dim(fmt.Sprintf("%s:%d", h.Field, h.LineNo)), h.Line)
+			}
+			F.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&useRegex, "regex", false, "Treat the query as a regular expression")
+	return cmd
+}
+
+// promptMatcher builds a case-insensitive line matcher for query.
+func promptMatcher(query string, useRegex bool) (func(string) bool, error) {
+	if !useRegex {
+		q := strings.ToLower(query)
+		return func(line string) bool { return strings.Contains(strings.ToLower(line), q) }, nil
+	}
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --regex %q: %w", query, err)
+	}
+	return re.MatchString, nil
+}
+
+// searchPrompt returns the matching lines in p's searchable fields.
+func searchPrompt(p api.Prompt, match func(string) bool) []promptSearchHit {
+	var hits []promptSearchHit
+	for _, f := range []struct{ name, text string }{
+		{"description", p.Description},
+		{"context", p.Context},
+		{"template", p.Template},
+	} {
+		for i, line := range strings.Split(f.text, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || !match(line) {
+				continue
+			}
+			if len(trimmed) > 200 {
+				trimmed = trimmed[:200] + "..."
+			}
+			hits = append(hits, promptSearchHit{Prompt: p.Name, Field: f.name, LineNo: i + 1, Line: trimmed})
+		}
+	}
+	return hits
+}
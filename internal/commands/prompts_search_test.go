@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestPromptsSearch(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /prompts": []map[string]any{
+			{"name": "hl_overview", "description": "High-level overview", "template": "Summarize the repo.\nInclude a Mermaid diagram."},
+			{"name": "dbs", "description": "Databases", "template": "List DynamoDB tables and Postgres schemas."},
+			{"name": "security", "description": "Security review", "template": "Check IAM policies."},
+		},
+	})
+	defer cleanup()
+
+	tests := []struct {
+		args []string
+		want []string // prompt:field:lineNo
+	}{
+		{[]string{"mermaid"}, []string{"hl_overview:template:2"}},
+		{[]string{"--regex", "dynamodb|iam"}, []string{"dbs:template:1", "security:template:1"}},
+		{[]string{"security"}, []string{"security:description:1"}},
+		{[]string{"nothing-matches"}, nil},
+	}
+	for _, tt := range tests {
+		out, err := runCmd(t, append([]string{"prompts", "search", "--json"}, tt.args...)...)
+		if err != nil {
+			t.Fatalf("prompts search %v: %v", tt.args, err)
+		}
+		var hits []promptSearchHit
+		if err := json.Unmarshal([]byte(out), &hits); err != nil {
+			t.Fatalf("invalid JSON for %v: %v\n%s", tt.args, err, out)
+		}
+		if len(hits) != len(tt.want) {
+			t.Errorf("%v: got %+v, want %v", tt.args, hits, tt.want)
+			continue
+		}
+		for i, h := range hits {
+			if got := fmt.Sprintf("%s:%s:%d", h.Prompt, h.Field, h.LineNo); got != tt.want[i] {
+				t.Errorf("%v: hit %d = %s, want %s", tt.args, i, got, tt.want[i])
+			}
+		}
+	}
+
+	if _, err := runCmd(t, "prompts", "search", "--regex", "("); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
@@ -13,6 +13,8 @@ import (
 func newReportCmd() *cobra.Command {
 	var outputFile string
 	var sections string
+	var repoPatterns string
+	var exclude []string
 
 	cmd := &cobra.Command{
 		Use:   "report [repos...]",
@@ -20,13 +22,19 @@ func newReportCmd() *cobra.Command {
 		Long: `Generate a consolidated markdown report from investigation results.
 
 Without repo names: reports on ALL repos with results.
-With repo names: reports on specified repos only.
+With repo names or globs (or --repo a,b): reports on matching repos only.
+--exclude (repeatable) and the excludeRepos config key then drop repos
+matching a name or glob.
+--sections limits every repo to the given section IDs, in the report and in
+--json.
 
 Examples:
   reposwarm results report                          # All repos
   reposwarm results report is-odd meshmart-catalog  # Specific repos
   reposwarm results report -o report.md             # Save to file
-  reposwarm results report --sections hl_overview,apis  # Only certain sections`,
+  reposwarm results report --sections hl_overview,apis  # Only certain sections
+  reposwarm results report 'team-a-*' --sections security_check,DBs
+  reposwarm results report --exclude 'archived-*' --exclude sandbox`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
@@ -34,41 +42,50 @@ Examples:
 			}
 
 			var repoList api.WikiReposResponse
-			if err := client.Get(ctx(), "/wiki", &repoList); err != nil {
+			if err := getWikiRepos(client, &repoList); err != nil {
+				return err
+			}
+
+			excluded, err := repoExclusions(exclude)
+			if err != nil {
 				return err
 			}
 
 			var targetRepos []api.WikiRepoSummary
-			if len(args) > 0 {
-				nameSet := make(map[string]bool)
-				for _, a := range args {
-					nameSet[strings.ToLower(a)] = true
+			patterns := append(splitCSV(repoPatterns), args...)
+			if len(patterns) > 0 {
+				for i, p := range patterns {
+					patterns[i] = strings.ToLower(p)
 				}
 				for _, r := range repoList.Repos {
-					if nameSet[strings.ToLower(r.Name)] {
+					if matchesAny(strings.ToLower(r.Name), patterns) {
 						targetRepos = append(targetRepos, r)
 					}
 				}
 			} else {
 				targetRepos = repoList.Repos
 			}
-
-			if len(targetRepos) == 0 {
-				return fmt.Errorf("no repos with investigation results found")
+			for i, p := range excluded {
+				excluded[i] = strings.ToLower(p)
 			}
+			targetRepos, skipped := excludeRepos(targetRepos, func(r api.WikiRepoSummary) string { return strings.ToLower(r.Name) }, excluded)
 
-			var sectionFilter map[string]bool
-			if sections != "" {
-				sectionFilter = make(map[string]bool)
-				for _, s := range strings.Split(sections, ",") {
-					sectionFilter[strings.TrimSpace(s)] = true
+			if len(targetRepos) == 0 {
+				if skipped > 0 {
+					return fmt.Errorf("all %d matching repos are excluded by %s", skipped, strings.Join(excluded, ", "))
+				}
+				if len(patterns) > 0 {
+					return fmt.Errorf("no repos with investigation results match %s", strings.Join(patterns, ", "))
 				}
+				return fmt.Errorf("no repos with investigation results found")
 			}
 
 			if !flagJSON {
 				output.F.Info(fmt.Sprintf("Generating report for %d repos...", len(targetRepos)))
 			}
 
+			sectionFilter := parseSectionFilter(sections)
+
 			var sb strings.Builder
 			sb.WriteString("# RepoSwarm Investigation Report\n\n")
 			sb.WriteString(fmt.Sprintf("Repos: %d | Generated by `reposwarm report`\n\n", len(targetRepos)))
@@ -76,6 +93,11 @@ Examples:
 
 			sb.WriteString("## Table of Contents\n\n")
 			for i, r := range targetRepos {
+				if sectionFilter != nil {
+					// Counts are only known once each repo's index is fetched
+					sb.WriteString(fmt.Sprintf("%d. [%s](#%s)\n", i+1, r.Name, strings.ReplaceAll(r.Name, " ", "-")))
+					continue
+				}
 				sb.WriteString(fmt.Sprintf("%d. [%s](#%s) (%d sections)\n", i+1, r.Name, strings.ReplaceAll(r.Name, " ", "-"), r.SectionCount))
 			}
 			sb.WriteString("\n---\n\n")
@@ -87,7 +109,10 @@ Examples:
 			}
 			var jsonReports []RepoReport
 
-			for _, r := range targetRepos {
+			missing := missingSections{}
+			status := startStatus("Fetching results...")
+			for i, r := range targetRepos {
+				status.Update(fmt.Sprintf("Fetching repo %d/%d (%s)...", i+1, len(targetRepos), r.Name))
 				sb.WriteString(fmt.Sprintf("# %s\n\n", r.Name))
 
 				var index api.WikiIndex
@@ -98,11 +123,7 @@ Examples:
 
 				jsonReport := RepoReport{Name: r.Name, Content: make(map[string]string)}
 
-				for _, s := range index.Sections {
-					if sectionFilter != nil && !sectionFilter[s.ID] {
-						continue
-					}
-
+				for _, s := range filterSections(r.Name, index.Sections, sectionFilter, missing) {
 					var content api.WikiContent
 					if err := client.Get(ctx(), "/wiki/"+r.Name+"/"+s.ID, &content); err != nil {
 						continue
@@ -117,6 +138,8 @@ Examples:
 				jsonReport.Sections = len(jsonReport.Content)
 				jsonReports = append(jsonReports, jsonReport)
 			}
+			status.Stop()
+			missing.warn()
 
 			if flagJSON {
 				return output.JSON(jsonReports)
@@ -139,5 +162,8 @@ Examples:
 
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path")
 	cmd.Flags().StringVar(&sections, "sections", "", "Comma-separated section names to include")
+	cmd.Flags().StringVar(&repoPatterns, "repo", "", "Comma-separated repo names or globs to include (added to any arguments)")
+	addExcludeFlag(cmd, &exclude)
+	addTimingsFlag(cmd)
 	return cmd
 }
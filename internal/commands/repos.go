@@ -2,9 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -25,30 +27,67 @@ func newReposCmd() *cobra.Command {
 }
 
 func newReposListCmd() *cobra.Command {
-	var source, filter string
-	var enabled, disabled bool
+	var source, columns string
+	var filters []string
+	var enabled, disabled, wide bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all tracked repositories",
+		Long: `List all tracked repositories.
+
+--wide adds the URL, description and when results were last updated
+(like kubectl's -o wide). --columns picks and orders columns from either
+set, e.g. --columns name,url.
+
+Columns: ` + strings.Join(repoColumnNames(), ", ") + `
+
+Examples:
+  reposwarm repos list
+  reposwarm repos list --wide
+  reposwarm repos list --columns name,url,last-investigated`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cols, err := selectRepoColumns(columns, wide)
+			if err != nil {
+				return err
+			}
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			// --filter without "=" keeps its original meaning: a name substring
+			var nameFilters, fieldFilters []string
+			for _, f := range filters {
+				if strings.Contains(f, "=") {
+					fieldFilters = append(fieldFilters, f)
+				} else {
+					nameFilters = append(nameFilters, strings.ToLower(f))
+				}
+			}
+			byField, err := parseFieldFilters(fieldFilters)
+			if err != nil {
+				return err
+			}
+
 			var repos []api.Repository
 			if err := client.Get(ctx(), "/repos", &repos); err != nil {
 				return err
 			}
+			if repos, err = filterByFields(repos, byField); err != nil {
+				return err
+			}
 
 			var filtered []api.Repository
+		repoLoop:
 			for _, r := range repos {
 				if source != "" && !strings.EqualFold(r.Source, source) {
 					continue
 				}
-				if filter != "" && !strings.Contains(strings.ToLower(r.Name), strings.ToLower(filter)) {
-					continue
+				for _, nf := range nameFilters {
+					if !strings.Contains(strings.ToLower(r.Name), nf) {
+						continue repoLoop
+					}
 				}
 				if enabled && !r.Enabled {
 					continue
@@ -60,23 +99,36 @@ func newReposListCmd() *cobra.Command {
 			}
 
 			if flagJSON {
-				return output.JSON(filtered)
+				return outputList(filtered)
+			}
+
+			// Last investigated comes from the results summaries
+			lastUpdated := map[string]string{}
+			for _, c := range cols {
+				if c.name != "last-investigated" {
+					continue
+				}
+				var wiki api.WikiReposResponse
+				if err := getWikiRepos(client, &wiki); err == nil {
+					for _, w := range wiki.Repos {
+						lastUpdated[w.Name] = w.LastUpdated
+					}
+				}
 			}
 
 			F := output.F
 			F.Section(fmt.Sprintf("Repositories (%d repos)", len(filtered)))
-			headers := []string{"Name", "Source", "Enabled", "Docs", "Status"}
+			var headers []string
+			for _, c := range cols {
+				headers = append(headers, c.header)
+			}
 			var rows [][]string
 			for _, r := range filtered {
-				en := "yes"
-				if !r.Enabled {
-					en = "no"
+				var row []string
+				for _, c := range cols {
+					row = append(row, c.value(r, lastUpdated))
 				}
-				docs := ""
-				if r.HasDocs {
-					docs = "yes"
-				}
-				rows = append(rows, []string{r.Name, r.Source, en, docs, r.Status})
+				rows = append(rows, row)
 			}
 			F.Table(headers, rows)
 			F.Println()
@@ -85,12 +137,88 @@ func newReposListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&source, "source", "", "Filter by source (CodeCommit, GitHub)")
-	cmd.Flags().StringVar(&filter, "filter", "", "Filter by name (case-insensitive)")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter by name substring, or by field with key=value (e.g. status=active; repeatable, ANDed)")
 	cmd.Flags().BoolVar(&enabled, "enabled", false, "Show only enabled repos")
 	cmd.Flags().BoolVar(&disabled, "disabled", false, "Show only disabled repos")
+	cmd.Flags().BoolVar(&wide, "wide", false, "Add URL, description and last-investigated columns")
+	cmd.Flags().StringVar(&columns, "columns", "", "Comma-separated columns to show, in order (see --help)")
 	return cmd
 }
 
+// repoColumn is a column of the 'repos list' table.
+type repoColumn struct {
+	name   string // as given to --columns
+	header string
+	wide   bool // shown by --wide only
+	value  func(r api.Repository, lastUpdated map[string]string) string
+}
+
+var repoColumns = []repoColumn{
+	{"name", "Name", false, func(r api.Repository, _ map[string]string) string { return r.Name }},
+	{"source", "Source", false, func(r api.Repository, _ map[string]string) string { return r.Source }},
+	{"enabled", "Enabled", false, func(r api.Repository, _ map[string]string) string {
+		if r.Enabled {
+			return "yes"
+		}
+		return "no"
+	}},
+	{"docs", "Docs", false, func(r api.Repository, _ map[string]string) string {
+		if r.HasDocs {
+			return "yes"
+		}
+		return ""
+	}},
+	{"status", "Status", false, func(r api.Repository, _ map[string]string) string { return r.Status }},
+	{"url", "URL", true, func(r api.Repository, _ map[string]string) string { return r.URL }},
+	{"description", "Description", true, func(r api.Repository, _ map[string]string) string {
+		if len([]rune(r.Description)) > 50 {
+			return string([]rune(r.Description)[:47]) + "..."
+		}
+		return r.Description
+	}},
+	{"last-investigated", "Last Investigated", true, func(r api.Repository, lastUpdated map[string]string) string {
+		return lastUpdated[r.Name]
+	}},
+}
+
+func repoColumnNames() []string {
+	var names []string
+	for _, c := range repoColumns {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// selectRepoColumns resolves --columns (any column, in the given order) or
+// the default compact/--wide set.
+func selectRepoColumns(spec string, wide bool) ([]repoColumn, error) {
+	if spec == "" {
+		var cols []repoColumn
+		for _, c := range repoColumns {
+			if wide || !c.wide {
+				cols = append(cols, c)
+			}
+		}
+		return cols, nil
+	}
+	var cols []repoColumn
+	for _, name := range splitCSV(spec) {
+		key := strings.ReplaceAll(strings.ToLower(name), "_", "-")
+		found := false
+		for _, c := range repoColumns {
+			if c.name == key {
+				cols = append(cols, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (valid: %s)", name, strings.Join(repoColumnNames(), ", "))
+		}
+	}
+	return cols, nil
+}
+
 // parseRepoURL extracts the repository name from a URL.
 // It handles trailing slashes and .git suffixes.
 func parseRepoURL(url string) string {
@@ -115,6 +243,39 @@ func detectSourceFromURL(url string) string {
 	return "CodeCommit"
 }
 
+// inferRepoURL builds the clone URL for a bare name on a known source and
+// returns the name to track it under.
+func inferRepoURL(name, source string) (string, string, error) {
+	switch strings.ToLower(source) {
+	case "github":
+		owner, repo, ok := strings.Cut(strings.TrimSuffix(name, ".git"), "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return "", "", fmt.Errorf("GitHub repos need an owner: use 'reposwarm repos add <owner>/<repo> --source GitHub' or pass --url")
+		}
+		return repo, fmt.Sprintf("https://github.com/%s/%s", owner, repo), nil
+	case "codecommit":
+		if strings.Contains(name, "/") {
+			return "", "", fmt.Errorf("CodeCommit repo names can't contain '/': did you mean --source GitHub?")
+		}
+		region := awsRegion()
+		if region == "" {
+			return "", "", fmt.Errorf("no AWS region to build the CodeCommit URL: run 'reposwarm config set region <region>' or pass --url")
+		}
+		return name, fmt.Sprintf("https://git-codecommit.%s.amazonaws.com/v1/repos/%s", region, name), nil
+	}
+	return "", "", fmt.Errorf("can't infer a URL for source %q: pass --url", source)
+}
+
+// awsRegion returns the configured region (the one 'new' uses for
+// AWS_REGION), falling back to the AWS_REGION and AWS_DEFAULT_REGION
+// environment variables.
+func awsRegion() string {
+	if cfg, err := config.Load(); err == nil && cfg.Region != "" {
+		return cfg.Region
+	}
+	return orDefault(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+}
+
 // isRepoURL checks if a string is a repository URL (starts with http:// or https://).
 func isRepoURL(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
@@ -126,7 +287,20 @@ func newReposAddCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add <name-or-url>",
 		Short: "Add a repository to track",
-		Args:  friendlyExactArgs(1, "reposwarm repos add <url>\nreposwarm repos add <name> --url <url>\n\nExamples:\n  reposwarm repos add https://github.com/org/repo\n  reposwarm repos add my-repo --url https://github.com/org/repo"),
+		Long: `Add a repository to track, given its URL or a name plus --url.
+
+With --source and no URL, the URL is inferred from the name:
+  GitHub      owner/repo -> https://github.com/owner/repo (tracked as "repo")
+  CodeCommit  name       -> https://git-codecommit.<region>.amazonaws.com/v1/repos/name
+The CodeCommit region comes from the region config key, then AWS_REGION or
+AWS_DEFAULT_REGION. A given --url always wins.
+
+Examples:
+  reposwarm repos add https://github.com/org/repo
+  reposwarm repos add my-repo --url https://github.com/org/repo
+  reposwarm repos add org/repo --source GitHub
+  reposwarm repos add my-service --source CodeCommit`,
+		Args: friendlyExactArgs(1, "reposwarm repos add <url>\nreposwarm repos add <name> --url <url>\n\nExamples:\n  reposwarm repos add https://github.com/org/repo\n  reposwarm repos add my-repo --url https://github.com/org/repo\n  reposwarm repos add org/repo --source GitHub"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
@@ -153,8 +327,16 @@ func newReposAddCmd() *cobra.Command {
 				name = arg
 				if urlFlag != "" {
 					url = urlFlag
+				} else if cmd.Flags().Changed("source") {
+					// Build the URL for a known source
+					if name, url, err = inferRepoURL(arg, source); err != nil {
+						return err
+					}
+					if !flagJSON {
+						output.F.Info(fmt.Sprintf("Using URL %s", url))
+					}
 				} else {
-					return fmt.Errorf("url is required: use 'reposwarm repos add <url>' or 'reposwarm repos add <name> --url <url>'")
+					return fmt.Errorf("url is required: use 'reposwarm repos add <url>', 'reposwarm repos add <name> --url <url>' or --source GitHub/CodeCommit to infer it")
 				}
 			}
 
@@ -186,7 +368,7 @@ func newReposAddCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&urlFlag, "url", "", "Repository URL (optional if URL provided as argument)")
-	cmd.Flags().StringVar(&source, "source", "CodeCommit", "Source (CodeCommit, GitHub) - auto-detected for GitHub URLs")
+	cmd.Flags().StringVar(&source, "source", "CodeCommit", "Source (CodeCommit, GitHub) - auto-detected for GitHub URLs; with a bare name, used to infer the URL")
 	return cmd
 }
 
@@ -199,10 +381,11 @@ func newReposRemoveCmd() *cobra.Command {
 		Args:  friendlyExactArgs(1, "reposwarm repos remove <name>\n\nExample:\n  reposwarm repos remove my-repo"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !yes {
-				fmt.Printf("  Remove %s? [y/N] ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm(fmt.Sprintf("Remove %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.F.Info("Cancelled")
 					return nil
 				}
@@ -289,8 +472,20 @@ func newReposShowCmd() *cobra.Command {
 				return err
 			}
 
+			// Investigation results are best-effort: a missing wiki just means none yet
+			var results *repoResultsSummary
+			if repo.HasDocs {
+				var index api.WikiIndex
+				if err := client.Get(ctx(), "/wiki/"+repo.Name, &index); err == nil {
+					results = summarizeWikiIndex(index)
+				}
+			}
+
 			if flagJSON {
-				return output.JSON(repo)
+				return output.JSON(struct {
+					api.Repository
+					Results *repoResultsSummary `json:"results,omitempty"`
+				}{repo, results})
 			}
 
 			F := output.F
@@ -303,8 +498,36 @@ func newReposShowCmd() *cobra.Command {
 			if repo.Description != "" {
 				F.KeyValue("Description", repo.Description)
 			}
+			if results != nil {
+				F.Println()
+				F.KeyValue("Sections", fmt.Sprint(results.SectionCount))
+				if results.LastUpdated != "" {
+					F.KeyValue("Last Updated", results.LastUpdated)
+				}
+			}
 			F.Println()
 			return nil
 		},
 	}
 }
+
+// repoResultsSummary is the investigation status shown by 'repos show'.
+type repoResultsSummary struct {
+	SectionCount int      `json:"sectionCount"`
+	LastUpdated  string   `json:"lastUpdated,omitempty"`
+	Sections     []string `json:"sections"`
+}
+
+// summarizeWikiIndex counts sections and finds when the newest was written.
+func summarizeWikiIndex(index api.WikiIndex) *repoResultsSummary {
+	s := &repoResultsSummary{SectionCount: len(index.Sections), Sections: []string{}}
+	var latest api.WikiSection
+	for _, sec := range index.Sections {
+		s.Sections = append(s.Sections, sec.Name())
+		if sec.Timestamp > latest.Timestamp || (sec.Timestamp == latest.Timestamp && sec.CreatedAt > latest.CreatedAt) {
+			latest = sec
+		}
+	}
+	s.LastUpdated = latest.CreatedAt
+	return s
+}
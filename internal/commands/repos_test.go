@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -335,3 +336,91 @@ func TestReposAddServerError(t *testing.T) {
 		t.Fatal("expected error when server returns 404")
 	}
 }
+
+func TestReposRemoveNonInteractiveNeedsYes(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"DELETE /repos/is-odd": map[string]any{"success": true},
+	})
+	defer cleanup()
+
+	_, err := runCmd(t, "repos", "remove", "is-odd")
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected an error asking for --yes, got %v", err)
+	}
+	if _, err := runCmd(t, "repos", "remove", "is-odd", "-y"); err != nil {
+		t.Fatalf("repos remove -y: %v", err)
+	}
+}
+
+func TestInferRepoURL(t *testing.T) {
+	_, cleanup := testServer(t, nil)
+	defer cleanup()
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	// Without a configured region the environment is used
+	if _, err := runCmd(t, "config", "set", "region", ""); err != nil {
+		t.Fatalf("config set region: %v", err)
+	}
+
+	tests := []struct {
+		name, source string
+		wantName     string
+		wantURL      string
+		wantErr      bool
+	}{
+		{"org/repo", "GitHub", "repo", "https://github.com/org/repo", false},
+		{"org/repo.git", "github", "repo", "https://github.com/org/repo", false},
+		{"repo", "GitHub", "", "", true},
+		{"my-service", "CodeCommit", "my-service", "https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/my-service", false},
+		{"org/repo", "CodeCommit", "", "", true},
+		{"repo", "Bitbucket", "", "", true},
+	}
+	for _, tt := range tests {
+		name, url, err := inferRepoURL(tt.name, tt.source)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("inferRepoURL(%q, %q) error = %v, wantErr %v", tt.name, tt.source, err, tt.wantErr)
+			continue
+		}
+		if name != tt.wantName || url != tt.wantURL {
+			t.Errorf("inferRepoURL(%q, %q) = %q, %q; want %q, %q", tt.name, tt.source, name, url, tt.wantName, tt.wantURL)
+		}
+	}
+
+	// The configured region wins over the environment
+	if _, err := runCmd(t, "config", "set", "region", "ap-south-1"); err != nil {
+		t.Fatalf("config set region: %v", err)
+	}
+	if _, url, _ := inferRepoURL("svc", "CodeCommit"); !strings.Contains(url, "ap-south-1") {
+		t.Errorf("expected the configured region, got %q", url)
+	}
+}
+
+func TestReposAddInfersGitHubURL(t *testing.T) {
+	server, cleanup := testServer(t, map[string]any{
+		"POST /repos": map[string]any{"success": true},
+	})
+	defer cleanup()
+	routes := server.Config.Handler
+	var posted map[string]any
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&posted)
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	if _, err := runCmd(t, "repos", "add", "org/repo", "--source", "GitHub", "--json"); err != nil {
+		t.Fatalf("repos add --source GitHub: %v", err)
+	}
+	if posted["name"] != "repo" || posted["url"] != "https://github.com/org/repo" || posted["source"] != "GitHub" {
+		t.Errorf("unexpected body: %v", posted)
+	}
+
+	// --url still wins over inference
+	if _, err := runCmd(t, "repos", "add", "org/repo", "--source", "GitHub", "--url", "https://github.com/fork/repo", "--json"); err != nil {
+		t.Fatalf("repos add --url: %v", err)
+	}
+	if posted["url"] != "https://github.com/fork/repo" {
+		t.Errorf("expected --url to win, got %v", posted["url"])
+	}
+}
@@ -1,9 +1,14 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
@@ -16,6 +21,8 @@ func newResultsCmd() *cobra.Command {
 		Aliases: []string{"res"},
 		Short:   "Browse architecture investigation results (→ use 'ask results' instead)",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Replaces the root hook (cobra runs only the nearest one)
+			applyGlobalFlags()
 			if !flagJSON && !flagAgent {
 				fmt.Fprintf(os.Stderr, "💡 Results commands are moving to the standalone `ask` CLI.\n")
 				fmt.Fprintf(os.Stderr, "   Install: curl -fsSL https://raw.githubusercontent.com/reposwarm/ask-cli/main/install.sh | sh\n")
@@ -25,18 +32,25 @@ func newResultsCmd() *cobra.Command {
 	}
 	cmd.AddCommand(newResultsListCmd())
 	cmd.AddCommand(newResultsSectionsCmd())
+	cmd.AddCommand(newResultsTreeCmd())
 	cmd.AddCommand(newResultsReadCmd())
 	cmd.AddCommand(newResultsMetaCmd())
 	cmd.AddCommand(newResultsExportCmd())
 	cmd.AddCommand(newResultsSearchCmd())
 	cmd.AddCommand(newResultsAuditCmd())
+	cmd.AddCommand(newResultsOpenCmd())
 	cmd.AddCommand(newDiffCmd())
 	cmd.AddCommand(newReportCmd())
 	return cmd
 }
 
 func newResultsListCmd() *cobra.Command {
-	return &cobra.Command{
+	var filters []string
+	var times timeDisplay
+	var pageSize, limit int
+	var all bool
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List repos with investigation results",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,14 +58,29 @@ func newResultsListCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			byField, err := parseFieldFilters(filters)
+			if err != nil {
+				return err
+			}
 
 			var result api.WikiReposResponse
-			if err := client.Get(ctx(), "/wiki", &result); err != nil {
+			var more bool
+			if result.Repos, more, err = fetchWikiRepos(client, pageSize, limit, all || limit > 0); err != nil {
 				return err
 			}
+			if result.Repos, err = filterByFields(result.Repos, byField); err != nil {
+				return err
+			}
+			if more {
+				hint := "use --all to fetch every page"
+				if limit > 0 {
+					hint = fmt.Sprintf("limited to %d", limit)
+				}
+				fmt.Fprintf(os.Stderr, "Note: the server has more repos with results (%s)\n", hint)
+			}
 
 			if flagJSON {
-				return output.JSON(result.Repos)
+				return outputList(result.Repos)
 			}
 
 			F := output.F
@@ -62,7 +91,7 @@ func newResultsListCmd() *cobra.Command {
 				rows = append(rows, []string{
 					r.Name,
 					fmt.Sprint(r.SectionCount),
-					r.LastUpdated,
+					times.format(r.LastUpdated),
 				})
 			}
 			F.Table(headers, rows)
@@ -70,14 +99,22 @@ func newResultsListCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	addFieldFilterFlag(cmd, &filters)
+	addTimeDisplayFlags(cmd, &times)
+	cmd.Flags().BoolVar(&all, "all", false, "Follow pagination and fetch every page")
+	cmd.Flags().IntVar(&pageSize, "page-size", 0, "Repos per request (0 = server default)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show at most this many repos (0 = no limit)")
+	return cmd
 }
 
 func newResultsSectionsCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:     "sections <repo>",
-		Aliases: []string{"show"},
-		Short:   "List investigation sections for a repo",
-		Args:    friendlyExactArgs(1, "reposwarm results sections <repo>\n\nExample:\n  reposwarm results sections my-repo"),
+		Use:               "sections <repo>",
+		Aliases:           []string{"show"},
+		Short:             "List investigation sections for a repo",
+		Args:              friendlyExactArgs(1, "reposwarm results sections <repo>\n\nExample:\n  reposwarm results sections my-repo"),
+		ValidArgsFunction: completeResults(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
@@ -86,7 +123,7 @@ func newResultsSectionsCmd() *cobra.Command {
 
 			var index api.WikiIndex
 			if err := client.Get(ctx(), "/wiki/"+args[0], &index); err != nil {
-				return err
+				return withResultsSuggestions(client, err, args[0], "")
 			}
 
 			if flagJSON {
@@ -111,7 +148,11 @@ func newResultsSectionsCmd() *cobra.Command {
 }
 
 func newResultsReadCmd() *cobra.Command {
-	var raw bool
+	var raw, render bool
+	var sections string
+	var pairsFile string
+	var fromStdin bool
+	var concurrency int
 
 	cmd := &cobra.Command{
 		Use:   "read <repo> [section]",
@@ -121,24 +162,69 @@ func newResultsReadCmd() *cobra.Command {
 With section name: returns just that section.
 Without section name: returns ALL sections concatenated.
 
+--section-from-stdin (or --pairs-file) reads "<repo> <section>" lines instead
+of arguments and fetches them in parallel (--concurrency, default 8), in the
+order given. With --json the result is an array of section contents.
+
+On a terminal the markdown is rendered with styling (headings, bold, code,
+lists). --render forces the styling, colors included, when piping (unless
+NO_COLOR is set); --render=false prints the markdown source instead, and
+--raw prints it without any framing.
+
 Examples:
   reposwarm results read is-odd                  # All sections
   reposwarm results read is-odd hl_overview      # Single section
-  reposwarm results read is-odd --raw > out.md   # Raw markdown`,
-		Args: friendlyRangeArgs(1, 2, "reposwarm results read <repo> [section]\n\nExamples:\n  reposwarm results read my-repo\n  reposwarm results read my-repo hl_overview"),
+  reposwarm results read is-odd --raw > out.md   # Raw markdown
+  reposwarm results read is-odd --render | less -R
+  reposwarm results read is-odd --sections security_check,DBs
+  printf 'is-odd DBs\nis-even DBs\n' | reposwarm results read --section-from-stdin --json
+  reposwarm results read --pairs-file sections.txt --raw`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if fromStdin || pairsFile != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--section-from-stdin and --pairs-file take no arguments")
+				}
+				return nil
+			}
+			return friendlyRangeArgs(1, 2, "reposwarm results read <repo> [section]\n\nExamples:\n  reposwarm results read my-repo\n  reposwarm results read my-repo hl_overview")(cmd, args)
+		},
+		ValidArgsFunction: completeResults(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			if raw && render {
+				return fmt.Errorf("--raw and --render are mutually exclusive")
+			}
+			if !cmd.Flags().Changed("render") {
+				render = stdoutIsTerminal()
+			} else if render && output.IsHuman {
+				// Asked for explicitly, e.g. to pipe into less -R
+				defer output.ForceColor()()
+			}
+			body := func(markdown string) string {
+				if render && !raw && output.IsHuman {
+					return output.RenderMarkdown(markdown)
+				}
+				return markdown
+			}
+
+			if fromStdin || pairsFile != "" {
+				if fromStdin && pairsFile != "" {
+					return fmt.Errorf("--section-from-stdin and --pairs-file are mutually exclusive")
+				}
+				return runBatchRead(client, pairsFile, concurrency, raw, body)
+			}
+
 			repo := args[0]
 
 			if len(args) == 2 {
 				section := args[1]
 				var content api.WikiContent
 				if err := client.Get(ctx(), "/wiki/"+repo+"/"+section, &content); err != nil {
-					return err
+					return withResultsSuggestions(client, err, repo, section)
 				}
 
 				if flagJSON {
@@ -152,39 +238,47 @@ Examples:
 				F.Section(fmt.Sprintf("Results — %s / %s", repo, section))
 				F.Info(content.CreatedAt)
 				F.Println()
-				fmt.Println(content.Content)
+				fmt.Println(body(content.Content))
 				return nil
 			}
 
 			// All sections
 			var index api.WikiIndex
 			if err := client.Get(ctx(), "/wiki/"+repo, &index); err != nil {
-				return err
+				return withResultsSuggestions(client, err, repo, "")
 			}
 
 			if len(index.Sections) == 0 {
 				return fmt.Errorf("no investigation results for %s", repo)
 			}
+			missing := missingSections{}
+			selected := filterSections(repo, index.Sections, parseSectionFilter(sections), missing)
+			missing.warn()
+
+			// Raw mode streams each section as soon as it's fetched so large
+			// investigations start printing immediately (e.g. piped to head).
+			streamRaw := raw && !flagJSON
 
 			var allContent []api.WikiContent
-			for _, s := range index.Sections {
+			for _, s := range selected {
 				var content api.WikiContent
 				if err := client.Get(ctx(), "/wiki/"+repo+"/"+s.Name(), &content); err != nil {
 					output.F.Error(fmt.Sprintf("Failed to read %s: %s", s.Name(), err))
 					continue
 				}
+				if streamRaw {
+					fmt.Printf("## %s\n\n%s\n\n", content.Section, content.Content)
+					continue
+				}
 				allContent = append(allContent, content)
 			}
 
-			if flagJSON {
-				return output.JSON(allContent)
+			if streamRaw {
+				return nil
 			}
 
-			if raw {
-				for _, c := range allContent {
-					fmt.Printf("## %s\n\n%s\n\n", c.Section, c.Content)
-				}
-				return nil
+			if flagJSON {
+				return outputList(allContent)
 			}
 
 			F := output.F
@@ -193,7 +287,7 @@ Examples:
 				F.Printf("--- %s ---\n", c.Section)
 				F.Info(c.CreatedAt)
 				F.Println()
-				fmt.Println(c.Content)
+				fmt.Println(body(c.Content))
 				F.Println()
 			}
 			return nil
@@ -201,14 +295,27 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&raw, "raw", false, "Output raw markdown (no formatting)")
+	cmd.Flags().BoolVar(&render, "render", false, "Render markdown with terminal styling (default on a terminal)")
+	cmd.Flags().StringVar(&sections, "sections", "", "Comma-separated section IDs to read (default: all)")
+	cmd.Flags().BoolVar(&fromStdin, "section-from-stdin", false, "Read \"<repo> <section>\" pairs from stdin")
+	cmd.Flags().StringVar(&pairsFile, "pairs-file", "", "Read \"<repo> <section>\" pairs from this file")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "Number of sections to fetch in parallel (batch mode)")
+	addOutputFileFlag(cmd)
 	return cmd
 }
 
 func newResultsMetaCmd() *cobra.Command {
-	return &cobra.Command{
+	var raw bool
+
+	cmd := &cobra.Command{
 		Use:   "meta <repo> [section]",
 		Short: "Show metadata for investigation results (no content)",
-		Args:  friendlyRangeArgs(1, 2, "reposwarm results meta <repo> [section]\n\nExamples:\n  reposwarm results meta my-repo\n  reposwarm results meta my-repo hl_overview"),
+		Long: `Show metadata for investigation results (no content).
+
+With --raw, every field the server returned is printed as JSON, including
+fields the CLI doesn't model. Useful for diagnosing referenceKey/timestamp
+mismatches. The section content itself is still omitted.`,
+		Args: friendlyRangeArgs(1, 2, "reposwarm results meta <repo> [section]\n\nExamples:\n  reposwarm results meta my-repo\n  reposwarm results meta my-repo hl_overview\n  reposwarm results meta my-repo hl_overview --raw"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
@@ -217,6 +324,14 @@ func newResultsMetaCmd() *cobra.Command {
 
 			repo := args[0]
 
+			if raw {
+				path := "/wiki/" + repo
+				if len(args) == 2 {
+					path += "/" + args[1]
+				}
+				return printRawMeta(client, path)
+			}
+
 			if len(args) == 2 {
 				section := args[1]
 				var content api.WikiContent
@@ -230,6 +345,7 @@ func newResultsMetaCmd() *cobra.Command {
 					"createdAt":    content.CreatedAt,
 					"timestamp":    content.Timestamp,
 					"referenceKey": content.ReferenceKey,
+					"contentHash":  content.ContentHash(),
 				}
 
 				if flagJSON {
@@ -243,6 +359,7 @@ func newResultsMetaCmd() *cobra.Command {
 				F.KeyValue("Created", content.CreatedAt)
 				F.KeyValue("Timestamp", fmt.Sprint(content.Timestamp))
 				F.KeyValue("Ref Key", content.ReferenceKey)
+				F.KeyValue("Content hash", content.ContentHash())
 				F.Println()
 				return nil
 			}
@@ -278,12 +395,27 @@ func newResultsMetaCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print every field the server returned as JSON (content omitted)")
+	return cmd
+}
+
+// printRawMeta fetches path and prints the response data as-is, bypassing the
+// typed structs, minus the (potentially large) content field.
+func printRawMeta(client *api.Client, path string) error {
+	var fields map[string]json.RawMessage
+	if err := client.Get(ctx(), path, &fields); err != nil {
+		return err
+	}
+	delete(fields, "content")
+	return output.JSON(fields)
 }
 
 func newResultsExportCmd() *cobra.Command {
 	var outputFile string
 	var outputDir string
-	var all bool
+	var all, resume, strict bool
+	var sections string
 
 	cmd := &cobra.Command{
 		Use:   "export [repo]",
@@ -294,9 +426,22 @@ Single repo:
   reposwarm results export my-app              # stdout
   reposwarm results export my-app -o out.md    # specific file
   reposwarm results export my-app -d ./docs    # writes docs/my-app.arch.md
+  reposwarm results export my-app --sections security_check,DBs
 
 All repos:
-  reposwarm results export --all -d ./arch-docs      # exports all repos to directory`,
+  reposwarm results export --all -d ./arch-docs      # exports all repos to directory
+
+--all (alias --all-repos) writes <dir>/<repo>.arch.md for every repo plus an
+index.md linking them, and reports the total files and bytes written.
+
+Each exported repo is recorded in <dir>/` + exportManifestFile + ` as it finishes. If an
+export is interrupted, re-run it with --resume to skip the repos already
+exported there and only fetch the rest.
+
+Section fetches are retried on transient failures. Sections that still can't
+be read are left out, reported at the end (and under "errors" with --json),
+and their repo isn't recorded as done, so --resume fetches it again. --strict
+exits non-zero when that happens.`,
 		Args: friendlyMaxArgs(1, "reposwarm results export [repo] [--all]\n\nExamples:\n  reposwarm results export my-repo\n  reposwarm results export --all -d ./docs"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
@@ -304,11 +449,15 @@ All repos:
 				return err
 			}
 
+			filter := parseSectionFilter(sections)
 			if all {
 				if outputDir == "" {
 					outputDir = "."
 				}
-				return exportAllRepos(client, outputDir)
+				return exportAllRepos(client, outputDir, filter, sections, resume, strict)
+			}
+			if resume {
+				return fmt.Errorf("--resume only applies to --all")
 			}
 
 			if len(args) == 0 {
@@ -316,10 +465,15 @@ All repos:
 			}
 
 			repo := args[0]
-			md, sections, err := exportRepo(client, repo)
+			missing := missingSections{}
+			md, count, fetchErrs, err := exportRepo(client, repo, filter, missing)
 			if err != nil {
 				return err
 			}
+			missing.warn()
+			if err := reportFetchErrors(fetchErrs, strict); err != nil {
+				return err
+			}
 
 			// Determine output path
 			dest := outputFile
@@ -334,7 +488,7 @@ All repos:
 				if err := os.WriteFile(dest, []byte(md), 0644); err != nil {
 					return fmt.Errorf("writing file: %w", err)
 				}
-				output.F.Success(fmt.Sprintf("Exported %d sections to %s (%d bytes)", sections, dest, len(md)))
+				output.F.Success(fmt.Sprintf("Exported %d sections to %s (%d bytes)", count, dest, len(md)))
 				return nil
 			}
 
@@ -345,55 +499,271 @@ All repos:
 
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path")
 	cmd.Flags().StringVarP(&outputDir, "dir", "d", "", "Output directory (writes <repo>.arch.md)")
-	cmd.Flags().BoolVar(&all, "all", false, "Export all repos")
+	cmd.Flags().BoolVar(&all, "all", false, "Export all repos (writes an index.md too)")
+	cmd.Flags().BoolVar(&all, "all-repos", false, "Alias for --all")
+	cmd.Flags().StringVar(&sections, "sections", "", "Comma-separated section IDs to export (default: all)")
+	cmd.Flags().BoolVar(&resume, "resume", false, "With --all, skip repos an interrupted export already wrote to the directory")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit non-zero if any section could not be read")
 	return cmd
 }
 
-func exportRepo(client *api.Client, repo string) (string, int, error) {
+// exportRepo renders a repo's selected sections as markdown. Sections that
+// can't be read even after retrying are left out and returned as fetchErrors;
+// requested sections the repo lacks are recorded in missing.
+func exportRepo(client *api.Client, repo string, filter map[string]bool, missing missingSections) (string, int, []fetchError, error) {
 	var index api.WikiIndex
-	if err := client.Get(ctx(), "/wiki/"+repo, &index); err != nil {
-		return "", 0, err
+	if err := getWithRetry(client, "/wiki/"+repo, &index); err != nil {
+		return "", 0, nil, err
 	}
+	selected := filterSections(repo, index.Sections, filter, missing)
 
 	var sb strings.Builder
-	for _, s := range index.Sections {
+	var fetchErrs []fetchError
+	for _, s := range selected {
 		var content api.WikiContent
-		if err := client.Get(ctx(), "/wiki/"+repo+"/"+s.Name(), &content); err != nil {
+		if err := getWithRetry(client, "/wiki/"+repo+"/"+s.Name(), &content); err != nil {
+			fetchErrs = addFetchError(fetchErrs, repo, s.Name(), err)
 			continue
 		}
 		sb.WriteString(fmt.Sprintf("# %s\n%s\n", s.Name(), content.Content))
 	}
 
-	return sb.String(), len(index.Sections), nil
+	return sb.String(), len(selected), fetchErrs, nil
+}
+
+// exportedRepo is one entry of the index written by exportAllRepos.
+type exportedRepo struct {
+	Repo     string `json:"repo"`
+	File     string `json:"file"`
+	Sections int    `json:"sections"`
+	Bytes    int    `json:"bytes"`
+}
+
+// exportManifestFile records, in an --all export directory, which repos
+// have been written so --resume can skip them.
+const exportManifestFile = ".export-manifest.json"
+
+// exportManifest is the content of exportManifestFile.
+type exportManifest struct {
+	Sections string         `json:"sections,omitempty"`
+	Repos    []exportedRepo `json:"repos"`
+}
+
+// loadExportManifest returns the repos a previous export into dir finished,
+// keyed by name, keeping only those whose file is still there. A manifest
+// written with different --sections is ignored.
+func loadExportManifest(dir, sections string) (map[string]exportedRepo, error) {
+	data, err := os.ReadFile(filepath.Join(dir, exportManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m exportManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", exportManifestFile, err)
+	}
+	if m.Sections != sections {
+		warning(fmt.Sprintf("Previous export used --sections %q; exporting everything again", m.Sections))
+		return nil, nil
+	}
+	done := make(map[string]exportedRepo, len(m.Repos))
+	for _, r := range m.Repos {
+		if _, err := os.Stat(filepath.Join(dir, r.File)); err == nil {
+			done[r.Repo] = r
+		}
+	}
+	return done, nil
+}
+
+func writeExportManifest(dir string, m exportManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, exportManifestFile), data, 0644)
 }
 
-func exportAllRepos(client *api.Client, dir string) error {
+// exportAllRepos writes <dir>/<repo>.arch.md for every repo with results,
+// plus an index.md linking them, and reports the files and bytes written.
+// With resume, repos recorded in the directory's manifest are skipped.
+// Repos with unreadable sections are written but left out of the manifest.
+func exportAllRepos(client *api.Client, dir string, filter map[string]bool, sections string, resume, strict bool) error {
 	var repoList api.WikiReposResponse
-	if err := client.Get(ctx(), "/wiki", &repoList); err != nil {
+	if err := getWikiRepos(client, &repoList); err != nil {
 		return err
 	}
 
-	os.MkdirAll(dir, 0755)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
 
-	exported := 0
+	var done map[string]exportedRepo
+	if resume {
+		var err error
+		if done, err = loadExportManifest(dir, sections); err != nil {
+			return err
+		}
+	}
+
+	manifest := exportManifest{Sections: sections, Repos: []exportedRepo{}}
+	var exported []exportedRepo
+	skipped, failed := []string{}, []string{}
+	fetchErrs := []fetchError{}
+	missing := missingSections{}
+	totalBytes := 0
 	for _, r := range repoList.Repos {
-		md, sections, err := exportRepo(client, r.Name)
+		if prev, ok := done[r.Name]; ok {
+			skipped = append(skipped, r.Name)
+			exported = append(exported, prev)
+			manifest.Repos = append(manifest.Repos, prev)
+			continue
+		}
+		md, count, sectionErrs, err := exportRepo(client, r.Name, filter, missing)
 		if err != nil {
 			output.F.Error(fmt.Sprintf("Failed to export %s: %s", r.Name, err))
+			failed = append(failed, r.Name)
 			continue
 		}
-		dest := fmt.Sprintf("%s/%s.arch.md", dir, r.Name)
+		file := r.Name + ".arch.md"
+		dest := filepath.Join(dir, file)
 		if err := os.WriteFile(dest, []byte(md), 0644); err != nil {
 			output.F.Error(fmt.Sprintf("Failed to write %s: %s", dest, err))
+			failed = append(failed, r.Name)
 			continue
 		}
-		output.F.Success(fmt.Sprintf("%s (%d sections, %d bytes)", r.Name, sections, len(md)))
-		exported++
+		if !flagJSON {
+			output.F.Success(fmt.Sprintf("%s (%d sections, %d bytes)", r.Name, count, len(md)))
+		}
+		entry := exportedRepo{Repo: r.Name, File: file, Sections: count, Bytes: len(md)}
+		exported = append(exported, entry)
+		totalBytes += len(md)
+		if len(sectionErrs) > 0 {
+			fetchErrs = append(fetchErrs, sectionErrs...)
+			continue
+		}
+
+		// Record progress as we go so an interrupted run can be resumed
+		manifest.Repos = append(manifest.Repos, entry)
+		if err := writeExportManifest(dir, manifest); err != nil {
+			warning(fmt.Sprintf("Could not update %s: %s", exportManifestFile, err))
+		}
+	}
+
+	missing.warn()
+
+	index := renderExportIndex(exported)
+	indexPath := filepath.Join(dir, "index.md")
+	if err := os.WriteFile(indexPath, []byte(index), 0644); err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	totalBytes += len(index)
+	files := len(exported) - len(skipped) + 1
+
+	if flagJSON {
+		if err := output.JSON(map[string]any{
+			"dir":     dir,
+			"index":   indexPath,
+			"repos":   exported,
+			"skipped": skipped,
+			"failed":  failed,
+			"errors":  fetchErrs,
+			"files":   files,
+			"bytes":   totalBytes,
+		}); err != nil {
+			return err
+		}
+	} else {
+		output.F.Println()
+		if len(skipped) > 0 {
+			output.F.Success(fmt.Sprintf("Exported %d/%d repos to %s (%d already exported, skipped)", len(exported)-len(skipped), len(repoList.Repos), dir, len(skipped)))
+		} else {
+			output.F.Success(fmt.Sprintf("Exported %d/%d repos to %s", len(exported), len(repoList.Repos), dir))
+		}
+		output.F.Info(fmt.Sprintf("Wrote %d files (%d bytes), index: %s", files, totalBytes, indexPath))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d repos failed to export (%s); re-run with --resume to retry only those", len(failed), strings.Join(failed, ", "))
+	}
+	if flagJSON {
+		if strict && len(fetchErrs) > 0 {
+			return fmt.Errorf("%s could not be read; re-run with --resume to retry those repos", pluralizeCount(len(fetchErrs), "section"))
+		}
+		return nil
+	}
+	return reportFetchErrors(fetchErrs, strict)
+}
+
+// renderExportIndex builds the markdown index linking each exported repo file.
+func renderExportIndex(repos []exportedRepo) string {
+	var sb strings.Builder
+	sb.WriteString("# Architecture Index\n\n")
+	sb.WriteString(fmt.Sprintf("Exported %s, %d repositories.\n\n", time.Now().UTC().Format(time.RFC3339), len(repos)))
+	sb.WriteString("| Repository | Sections | Size |\n|---|---|---|\n")
+	for _, r := range repos {
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %d | %d bytes |\n", r.Repo, r.File, r.Sections, r.Bytes))
+	}
+	return sb.String()
+}
+
+// parseSectionFilter turns a comma-separated --sections value into a set.
+// An empty value returns nil, meaning no filtering.
+func parseSectionFilter(sections string) map[string]bool {
+	if strings.TrimSpace(sections) == "" {
+		return nil
+	}
+	filter := make(map[string]bool)
+	for _, s := range strings.Split(sections, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			filter[s] = true
+		}
 	}
+	return filter
+}
 
-	output.F.Println()
-	output.F.Success(fmt.Sprintf("Exported %d/%d repos to %s", exported, len(repoList.Repos), dir))
-	return nil
+// filterSections keeps the sections named in filter, in index order, and
+// records requested IDs the repo doesn't have in missing (when non-nil).
+func filterSections(repo string, sections []api.WikiSection, filter map[string]bool, missing missingSections) []api.WikiSection {
+	if filter == nil {
+		return sections
+	}
+	found := make(map[string]bool)
+	var selected []api.WikiSection
+	for _, s := range sections {
+		if filter[s.Name()] {
+			selected = append(selected, s)
+			found[s.Name()] = true
+		}
+	}
+	for id := range filter {
+		if !found[id] && missing != nil {
+			missing[repo] = append(missing[repo], id)
+		}
+	}
+	return selected
 }
 
+// missingSections collects, per repo, the --sections IDs it doesn't have, so
+// commands spanning many repos warn about them once.
+type missingSections map[string][]string
 
+// warn emits a single warning listing every repo's missing sections.
+func (m missingSections) warn() {
+	if len(m) == 0 {
+		return
+	}
+	repos := make([]string, 0, len(m))
+	for repo := range m {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	parts := make([]string, len(repos))
+	for i, repo := range repos {
+		ids := m[repo]
+		sort.Strings(ids)
+		parts[i] = fmt.Sprintf("%s (%s)", repo, strings.Join(ids, ", "))
+	}
+	warning("No such section(s): " + strings.Join(parts, "; "))
+}
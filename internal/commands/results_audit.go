@@ -2,8 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
@@ -11,27 +13,84 @@ import (
 )
 
 func newResultsAuditCmd() *cobra.Command {
-	return &cobra.Command{
+	var concurrency int
+	var expected, expectedFile string
+	var only string
+	var exclude []string
+	var strict, allRepos bool
+	var minCoverage float64
+
+	cmd := &cobra.Command{
 		Use:   "audit",
 		Short: "Validate all repos have complete investigation sections",
 		Long: `Check every repo with results and verify it has all expected sections.
-The expected section list is derived from the majority of completed repos.
+The expected section list is derived from the majority of completed repos,
+or from at least --min-coverage percent of them. Pass --expected (or
+--expected-file, one name per line or comma-separated) to give the canonical
+list explicitly and skip the inference, e.g. while part of the fleet is
+freshly onboarded.
 
 Reports:
   - Total repos and section coverage
   - Any repos with missing or extra sections
-  - Summary pass/fail`,
+  - Summary pass/fail
+
+Human output lists failing repos only; --all-repos prints every repo with
+its section count and OK/FAIL. --only restricts the audit to repos matching the
+given names or globs.
+
+Repo indexes are fetched in parallel (--concurrency, default 8); output is
+always ordered by repo name. Transient failures are retried; indexes that
+still can't be read are listed under "errors" in --json and warned about
+otherwise. --strict exits non-zero when that happens.
+
+Examples:
+  reposwarm results audit
+  reposwarm results audit --min-coverage 80
+  reposwarm results audit --only 'team-a-*' --all-repos
+  reposwarm results audit --exclude 'archived-*'
+  reposwarm results audit --expected hl_overview,module_deep_dive,apis
+  reposwarm results audit --expected-file sections.txt --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			explicit, err := auditExpectedSections(expected, expectedFile)
+			if err != nil {
+				return err
+			}
+			if explicit != nil && cmd.Flags().Changed("min-coverage") {
+				return fmt.Errorf("--min-coverage only applies when inferring; drop it or --expected/--expected-file")
+			}
+			if minCoverage < 0 || minCoverage > 100 {
+				return fmt.Errorf("--min-coverage must be between 0 and 100")
+			}
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
 			var repoList api.WikiReposResponse
-			if err := client.Get(ctx(), "/wiki", &repoList); err != nil {
+			if err := getWikiRepos(client, &repoList); err != nil {
+				return err
+			}
+
+			excluded, err := repoExclusions(exclude)
+			if err != nil {
 				return err
 			}
 
+			if patterns := splitCSV(only); len(patterns) > 0 {
+				var subset []api.WikiRepoSummary
+				for _, r := range repoList.Repos {
+					if matchesAny(r.Name, patterns) {
+						subset = append(subset, r)
+					}
+				}
+				repoList.Repos = subset
+			}
+			repoList.Repos, _ = excludeRepos(repoList.Repos, func(r api.WikiRepoSummary) string { return r.Name }, excluded)
+
 			if len(repoList.Repos) == 0 {
 				output.F.Info("No repos with results")
 				return nil
@@ -45,15 +104,24 @@ Reports:
 				OK       bool     `json:"ok"`
 			}
 
+			sort.Slice(repoList.Repos, func(i, j int) bool { return repoList.Repos[i].Name < repoList.Repos[j].Name })
+
 			// Collect section names from all repos
 			sectionFreq := map[string]int{}
 			repoSections := map[string][]string{}
 			var fetchFailed []repoResult
+			fetchErrs := []fetchError{}
 
-			for _, r := range repoList.Repos {
-				var index api.WikiIndex
-				if err := client.Get(ctx(), "/wiki/"+r.Name, &index); err != nil {
+			status := startStatus(fmt.Sprintf("Fetching repo 0/%d...", len(repoList.Repos)))
+			indexes, errs := fetchWikiIndexes(client, repoList.Repos, concurrency, func(done, total int) {
+				status.Update(fmt.Sprintf("Fetching repo %d/%d...", done, total))
+			})
+			status.Stop()
+			for i, r := range repoList.Repos {
+				index := indexes[i]
+				if index == nil {
 					fetchFailed = append(fetchFailed, repoResult{Name: r.Name, OK: false, Missing: []string{"(fetch failed)"}})
+					fetchErrs = addFetchError(fetchErrs, r.Name, "", errs[i])
 					continue
 				}
 				var names []string
@@ -65,13 +133,23 @@ Reports:
 				repoSections[r.Name] = names
 			}
 
-			// Expected = sections in majority of repos
+			// Expected = explicit list, or sections in majority (or --min-coverage %) of repos
 			totalRepos := len(repoList.Repos)
-			threshold := totalRepos / 2
+			mode := "inferred"
 			var expectedSections []string
-			for name, count := range sectionFreq {
-				if count > threshold {
-					expectedSections = append(expectedSections, name)
+			if explicit != nil {
+				mode = "explicit"
+				expectedSections = explicit
+			} else {
+				threshold := totalRepos / 2
+				for name, count := range sectionFreq {
+					covered := count > threshold
+					if cmd.Flags().Changed("min-coverage") {
+						covered = float64(count)*100 >= minCoverage*float64(totalRepos)
+					}
+					if covered {
+						expectedSections = append(expectedSections, name)
+					}
 				}
 			}
 			sort.Strings(expectedSections)
@@ -122,43 +200,129 @@ Reports:
 			failCount := len(results) - passCount
 
 			if flagJSON {
-				return output.JSON(map[string]any{
+				out := map[string]any{
 					"totalRepos":       totalRepos,
+					"mode":             mode,
 					"expectedSections": expectedSections,
 					"passed":           passCount,
 					"failed":           failCount,
 					"repos":            results,
-				})
+					"errors":           fetchErrs,
+				}
+				if cmd.Flags().Changed("min-coverage") {
+					out["minCoverage"] = minCoverage
+				}
+				if err := output.JSON(out); err != nil {
+					return err
+				}
+				if strict && len(fetchErrs) > 0 {
+					return fmt.Errorf("%s could not be read; results are incomplete", pluralizeCount(len(fetchErrs), "repo"))
+				}
+				return nil
 			}
 
 			F := output.F
 			F.Section(fmt.Sprintf("Results Audit (%d repos, %d expected sections)", totalRepos, len(expectedSections)))
-			F.Printf("Expected: %s\n\n", strings.Join(expectedSections, ", "))
+			F.Printf("Expected (%s): %s\n\n", mode, strings.Join(expectedSections, ", "))
 
-			// Only show repos with issues (or all if verbose)
-			hasIssues := false
+			// Only show repos with issues (or all with --all-repos)
+			printed := false
 			for _, r := range results {
-				if !r.OK {
-					hasIssues = true
-					issues := ""
-					if len(r.Missing) > 0 {
-						issues += fmt.Sprintf("missing: %s", strings.Join(r.Missing, ", "))
-					}
-					if len(r.Extra) > 0 {
-						if issues != "" {
-							issues += "; "
-						}
-						issues += fmt.Sprintf("extra: %s", strings.Join(r.Extra, ", "))
+				if r.OK && !allRepos {
+					continue
+				}
+				printed = true
+				issues := ""
+				if len(r.Missing) > 0 {
+					issues += fmt.Sprintf("missing: %s", strings.Join(r.Missing, ", "))
+				}
+				if len(r.Extra) > 0 {
+					if issues != "" {
+						issues += "; "
 					}
-					F.Printf("FAIL  %-30s %d/%d  %s\n", r.Name, len(r.Sections), len(expectedSections), issues)
+					issues += fmt.Sprintf("extra: %s", strings.Join(r.Extra, ", "))
+				}
+				label := "FAIL"
+				if r.OK {
+					label = "OK  "
 				}
+				F.Printf("%s  %-30s %d/%d  %s\n", label, r.Name, len(r.Sections), len(expectedSections), issues)
 			}
-			if !hasIssues {
+			if !printed {
 				F.Println()
 			}
 
 			F.CheckSummary(passCount, 0, failCount)
-			return nil
+			return reportFetchErrors(fetchErrs, strict)
 		},
 	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "Number of repo indexes to fetch in parallel")
+	cmd.Flags().StringVar(&expected, "expected", "", "Comma-separated canonical section list (skips inference)")
+	cmd.Flags().StringVar(&expectedFile, "expected-file", "", "File with the canonical section list (skips inference)")
+	cmd.Flags().StringVar(&only, "only", "", "Audit only repos matching these comma-separated names or globs")
+	addExcludeFlag(cmd, &exclude)
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit non-zero if any repo index could not be read")
+	cmd.Flags().BoolVar(&allRepos, "all-repos", false, "List every repo in human output, not just failing ones")
+	cmd.Flags().Float64Var(&minCoverage, "min-coverage", 0, "Infer sections present in at least this % of repos (default: more than half)")
+	addTimingsFlag(cmd)
+	return cmd
+}
+
+// auditExpectedSections returns the explicit expected section list from
+// --expected or --expected-file, or nil to infer it from the fleet.
+func auditExpectedSections(expected, expectedFile string) ([]string, error) {
+	if expected != "" && expectedFile != "" {
+		return nil, fmt.Errorf("--expected and --expected-file are mutually exclusive")
+	}
+	var names []string
+	switch {
+	case expected != "":
+		names = splitCSV(expected)
+	case expectedFile != "":
+		data, err := os.ReadFile(expectedFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading --expected-file: %w", err)
+		}
+		names = parseNameList(string(data))
+	default:
+		return nil, nil
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("expected section list is empty")
+	}
+	return names, nil
+}
+
+// fetchWikiIndexes fetches /wiki/<repo> for each repo using up to workers
+// concurrent requests, retrying transient failures. The results are
+// index-aligned with repos; a nil index comes with the error that caused it.
+// progress, if set, is called after each fetch.
+func fetchWikiIndexes(client *api.Client, repos []api.WikiRepoSummary, workers int, progress func(done, total int)) ([]*api.WikiIndex, []error) {
+	indexes := make([]*api.WikiIndex, len(repos))
+	errs := make([]error, len(repos))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i, r := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			var index api.WikiIndex
+			if errs[i] = getWithRetry(client, "/wiki/"+name, &index); errs[i] == nil {
+				indexes[i] = &index
+			}
+			if progress != nil {
+				mu.Lock()
+				done++
+				progress(done, len(repos))
+				mu.Unlock()
+			}
+		}(i, r.Name)
+	}
+	wg.Wait()
+	return indexes, errs
 }
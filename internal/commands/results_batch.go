@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+)
+
+// sectionPair is one "repo section" line of a batch read.
+type sectionPair struct {
+	Repo    string
+	Section string
+}
+
+// parseSectionPairs reads "repo section" pairs, one per line. Blank lines
+// and lines starting with # are skipped; "repo/section" is accepted too.
+func parseSectionPairs(r io.Reader) ([]sectionPair, error) {
+	var pairs []sectionPair
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 1 {
+			fields = strings.SplitN(fields[0], "/", 2)
+		}
+		if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+			return nil, fmt.Errorf("line %d: expected \"<repo> <section>\", got %q", n, line)
+		}
+		pairs = append(pairs, sectionPair{Repo: fields[0], Section: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no repo/section pairs given")
+	}
+	return pairs, nil
+}
+
+// readSectionPairs loads pairs from path, or from stdin when path is "" or "-".
+func readSectionPairs(path string) ([]sectionPair, error) {
+	if path == "" || path == "-" {
+		return parseSectionPairs(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading pairs file: %w", err)
+	}
+	defer f.Close()
+	return parseSectionPairs(f)
+}
+
+// fetchSectionPairs fetches each pair using up to workers concurrent
+// requests. Results and errors are index-aligned with pairs.
+func fetchSectionPairs(client *api.Client, pairs []sectionPair, workers int) ([]api.WikiContent, []error) {
+	contents := make([]api.WikiContent, len(pairs))
+	errs := make([]error, len(pairs))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, p := range pairs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, p sectionPair) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = client.Get(ctx(), "/wiki/"+p.Repo+"/"+p.Section, &contents[i])
+			if contents[i].Repo == "" {
+				contents[i].Repo = p.Repo
+			}
+			if contents[i].Section == "" {
+				contents[i].Section = p.Section
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return contents, errs
+}
+
+// runBatchRead implements `results read --pairs-file/--section-from-stdin`:
+// every section is fetched concurrently and printed in input order.
+func runBatchRead(client *api.Client, path string, workers int, raw bool, body func(string) string) error {
+	if workers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	pairs, err := readSectionPairs(path)
+	if err != nil {
+		return err
+	}
+
+	status := startStatus(fmt.Sprintf("Reading %d sections...", len(pairs)))
+	contents, errs := fetchSectionPairs(client, pairs, workers)
+	status.Stop()
+
+	var fetched []api.WikiContent
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			output.F.Error(fmt.Sprintf("Failed to read %s/%s: %s", pairs[i].Repo, pairs[i].Section, err))
+			failed++
+			continue
+		}
+		fetched = append(fetched, contents[i])
+	}
+
+	switch {
+	case flagJSON:
+		if fetched == nil {
+			fetched = []api.WikiContent{}
+		}
+		if err := outputList(fetched); err != nil {
+			return err
+		}
+	case raw:
+		for _, c := range fetched {
+			fmt.Printf("## %s / %s\n\n%s\n\n", c.Repo, c.Section, c.Content)
+		}
+	default:
+		F := output.F
+		F.Section(fmt.Sprintf("Results — %d sections", len(fetched)))
+		for _, c := range fetched {
+			F.Printf("--- %s / %s ---\n", c.Repo, c.Section)
+			F.Info(c.CreatedAt)
+			F.Println()
+			fmt.Println(body(c.Content))
+			F.Println()
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sections could not be read", failed, len(pairs))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"net/url"
+
+	"github.com/reposwarm/reposwarm-cli/internal/config"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newResultsOpenCmd() *cobra.Command {
+	var printOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "open <repo>",
+		Short: "Open a repo's results in the RepoSwarm UI",
+		Long: `Open the RepoSwarm UI wiki page for a repository in the default browser.
+
+The UI base URL comes from the uiUrl config key, or http://localhost:<uiPort>.
+When no browser opener is available or stdout isn't a terminal, the URL is printed instead.
+
+Examples:
+  reposwarm results open is-odd
+  reposwarm results open is-odd --print
+  reposwarm config set uiUrl https://reposwarm.internal.example.com`,
+		Args: friendlyExactArgs(1, "reposwarm results open <repo>\n\nExample:\n  reposwarm results open my-repo"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			repo := args[0]
+			pageURL := resultsUIURL(cfg, repo)
+
+			if flagJSON {
+				opened := false
+				if !printOnly && stdoutIsTerminal() {
+					opened = openBrowser(pageURL) == nil
+				}
+				return output.JSON(map[string]any{
+					"repo":   repo,
+					"url":    pageURL,
+					"opened": opened,
+				})
+			}
+
+			openOrPrint(pageURL, printOnly, repo+" results")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&printOnly, "print", false, "Print the URL instead of opening it")
+	return cmd
+}
+
+// resultsUIURL returns the UI wiki page URL for a repo.
+func resultsUIURL(cfg *config.Config, repo string) string {
+	return cfg.EffectiveUIURL() + "/wiki/" + url.PathEscape(repo)
+}
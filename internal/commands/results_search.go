@@ -13,6 +13,9 @@ func newResultsSearchCmd() *cobra.Command {
 	var repoFilter string
 	var sectionFilter string
 	var maxHits int
+	var countOnly bool
+	var exclude []string
+	var strict bool
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
@@ -21,11 +24,24 @@ func newResultsSearchCmd() *cobra.Command {
 
 Without filters, searches all repos (can be slow for many repos).
 Use --repo to limit to a specific repo, --section for a specific section.
+When searching all repos, --exclude (repeatable) and the excludeRepos config
+key skip repos matching a name or glob.
+
+With --count, print the number of matching lines per repo/section and a
+total instead of the lines themselves (like grep -c); --max is ignored.
+
+Each repo and section fetch is retried on connection errors and 5xx/429
+responses. Anything still unreadable (missing sections aside) is reported as
+a warning, on stderr with --json, so a short hit list isn't mistaken for a
+complete one; with --strict the search fails instead of printing partial
+results.
 
 Examples:
   reposwarm results search "Cognito" --repo my-app
   reposwarm results search "DynamoDB" --section DBs
-  reposwarm results search "security" --max 20`,
+  reposwarm results search "security" --max 20
+  reposwarm results search "TODO" --count
+  reposwarm results search "TODO" --exclude 'archived-*' --exclude sandbox`,
 		Args: friendlyExactArgs(1, "reposwarm results search <query>\n\nExample:\n  reposwarm results search \"DynamoDB\" --repo my-app"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
@@ -41,29 +57,46 @@ Examples:
 				Line    string `json:"line"`
 			}
 
+			type SearchCount struct {
+				Repo    string `json:"repo"`
+				Section string `json:"section"`
+				Count   int    `json:"count"`
+			}
+
 			var hits []SearchHit
+			var counts []SearchCount
+			var fetchErrs []fetchError
+			total := 0
 			done := false
 
-			// Get repo list
+			// Get repo list; an explicit --repo is searched even if excluded
 			var repos []string
 			if repoFilter != "" {
 				repos = []string{repoFilter}
 			} else {
+				excluded, err := repoExclusions(exclude)
+				if err != nil {
+					return err
+				}
 				var repoList api.WikiReposResponse
-				if err := client.Get(ctx(), "/wiki", &repoList); err != nil {
+				if err := getWikiRepos(client, &repoList); err != nil {
 					return err
 				}
 				for _, r := range repoList.Repos {
 					repos = append(repos, r.Name)
 				}
+				repos, _ = excludeRepos(repos, func(s string) string { return s }, excluded)
 			}
 
-			for _, repoName := range repos {
+			status := startStatus("Searching...")
+			for i, repoName := range repos {
 				if done {
 					break
 				}
+				status.Update(fmt.Sprintf("Searching repo %d/%d (%s)...", i+1, len(repos), repoName))
 				var index api.WikiIndex
-				if err := client.Get(ctx(), "/wiki/"+repoName, &index); err != nil {
+				if err := getWithRetry(client, "/wiki/"+repoName, &index); err != nil {
+					fetchErrs = addFetchError(fetchErrs, repoName, "", err)
 					continue
 				}
 				for _, s := range index.Sections {
@@ -75,7 +108,15 @@ Examples:
 						continue
 					}
 					var content api.WikiContent
-					if err := client.Get(ctx(), "/wiki/"+repoName+"/"+sName, &content); err != nil {
+					if err := getWithRetry(client, "/wiki/"+repoName+"/"+sName, &content); err != nil {
+						fetchErrs = addFetchError(fetchErrs, repoName, sName, err)
+						continue
+					}
+					if countOnly {
+						if n := countMatchingLines(content.Content, query); n > 0 {
+							counts = append(counts, SearchCount{Repo: repoName, Section: sName, Count: n})
+							total += n
+						}
 						continue
 					}
 					for _, line := range strings.Split(content.Content, "\n") {
@@ -102,11 +143,37 @@ Examples:
 				}
 			}
 
-			if flagJSON {
-				return output.JSON(hits)
+			status.Stop()
+			if err := reportFetchErrors(fetchErrs, strict); err != nil {
+				return err
 			}
 
 			F := output.F
+			if countOnly {
+				if flagJSON {
+					if counts == nil {
+						counts = []SearchCount{}
+					}
+					return outputList(counts)
+				}
+				F.Section(fmt.Sprintf("Search '%s' (%d hits in %d sections)", args[0], total, len(counts)))
+				if len(counts) == 0 {
+					F.Info("No results found")
+					return nil
+				}
+				rows := make([][]string, 0, len(counts))
+				for _, c := range counts {
+					rows = append(rows, []string{c.Repo, c.Section, fmt.Sprint(c.Count)})
+				}
+				F.Table([]string{"Repo", "Section", "Matches"}, rows)
+				F.Printf("\nTotal: %d\n", total)
+				return nil
+			}
+
+			if flagJSON {
+				return outputList(hits)
+			}
+
 			suffix := ""
 			if done && maxHits > 0 {
 				suffix = fmt.Sprintf(", limited to %d", maxHits)
@@ -136,5 +203,21 @@ Examples:
 	cmd.Flags().StringVar(&repoFilter, "repo", "", "Limit search to specific repo")
 	cmd.Flags().StringVar(&sectionFilter, "section", "", "Limit search to specific section")
 	cmd.Flags().IntVar(&maxHits, "max", 50, "Maximum number of hits (0 = unlimited)")
+	cmd.Flags().BoolVar(&countOnly, "count", false, "Only print the number of matches per repo/section")
+	addExcludeFlag(cmd, &exclude)
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail instead of printing partial results if any repo or section could not be read")
+	addTimingsFlag(cmd)
 	return cmd
 }
+
+// countMatchingLines counts the non-blank lines of content containing query
+// (already lower-cased), the same lines a search would list.
+func countMatchingLines(content, query string) int {
+	n := 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" && strings.Contains(strings.ToLower(line), query) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// treeRepo is one repo node of 'results tree'.
+type treeRepo struct {
+	Name        string            `json:"name"`
+	LastUpdated string            `json:"lastUpdated,omitempty"`
+	Sections    []api.WikiSection `json:"sections"`
+	Error       string            `json:"error,omitempty"`
+}
+
+func newResultsTreeCmd() *cobra.Command {
+	var repo string
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show all results as a repo/section tree",
+		Long: `Show every repo with results as a tree, with its sections nested beneath
+and annotated with when they were last updated.
+
+Repo indexes are fetched in parallel (--concurrency, default 8). --repo limits
+the tree to one repo.
+
+Examples:
+  reposwarm results tree
+  reposwarm results tree --repo is-odd
+  reposwarm results tree --json`,
+		Args: friendlyMaxArgs(0, "reposwarm results tree [--repo <name>]\n\nExamples:\n  reposwarm results tree\n  reposwarm results tree --repo my-repo"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var tree []treeRepo
+			if repo != "" {
+				var index api.WikiIndex
+				if err := client.Get(ctx(), "/wiki/"+repo, &index); err != nil {
+					return withResultsSuggestions(client, err, repo, "")
+				}
+				tree = append(tree, treeRepo{Name: repo, Sections: index.Sections})
+			} else {
+				var list api.WikiReposResponse
+				if err := getWikiRepos(client, &list); err != nil {
+					return err
+				}
+				sort.Slice(list.Repos, func(i, j int) bool { return list.Repos[i].Name < list.Repos[j].Name })
+				status := startStatus(fmt.Sprintf("Reading repo 0/%d...", len(list.Repos)))
+				indexes, _ := fetchWikiIndexes(client, list.Repos, concurrency, func(done, total int) {
+					status.Update(fmt.Sprintf("Reading repo %d/%d...", done, total))
+				})
+				status.Stop()
+				for i, r := range list.Repos {
+					node := treeRepo{Name: r.Name, LastUpdated: r.LastUpdated}
+					if indexes[i] == nil {
+						node.Error = "failed to read index"
+					} else {
+						node.Sections = indexes[i].Sections
+					}
+					tree = append(tree, node)
+				}
+			}
+			for i := range tree {
+				if tree[i].Sections == nil {
+					tree[i].Sections = []api.WikiSection{}
+				}
+				// Fall back to the newest section when /wiki gave no date
+				if tree[i].LastUpdated == "" {
+					for _, s := range tree[i].Sections {
+						if s.CreatedAt > tree[i].LastUpdated {
+							tree[i].LastUpdated = s.CreatedAt
+						}
+					}
+				}
+			}
+
+			if flagJSON {
+				return output.JSON(tree)
+			}
+
+			F := output.F
+			if len(tree) == 0 {
+				F.Info("No repos with results")
+				return nil
+			}
+			F.Section(fmt.Sprintf("Results Tree (%d repos)", len(tree)))
+			for _, r := range tree {
+				annotation := fmt.Sprintf("%d sections", len(r.Sections))
+				if r.LastUpdated != "" {
+					annotation += ", updated " + r.LastUpdated
+				}
+				if r.Error != "" {
+					annotation = output.Red(r.Error)
+				}
+				F.Printf("  %s %s\n", output.Bold(r.Name), output.Dim("("+annotation+")"))
+				for i, s := range r.Sections {
+					branch := "├── "
+					if i == len(r.Sections)-1 {
+						branch = "└── "
+					}
+					line := "  " + output.Dim(branch) + F.SectionIcon(s.Name()) + s.Name()
+					if s.CreatedAt != "" {
+						line += "  " + output.Dim(s.CreatedAt)
+					}
+					F.Println(line)
+				}
+			}
+			F.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "Only show this repo")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "Number of repo indexes to fetch in parallel")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func treeRoutes() map[string]any {
+	return map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{
+				{"name": "meshmart", "lastUpdated": "2026-03-02"},
+				{"name": "is-odd", "lastUpdated": "2026-03-01"},
+			},
+		},
+		"GET /wiki/is-odd": map[string]any{
+			"repo": "is-odd",
+			"sections": []map[string]any{
+				{"id": "hl_overview", "createdAt": "2026-03-01T10:00:00Z"},
+				{"id": "APIs", "createdAt": "2026-03-01T11:00:00Z"},
+			},
+		},
+		"GET /wiki/meshmart": map[string]any{
+			"repo":     "meshmart",
+			"sections": []map[string]any{{"id": "DBs"}},
+		},
+	}
+}
+
+func TestResultsTree(t *testing.T) {
+	_, cleanup := testServer(t, treeRoutes())
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "tree")
+	if err != nil {
+		t.Fatalf("results tree: %v", err)
+	}
+	for _, want := range []string{
+		"is-odd (2 sections, updated 2026-03-01)",
+		"├── 📋 hl_overview  2026-03-01T10:00:00Z",
+		"└── 🌐 APIs",
+		"meshmart (1 sections, updated 2026-03-02)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tree missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "is-odd") > strings.Index(out, "meshmart") {
+		t.Errorf("repos should be sorted by name:\n%s", out)
+	}
+}
+
+func TestResultsTreeRepoJSON(t *testing.T) {
+	_, cleanup := testServer(t, treeRoutes())
+	defer cleanup()
+
+	out, err := runCmd(t, "results", "tree", "--repo", "is-odd", "--json")
+	if err != nil {
+		t.Fatalf("results tree --repo: %v", err)
+	}
+	var tree []struct {
+		Name        string `json:"name"`
+		LastUpdated string `json:"lastUpdated"`
+		Sections    []struct {
+			ID string `json:"id"`
+		} `json:"sections"`
+	}
+	if err := json.Unmarshal([]byte(out), &tree); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(tree) != 1 || tree[0].Name != "is-odd" || len(tree[0].Sections) != 2 {
+		t.Fatalf("tree = %+v", tree)
+	}
+	if tree[0].LastUpdated != "2026-03-01T11:00:00Z" {
+		t.Errorf("lastUpdated = %q, want newest section time", tree[0].LastUpdated)
+	}
+}
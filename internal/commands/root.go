@@ -3,8 +3,12 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/config"
@@ -14,14 +18,29 @@ import (
 
 var (
 	flagJSON     bool
+	flagJSONL    bool
+	flagCompact  bool
+	flagFields   string
 	flagAgent    bool
 	flagAPIUrl   string
 	flagAPIToken string
 	flagVerbose  bool
+	flagQuiet    bool
+	flagInsecure bool
+	flagCACert   string
+	flagProxy    string
+	flagHeaders  []string
+	flagNoIcons  bool
+	flagNoCache  bool
+	flagConfig   string
+
+	// cliVersion is the running CLI version, used for the User-Agent header.
+	cliVersion string
 )
 
 // NewRootCmd creates the root cobra command with all subcommands.
 func NewRootCmd(version string) *cobra.Command {
+	cliVersion = version
 	root := &cobra.Command{
 		Use:   "reposwarm",
 		Short: "CLI for RepoSwarm — AI-powered multi-repo architecture discovery",
@@ -38,15 +57,15 @@ Get started:
   reposwarm results list           Browse investigation results`,
 		Version: version,
 		PersistentPostRun: func(cmd *cobra.Command, args []string) {
-			// Don't print hint for version, help, completion, or JSON mode
+			// Don't print hint for version, help, completion, raw api output, or JSON mode
 			name := cmd.Name()
-			if name == "version" || name == "help" || name == "completion" || flagJSON {
+			if name == "version" || name == "help" || name == "completion" || name == cobra.ShellCompRequestCmd || name == "api" || flagJSON || flagExplain {
 				return
 			}
 			output.F.Finish()
 		},
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			output.InitFormatter(!flagAgent)
+			applyGlobalFlags()
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -54,14 +73,28 @@ Get started:
 
 	root.Flags().BoolP("version", "v", false, "Print version")
 	root.PersistentFlags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	root.PersistentFlags().BoolVar(&flagJSONL, "jsonl", false, "Output lists as JSON Lines (one object per line; implies --json)")
+	root.PersistentFlags().BoolVar(&flagCompact, "compact", false, "With --json, print single-line JSON instead of indented (implies --json)")
+	root.PersistentFlags().StringVar(&flagFields, "fields", "", "With --json/--jsonl, keep only these comma-separated top-level fields (e.g. name,enabled)")
 	root.PersistentFlags().BoolVar(&flagAgent, "for-agent", false, "Plain text output for agents/scripts")
+	root.PersistentFlags().StringVar(&flagConfig, "config", "", "Config file to use instead of the default (see 'reposwarm config path')")
 	root.PersistentFlags().StringVar(&flagAPIUrl, "api-url", "", "API server URL (overrides config)")
-	root.PersistentFlags().StringVar(&flagAPIToken, "api-token", "", "API bearer token (overrides config)")
+	root.PersistentFlags().StringVar(&flagAPIToken, "api-token", "", "API bearer token, or file:<path> to read it from a file (overrides config)")
 	root.PersistentFlags().BoolVar(&flagVerbose, "verbose", false, "Show debug info")
+	root.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Print only essential data (no banners, blank lines or hints)")
+	root.PersistentFlags().BoolVar(&flagNoIcons, "no-icons", false, "Drop emoji section icons but keep colors (config key noIcons)")
+	root.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Don't use or update the on-disk HTTP response cache (config key noHttpCache)")
+	root.PersistentFlags().BoolVar(&flagInsecure, "insecure", false, "Skip TLS certificate verification (dev servers only)")
+	root.PersistentFlags().StringVar(&flagCACert, "ca-cert", "", "PEM CA bundle to trust for the API server")
+	root.PersistentFlags().StringVar(&flagProxy, "proxy", "", "HTTP/SOCKS proxy URL (overrides HTTP_PROXY/HTTPS_PROXY)")
+	root.PersistentFlags().StringArrayVar(&flagHeaders, "header", nil, "Extra API request header as key=value (repeatable; adds to extraHeaders config)")
 
 	// Setup & diagnostics
 	root.AddCommand(newNewCmd())
-	root.AddCommand(newDoctorCmd(version))
+	doctorCmd := newDoctorCmd(version)
+	doctorCmd.AddCommand(newEnvCmd())
+	root.AddCommand(doctorCmd)
+	root.AddCommand(newEnvCmd())
 	root.AddCommand(&cobra.Command{
 		Use:   "version",
 		Short: "Print the version number",
@@ -70,6 +103,8 @@ Get started:
 		},
 	})
 	root.AddCommand(newStatusCmd())
+	root.AddCommand(newSummaryCmd())
+	root.AddCommand(newPingCmd())
 	root.AddCommand(newConfigCmd())
 	root.AddCommand(newUpgradeCmd(version))
 	root.AddCommand(newChangelogCmd(version))
@@ -78,6 +113,7 @@ Get started:
 	root.AddCommand(newTunnelCmd())
 	root.AddCommand(newShowCmd())
 	root.AddCommand(newURLCmd())
+	root.AddCommand(newAPICmd())
 
 	// Repos (includes discover as subcommand)
 	root.AddCommand(newReposCmd())
@@ -87,6 +123,7 @@ Get started:
 	root.AddCommand(newDashboardCmd())
 	root.AddCommand(newErrorsCmd())
 	root.AddCommand(newInvestigateCmd())
+	root.AddCommand(newScheduleCmd())
 	root.AddCommand(newLogsCmd())
 	root.AddCommand(newWorkersCmd())
 	root.AddCommand(newPreflightCmd())
@@ -105,6 +142,8 @@ Get started:
 	// AI Assistant
 	root.AddCommand(newAskCmd())
 
+	addExplain(root)
+
 
 
 	return root
@@ -112,6 +151,23 @@ Get started:
 
 
 
+// applyGlobalFlags applies the global flags to the config and output
+// packages. It runs before every command.
+func applyGlobalFlags() {
+	config.SetPath(flagConfig)
+	if flagJSONL || flagCompact {
+		flagJSON = true
+	}
+	output.InitFormatter(!flagAgent)
+	output.Compact = flagCompact
+	output.Quiet = flagQuiet
+	output.Fields = splitCSV(flagFields)
+	output.NoIcons = flagNoIcons
+	if cfg, err := config.Load(); err == nil && cfg.NoIcons {
+		output.NoIcons = true
+	}
+}
+
 // getClient creates an API client from config + flag overrides.
 func getClient() (*api.Client, error) {
 	cfg, err := config.Load()
@@ -122,20 +178,147 @@ func getClient() (*api.Client, error) {
 	url := cfg.APIUrl
 	token := cfg.APIToken
 	if flagAPIUrl != "" {
-		url = flagAPIUrl
+		if url, err = config.NormalizeAPIURL(flagAPIUrl); err != nil {
+			return nil, fmt.Errorf("--api-url: %w", err)
+		}
 	}
 	if flagAPIToken != "" {
 		token = flagAPIToken
 	}
 
+	// tokenCommand replaces the static apiToken, but not an explicit --api-token
+	useTokenCommand := cfg.TokenCommand != "" && flagAPIToken == ""
+
 	if url == "" {
 		return nil, fmt.Errorf("no API URL configured: run 'reposwarm config init' or pass --api-url")
 	}
-	if token == "" {
+	if token == "" && !useTokenCommand {
 		return nil, fmt.Errorf("no API token configured: run 'reposwarm config init' or pass --api-token")
 	}
+	if !useTokenCommand {
+		if token, err = config.ResolveToken(token); err != nil {
+			return nil, err
+		}
+	}
+
+	client := api.New(url, token)
+	if useTokenCommand {
+		client.TokenSource = api.NewCommandTokenSource(cfg.TokenCommand)
+	}
+	client.UserAgent = userAgent()
+	if err := configureTransport(client, cfg); err != nil {
+		return nil, err
+	}
+	if client.Headers, err = extraHeaders(cfg); err != nil {
+		return nil, err
+	}
+	if flagVerbose {
+		client.Log = os.Stderr
+	}
+	client.Timings = clientTimings
+	if dir, err := config.CacheDir(); err == nil && !flagNoCache && !cfg.NoHTTPCache {
+		client.Cache = api.NewETagCache(filepath.Join(dir, "http"))
+	}
+	return client, nil
+}
+
+// isBodyFailure reports whether err is a failure the API returned in a 2xx
+// body ({success: false, ...}). The response has still been decoded, so
+// callers with their own success/error fields can handle it themselves.
+func isBodyFailure(err error) bool {
+	var respErr *api.ResponseError
+	return errors.As(err, &respErr)
+}
+
+// configureTransport applies TLS and proxy overrides from flags and config to the client.
+func configureTransport(client *api.Client, cfg *config.Config) error {
+	opts := api.TransportOptions{
+		InsecureSkipVerify: flagInsecure || cfg.InsecureSkipVerify,
+		CACertFile:         cfg.CACert,
+		ProxyURL:           effectiveProxy(cfg),
+	}
+	if flagCACert != "" {
+		opts.CACertFile = flagCACert
+	}
+	if opts.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "WARNING: TLS certificate verification is DISABLED (--insecure / insecureSkipVerify). Use only with trusted dev servers.")
+	}
 
-	return api.New(url, token), nil
+	transport, err := api.NewTransport(opts)
+	if err != nil {
+		return fmt.Errorf("configuring HTTP transport: %w", err)
+	}
+	client.HTTPClient.Transport = transport
+	return nil
+}
+
+// extraHeaders merges the extraHeaders config key with --header flags, which
+// take precedence.
+func extraHeaders(cfg *config.Config) (map[string]string, error) {
+	headers := map[string]string{}
+	for k, v := range cfg.ExtraHeaders {
+		if _, _, err := config.ParseHeader(k + "=" + v); err != nil {
+			return nil, fmt.Errorf("extraHeaders: %w", err)
+		}
+		headers[k] = v
+	}
+	for _, h := range flagHeaders {
+		k, v, err := config.ParseHeader(h)
+		if err != nil {
+			return nil, fmt.Errorf("--header: %w", err)
+		}
+		headers[k] = v
+	}
+	return headers, nil
+}
+
+// userAgent returns the User-Agent sent on all outbound requests.
+func userAgent() string {
+	if cliVersion == "" {
+		return api.DefaultUserAgent
+	}
+	return api.DefaultUserAgent + "/" + cliVersion
+}
+
+// effectiveProxy returns the --proxy flag, falling back to the httpProxy config key.
+func effectiveProxy(cfg *config.Config) string {
+	if flagProxy != "" {
+		return flagProxy
+	}
+	if cfg != nil {
+		return cfg.HTTPProxy
+	}
+	return ""
+}
+
+// newHTTPClient returns a client for non-API downloads (GitHub releases etc.)
+// that honors the proxy settings but not the API server's TLS overrides.
+func newHTTPClient(timeout time.Duration) (*http.Client, error) {
+	cfg, _ := config.Load()
+	transport, err := api.NewTransport(api.TransportOptions{ProxyURL: effectiveProxy(cfg)})
+	if err != nil {
+		return nil, fmt.Errorf("configuring HTTP transport: %w", err)
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// outputList prints a list result as an indented JSON array, or one object
+// per line with --jsonl.
+func outputList(items any) error {
+	if flagJSONL {
+		return output.JSONL(items)
+	}
+	return output.JSON(items)
+}
+
+// warning prints a warning without breaking --json output: in JSON mode it
+// goes to stderr, otherwise through the formatter.
+func warning(msg string) {
+	if flagJSON {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", msg)
+		return
+	}
+	output.F.Warning(msg)
 }
 
 // ctx returns a background context.
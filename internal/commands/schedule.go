@@ -0,0 +1,331 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Show when the scheduled (daily) investigation runs",
+	}
+	cmd.AddCommand(newScheduleShowCmd())
+	return cmd
+}
+
+func newScheduleShowCmd() *cobra.Command {
+	var count int
+
+	cmd := &cobra.Command{
+		Use:     "show",
+		Aliases: []string{"next"},
+		Short:   "Print the schedule expression and the next run times",
+		Long: `Print the server's scheduleExpression and the next few times it fires.
+
+Both EventBridge forms are supported, in UTC:
+  rate(6 hours)              every 6 hours, counted from the last scheduled run
+  cron(0 3 * * ? *)          minute hour day-of-month month day-of-week year
+
+rate() schedules have no fixed anchor, so the next runs are counted from the
+most recent InvestigateReposWorkflow start (or from now if there is none).
+
+Examples:
+  reposwarm schedule show
+  reposwarm schedule next --count 10
+  reposwarm schedule show --json`,
+		Args: friendlyMaxArgs(0, "reposwarm schedule show"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 1 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var cfg api.ConfigResponse
+			if err := client.Get(ctx(), "/config", &cfg); err != nil {
+				return err
+			}
+			if cfg.ScheduleExpression == "" {
+				return fmt.Errorf("the server has no scheduleExpression configured")
+			}
+			sched, err := parseSchedule(cfg.ScheduleExpression)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now().UTC()
+			var anchor time.Time
+			if sched.every > 0 {
+				anchor = lastDailyStart(client)
+			}
+			next := sched.next(now, anchor, count)
+
+			if flagJSON {
+				var times []string
+				for _, t := range next {
+					times = append(times, t.Format(time.RFC3339))
+				}
+				result := map[string]any{
+					"expression": cfg.ScheduleExpression,
+					"timezone":   "UTC",
+					"next":       times,
+				}
+				if !anchor.IsZero() {
+					result["anchor"] = anchor.Format(time.RFC3339)
+				}
+				return output.JSON(result)
+			}
+
+			F := output.F
+			F.Section("Schedule")
+			F.KeyValue("Expression", cfg.ScheduleExpression)
+			if sched.every > 0 {
+				if anchor.IsZero() {
+					F.KeyValue("Counted from", "now (no previous scheduled run found)")
+				} else {
+					F.KeyValue("Counted from", anchor.Format(time.RFC3339)+" (last scheduled run)")
+				}
+			}
+			F.Println()
+			var items []string
+			for _, t := range next {
+				items = append(items, fmt.Sprintf("%s  (in %s)", t.Format("Mon 2006-01-02 15:04 UTC"), formatRelativeTime(t.Sub(now))))
+			}
+			F.List(items)
+			F.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "n", 5, "Number of upcoming runs to show")
+	return cmd
+}
+
+// lastDailyStart returns the start of the most recent InvestigateReposWorkflow,
+// or the zero time if there is none or the lookup fails.
+func lastDailyStart(client *api.Client) time.Time {
+	var result api.WorkflowsResponse
+	if err := client.Get(ctx(), "/workflows?pageSize=100", &result); err != nil {
+		return time.Time{}
+	}
+	var latest time.Time
+	for _, w := range result.Executions {
+		if w.Type != "InvestigateReposWorkflow" {
+			continue
+		}
+		if t, ok := parseTimestamp(w.StartTime); ok && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// schedule is a parsed EventBridge schedule expression: either a fixed
+// interval (rate) or a set of cron fields.
+type schedule struct {
+	every time.Duration
+
+	minutes, hours, doms, months, dows []bool
+	years                              map[int]bool // nil means any year
+}
+
+// parseSchedule parses "rate(N unit)" or "cron(min hour dom month dow year)".
+func parseSchedule(expr string) (*schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if inner, ok := cutWrapped(expr, "rate("); ok {
+		return parseRate(inner)
+	}
+	if inner, ok := cutWrapped(expr, "cron("); ok {
+		return parseCron(inner)
+	}
+	return nil, fmt.Errorf("unsupported schedule expression %q (expected rate(...) or cron(...))", expr)
+}
+
+func cutWrapped(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, ")") {
+		return "", false
+	}
+	return strings.TrimSpace(s[len(prefix) : len(s)-1]), true
+}
+
+func parseRate(s string) (*schedule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid rate %q (expected e.g. rate(6 hours))", s)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid rate value %q", fields[0])
+	}
+	// EventBridge wants "1 hour" but "2 hours"
+	unitName := strings.ToLower(fields[1])
+	if plural := strings.HasSuffix(unitName, "s"); plural != (n > 1) {
+		return nil, fmt.Errorf("invalid rate %q (use a singular unit only with 1, e.g. rate(1 hour) or rate(6 hours))", s)
+	}
+	var unit time.Duration
+	switch strings.TrimSuffix(unitName, "s") {
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	default:
+		return nil, fmt.Errorf("invalid rate unit %q (use minutes, hours or days)", fields[1])
+	}
+	return &schedule{every: time.Duration(n) * unit}, nil
+}
+
+var (
+	monthNames = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	dowNames   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+func parseCron(s string) (*schedule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid cron %q (expected 6 fields: minute hour day-of-month month day-of-week year)", s)
+	}
+	if (fields[2] == "?") == (fields[4] == "?") {
+		return nil, fmt.Errorf("invalid cron %q (exactly one of day-of-month and day-of-week must be ?)", s)
+	}
+	sched := &schedule{}
+	var err error
+	if sched.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron minute: %w", err)
+	}
+	if sched.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron hour: %w", err)
+	}
+	if sched.doms, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron day-of-month: %w", err)
+	}
+	if sched.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+	// EventBridge numbers days of the week 1-7 starting on Sunday
+	if sched.dows, err = parseCronField(fields[4], 1, 7, dowNames); err != nil {
+		return nil, fmt.Errorf("cron day-of-week: %w", err)
+	}
+	if fields[5] != "*" && fields[5] != "?" {
+		years, err := parseCronField(fields[5], 1970, 2199, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cron year: %w", err)
+		}
+		sched.years = map[int]bool{}
+		for y, ok := range years {
+			if ok {
+				sched.years[y] = true
+			}
+		}
+	}
+	return sched, nil
+}
+
+// parseCronField expands a comma-separated list of *, ?, values, ranges
+// (a-b) and steps (*/n, a/n, a-b/n) into a lookup indexed by value.
+// names, if given, are accepted in place of min, min+1, ...
+func parseCronField(field string, min, max int, names []string) ([]bool, error) {
+	set := make([]bool, max+1)
+	value := func(s string) (int, error) {
+		for i, name := range names {
+			if strings.EqualFold(s, name) {
+				return min + i, nil
+			}
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("%q is not a value between %d and %d", s, min, max)
+		}
+		return n, nil
+	}
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+		lo, hi := min, max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = value(a); err != nil {
+				return nil, err
+			}
+			if hi, err = value(b); err != nil {
+				return nil, err
+			}
+			if lo > hi {
+				return nil, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			n, err := value(rng)
+			if err != nil {
+				return nil, err
+			}
+			lo, hi = n, n
+			if hasStep {
+				hi = max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// next returns the first count run times after now. For rate schedules the
+// runs are counted from anchor (or now when anchor is zero).
+func (s *schedule) next(now, anchor time.Time, count int) []time.Time {
+	var runs []time.Time
+	if s.every > 0 {
+		t := now
+		if !anchor.IsZero() && !anchor.After(now) {
+			t = anchor.Add(now.Sub(anchor) / s.every * s.every)
+		}
+		for len(runs) < count {
+			t = t.Add(s.every)
+			runs = append(runs, t)
+		}
+		return runs
+	}
+
+	// Walk day by day (up to ~5 years) and expand matching hours/minutes
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5*366 && len(runs) < count; i++ {
+		d := day.AddDate(0, 0, i)
+		if !s.months[int(d.Month())] || !s.doms[d.Day()] || !s.dows[int(d.Weekday())+1] {
+			continue
+		}
+		if s.years != nil && !s.years[d.Year()] {
+			continue
+		}
+		for h := 0; h < 24 && len(runs) < count; h++ {
+			if !s.hours[h] {
+				continue
+			}
+			for m := 0; m < 60 && len(runs) < count; m++ {
+				if t := d.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute); s.minutes[m] && t.After(now) {
+					runs = append(runs, t)
+				}
+			}
+		}
+	}
+	return runs
+}
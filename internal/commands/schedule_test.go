@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleNextCron(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC) // a Wednesday
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"cron(0 3 * * ? *)", []string{"2026-03-05T03:00:00Z", "2026-03-06T03:00:00Z"}},
+		{"cron(15 */6 * * ? *)", []string{"2026-03-04T12:15:00Z", "2026-03-04T18:15:00Z"}},
+		{"cron(0 9 ? * MON-FRI *)", []string{"2026-03-05T09:00:00Z", "2026-03-06T09:00:00Z", "2026-03-09T09:00:00Z"}},
+		{"cron(0 0 1 JUL ? *)", []string{"2026-07-01T00:00:00Z", "2027-07-01T00:00:00Z"}},
+		{"cron(30 10 4 3 ? 2027)", []string{"2027-03-04T10:30:00Z"}},
+	}
+	for _, tt := range tests {
+		sched, err := parseSchedule(tt.expr)
+		if err != nil {
+			t.Fatalf("parseSchedule(%q): %v", tt.expr, err)
+		}
+		got := sched.next(now, time.Time{}, len(tt.want))
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+		for i, want := range tt.want {
+			if got[i].Format(time.RFC3339) != want {
+				t.Errorf("%s run %d = %s, want %s", tt.expr, i, got[i].Format(time.RFC3339), want)
+			}
+		}
+	}
+}
+
+func TestScheduleNextRate(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	sched, err := parseSchedule("rate(6 hours)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchor := time.Date(2026, 3, 4, 2, 0, 0, 0, time.UTC)
+	got := sched.next(now, anchor, 2)
+	if got[0].Format(time.RFC3339) != "2026-03-04T14:00:00Z" || got[1].Format(time.RFC3339) != "2026-03-04T20:00:00Z" {
+		t.Errorf("rate from anchor = %v", got)
+	}
+	if got := sched.next(now, time.Time{}, 1); !got[0].Equal(now.Add(6 * time.Hour)) {
+		t.Errorf("rate without anchor = %v, want now+6h", got)
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"every day",
+		"rate(0 hours)",
+		"rate(2 weeks)",
+		"rate(1 hours)",
+		"rate(5 minute)",
+		"cron(0 2 * * * *)",
+		"cron(0 9 1 * MON *)",
+		"cron(0 9 ? * ? *)",
+		"cron(0 3 * * ?)",
+		"cron(61 3 * * ? *)",
+		"cron(0 3 L * ? *)",
+		"cron(0 3 ? * 2#1 *)",
+	} {
+		if _, err := parseSchedule(expr); err == nil {
+			t.Errorf("parseSchedule(%q) should fail", expr)
+		}
+	}
+}
+
+func TestScheduleShowJSON(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"/config": map[string]any{"scheduleExpression": "cron(0 3 * * ? *)"},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "schedule", "next", "--count", "3", "--json")
+	if err != nil {
+		t.Fatalf("schedule next: %v", err)
+	}
+	var result struct {
+		Expression string   `json:"expression"`
+		Next       []string `json:"next"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Expression != "cron(0 3 * * ? *)" || len(result.Next) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for _, s := range result.Next {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil || ts.Hour() != 3 || ts.Minute() != 0 || !ts.After(time.Now()) {
+			t.Errorf("unexpected run time %q", s)
+		}
+	}
+}
+
+func TestServerSetScheduleValidates(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"PATCH /config": map[string]any{"success": true},
+		"/workflows":    map[string]any{"executions": []any{}},
+	})
+	defer cleanup()
+
+	_, err := runCmd(t, "config", "server-set", "schedule", "cron(0 2 * *)")
+	if err == nil || !strings.Contains(err.Error(), "Examples:") {
+		t.Fatalf("expected validation error with examples, got %v", err)
+	}
+	if _, err := runCmd(t, "config", "server-set", "schedule", "cron(0 2 * * * *)"); err == nil {
+		t.Fatal("expected cron without ? to be rejected")
+	}
+
+	out, err := runCmd(t, "config", "server-set", "schedule", "cron(0 2 * * ? *)", "--json")
+	if err != nil {
+		t.Fatalf("server-set schedule: %v", err)
+	}
+	var result struct {
+		Key  string   `json:"key"`
+		Next []string `json:"next"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Key != "scheduleExpression" || len(result.Next) != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
@@ -45,23 +46,68 @@ func newConfigServerSetCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "server-set <key> <value>",
 		Short: "Update a server configuration value",
-		Args:  friendlyExactArgs(2, "reposwarm config server-set <key> <value>\n\nExample:\n  reposwarm config server-set defaultModel claude-opus-4-6"),
+		Long: `Update a server configuration value.
+
+scheduleExpression (or just "schedule") is checked before it is sent, and the
+next run times are printed after it is set. It takes EventBridge rate(...)
+or 6-field cron(...) expressions, in UTC.
+
+Examples:
+  reposwarm config server-set defaultModel claude-opus-4-6
+  reposwarm config server-set schedule 'rate(12 hours)'
+  reposwarm config server-set schedule 'cron(0 2 * * ? *)'`,
+		Args: friendlyExactArgs(2, "reposwarm config server-set <key> <value>\n\nExample:\n  reposwarm config server-set defaultModel claude-opus-4-6"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			var sched *schedule
+			if key == "schedule" || key == "scheduleExpression" {
+				key = "scheduleExpression"
+				var err error
+				if sched, err = parseSchedule(value); err != nil {
+					return fmt.Errorf("%w\n  Examples: rate(6 hours), rate(1 day), cron(0 2 * * ? *), cron(0 9 ? * MON-FRI *)", err)
+				}
+			}
+
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
-			body := map[string]any{args[0]: args[1]}
+			body := map[string]any{key: value}
 			var result any
 			if err := client.Patch(ctx(), "/config", body, &result); err != nil {
 				return err
 			}
 
+			var next []time.Time
+			if sched != nil {
+				var anchor time.Time
+				if sched.every > 0 {
+					anchor = lastDailyStart(client)
+				}
+				next = sched.next(time.Now().UTC(), anchor, 3)
+			}
+
 			if flagJSON {
-				return output.JSON(map[string]any{"key": args[0], "value": args[1]})
+				res := map[string]any{"key": key, "value": value}
+				if sched != nil {
+					var times []string
+					for _, t := range next {
+						times = append(times, t.Format(time.RFC3339))
+					}
+					res["next"] = times
+				}
+				return output.JSON(res)
+			}
+			output.F.Success(fmt.Sprintf("Set server %s = %s", key, value))
+			if sched != nil {
+				output.F.Info("Next runs:")
+				var items []string
+				for _, t := range next {
+					items = append(items, t.Format("Mon 2006-01-02 15:04 UTC"))
+				}
+				output.F.List(items)
 			}
-			output.F.Success(fmt.Sprintf("Set server %s = %s", args[0], args[1]))
 			return nil
 		},
 	}
@@ -574,6 +574,9 @@ func toBsConfig(cfg *config.Config) *bootstrap.Config {
 		WorkerRepoURL:  cfg.EffectiveWorkerRepoURL(),
 		APIRepoURL:     cfg.EffectiveAPIRepoURL(),
 		UIRepoURL:      cfg.EffectiveUIRepoURL(),
+		ProxyURL:       effectiveProxy(cfg),
+		ServiceTimeout: durationOr(0, cfg.ServiceTimeout),
+		PollInterval:   durationOr(0, cfg.ReadinessPollInterval),
 	}
 	// Include provider env vars so worker gets CLAUDE_CODE_USE_BEDROCK etc.
 	bsCfg.ProviderEnvVars = config.WorkerEnvVars(&cfg.ProviderConfig, cfg.EffectiveModel())
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 
@@ -74,7 +75,7 @@ func getURLForTarget(cfg *config.Config, target string) (string, error) {
 	case "temporal":
 		return fmt.Sprintf("http://localhost:%s", cfg.EffectiveTemporalUIPort()), nil
 	case "ui":
-		return fmt.Sprintf("http://localhost:%s", cfg.EffectiveUIPort()), nil
+		return cfg.EffectiveUIURL(), nil
 	case "api":
 		// Use configured API URL if set, otherwise construct from port
 		if cfg.APIUrl != "" {
@@ -88,6 +89,22 @@ func getURLForTarget(cfg *config.Config, target string) (string, error) {
 	}
 }
 
+// openOrPrint opens pageURL in the browser, printing it instead with
+// --print, --for-agent, when stdout isn't a terminal, or if no opener works.
+func openOrPrint(pageURL string, printOnly bool, what string) {
+	if printOnly || flagAgent || !stdoutIsTerminal() {
+		fmt.Println(pageURL)
+		return
+	}
+	F := output.F
+	if err := openBrowser(pageURL); err != nil {
+		F.Warning(fmt.Sprintf("Could not open browser: %s", err))
+		F.Info(fmt.Sprintf("URL: %s", pageURL))
+		return
+	}
+	F.Success(fmt.Sprintf("Opened %s: %s", what, pageURL))
+}
+
 // openBrowser opens the specified URL in the default browser.
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
@@ -105,3 +122,16 @@ func openBrowser(url string) error {
 
 	return cmd.Start()
 }
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal.
+func stdoutIsTerminal() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// startStatus starts a transient "is it hung?" status line for slow
+// commands. It only shows in human mode on a terminal, never with --json,
+// and must be stopped before the command prints its results.
+func startStatus(msg string) *output.Spinner {
+	return output.NewStatus(msg, !flagJSON && stdoutIsTerminal())
+}
@@ -1,82 +1,279 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
+// statusTrendSize is how many latency samples the --watch trend keeps.
+const statusTrendSize = 30
+
+// latencyThresholds colors a latency green below Warn, yellow below Slow
+// and red otherwise.
+type latencyThresholds struct {
+	Warn, Slow time.Duration
+}
+
+// parseLatencyThresholds parses "<warn>,<slow>", e.g. "200ms,1s".
+func parseLatencyThresholds(s string) (latencyThresholds, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return latencyThresholds{}, fmt.Errorf("--threshold must be <warn>,<slow> (e.g. 200ms,1s), got %q", s)
+	}
+	var t latencyThresholds
+	var err error
+	if t.Warn, err = time.ParseDuration(strings.TrimSpace(parts[0])); err != nil {
+		return latencyThresholds{}, fmt.Errorf("--threshold: %w", err)
+	}
+	if t.Slow, err = time.ParseDuration(strings.TrimSpace(parts[1])); err != nil {
+		return latencyThresholds{}, fmt.Errorf("--threshold: %w", err)
+	}
+	if t.Warn <= 0 || t.Slow < t.Warn {
+		return latencyThresholds{}, fmt.Errorf("--threshold: need 0 < warn <= slow, got %s,%s", t.Warn, t.Slow)
+	}
+	return t, nil
+}
+
+// color renders latency in ms, colored by how it compares to t.
+func (t latencyThresholds) color(latency time.Duration) string {
+	text := fmt.Sprintf("%dms", latency.Milliseconds())
+	switch {
+	case latency < t.Warn:
+		return output.Green(text)
+	case latency < t.Slow:
+		return output.Yellow(text)
+	}
+	return output.Red(text)
+}
+
 func newStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var watch bool
+	var interval int
+	var threshold string
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Check API health and connection",
+		Long: `Check API health and connection.
+
+With --watch, refreshes the health view on an interval until Ctrl+C.
+In --json --watch mode, one JSON object is emitted per poll (newline-delimited).
+
+Latency is green under 200ms, yellow under 1s and red above; --threshold
+sets other limits as <warn>,<slow>.
+
+Examples:
+  reposwarm status
+  reposwarm status --watch
+  reposwarm status --watch --interval 2 --json
+  reposwarm status --threshold 500ms,2s`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			thresholds, err := parseLatencyThresholds(threshold)
+			if err != nil {
+				return err
+			}
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			if watch {
+				return watchStatus(client, time.Duration(interval)*time.Second, thresholds)
+			}
+
 			start := time.Now()
 			health, err := client.Health(ctx())
 			latency := time.Since(start)
 
 			if err != nil {
 				if flagJSON {
-					return output.JSON(map[string]any{
-						"connected": false,
-						"error":     err.Error(),
-					})
+					return output.JSON(statusJSON(nil, 0, err))
 				}
 				output.F.Error(fmt.Sprintf("Connection failed: %s", err))
+				if hint := connectionHint(err); hint != "" {
+					output.F.Info(hint)
+				}
 				return nil
 			}
 
-			cfg, _ := config.Load()
-
 			if flagJSON {
-				return output.JSON(map[string]any{
-					"connected": true,
-					"status":    health.Status,
-					"version":   health.Version,
-					"latency":   latency.Milliseconds(),
-					"temporal":  health.Temporal.Connected,
-					"dynamodb":  health.DynamoDB.Connected,
-					"worker":    health.Worker.Connected,
-					"apiUrl":    cfg.APIUrl,
-				})
+				return output.JSON(statusJSON(health, latency, nil))
 			}
 
-			F := output.F
-			F.Section("RepoSwarm Status")
-			F.KeyValue("API URL", cfg.APIUrl)
-			F.KeyValue("Status", health.Status)
-			F.KeyValue("Version", health.Version)
-			F.KeyValue("Latency", fmt.Sprintf("%dms", latency.Milliseconds()))
+			renderStatus(health, latency, thresholds)
+			output.F.Println()
+			return nil
+		},
+	}
 
-			svcStatus := func(name string, connected bool) string {
-				if connected {
-					return "ok"
-				}
-				return "DISCONNECTED"
-			}
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Continuously refresh the health view")
+	cmd.Flags().IntVar(&interval, "interval", 5, "Refresh interval in seconds for --watch")
+	cmd.Flags().StringVar(&threshold, "threshold", "200ms,1s", "Latency limits for yellow and red, as <warn>,<slow>")
+	return cmd
+}
 
-			F.Println()
-			F.KeyValue("Temporal", svcStatus("Temporal", health.Temporal.Connected))
-			F.KeyValue("DynamoDB", svcStatus("DynamoDB", health.DynamoDB.Connected))
-			F.KeyValue("Worker", svcStatus("Worker", health.Worker.Connected))
+// connectionHint suggests a next step for a failed API call, or "".
+func connectionHint(err error) string {
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return "Run 'reposwarm config init' to set a valid API token"
+	case errors.Is(err, api.ErrNotFound):
+		return "Check the API URL with 'reposwarm config show' — it usually ends in /v1"
+	case errors.Is(err, api.ErrConnectionFailed):
+		return "Check the API server is running ('reposwarm doctor') and the API URL is correct"
+	}
+	return ""
+}
 
-			if health.Temporal.Connected {
-				F.KeyValue("  namespace", health.Temporal.Namespace)
-				F.KeyValue("  taskQueue", health.Temporal.TaskQueue)
+// statusJSON builds the JSON representation of a health check.
+func statusJSON(health *api.HealthResponse, latency time.Duration, err error) map[string]any {
+	if err != nil {
+		obj := map[string]any{
+			"connected": false,
+			"error":     err.Error(),
+		}
+		if hint := connectionHint(err); hint != "" {
+			obj["hint"] = hint
+		}
+		return obj
+	}
+	cfg, _ := config.Load()
+	return map[string]any{
+		"connected": true,
+		"status":    health.Status,
+		"version":   health.Version,
+		"latency":   latency.Milliseconds(),
+		"temporal":  health.Temporal.Connected,
+		"dynamodb":  health.DynamoDB.Connected,
+		"worker":    health.Worker.Connected,
+		"apiUrl":    cfg.APIUrl,
+	}
+}
+
+// renderStatus prints the human health view.
+func renderStatus(health *api.HealthResponse, latency time.Duration, thresholds latencyThresholds) {
+	cfg, _ := config.Load()
+
+	F := output.F
+	F.Section("RepoSwarm Status")
+	F.KeyValue("API URL", cfg.APIUrl)
+	F.KeyValue("Status", health.Status)
+	F.KeyValue("Version", health.Version)
+	F.KeyValue("Latency", thresholds.color(latency))
+
+	svcStatus := func(name string, connected bool) string {
+		if connected {
+			return "ok"
+		}
+		return "DISCONNECTED"
+	}
+
+	F.Println()
+	F.KeyValue("Temporal", svcStatus("Temporal", health.Temporal.Connected))
+	F.KeyValue("DynamoDB", svcStatus("DynamoDB", health.DynamoDB.Connected))
+	F.KeyValue("Worker", svcStatus("Worker", health.Worker.Connected))
+
+	if health.Temporal.Connected {
+		F.KeyValue("  namespace", health.Temporal.Namespace)
+		F.KeyValue("  taskQueue", health.Temporal.TaskQueue)
+	}
+	if health.Worker.Connected {
+		F.KeyValue("  workers", fmt.Sprint(health.Worker.Count))
+	}
+}
+
+// watchStatus polls /health until interrupted, redrawing in place (human)
+// or emitting one JSON object per poll (--json).
+func watchStatus(client *api.Client, interval time.Duration, thresholds latencyThresholds) error {
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var trend []time.Duration
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		start := time.Now()
+		health, err := client.Health(sigCtx)
+		latency := time.Since(start)
+		if sigCtx.Err() != nil {
+			return nil
+		}
+
+		if flagJSON {
+			obj := statusJSON(health, latency, err)
+			obj["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+			if encErr := enc.Encode(obj); encErr != nil {
+				return encErr
+			}
+		} else {
+			if err == nil {
+				trend = append(trend, latency)
+				if len(trend) > statusTrendSize {
+					trend = trend[len(trend)-statusTrendSize:]
+				}
 			}
-			if health.Worker.Connected {
-				F.KeyValue("  workers", fmt.Sprint(health.Worker.Count))
+			clearScreen()
+			if err != nil {
+				output.F.Section("RepoSwarm Status")
+				output.F.Error(fmt.Sprintf("Connection failed: %s", err))
+			} else {
+				renderStatus(health, latency, thresholds)
 			}
-			F.Println()
+			if len(trend) > 0 {
+				output.F.Println()
+				output.F.KeyValue("Latency trend", latencyTrend(trend))
+			}
+			output.F.Println()
+			output.F.Info(fmt.Sprintf("Updated %s — refreshing every %s (Ctrl+C to stop)", time.Now().Format("15:04:05"), interval))
+		}
+
+		select {
+		case <-sigCtx.Done():
 			return nil
-		},
+		case <-ticker.C:
+		}
+	}
+}
+
+// latencyTrend renders latency samples as a sparkline with min/avg/max.
+func latencyTrend(samples []time.Duration) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	bars := []rune("▁▂▃▄▅▆▇█")
+	lo, hi, sum := samples[0], samples[0], time.Duration(0)
+	for _, s := range samples {
+		lo = min(lo, s)
+		hi = max(hi, s)
+		sum += s
+	}
+
+	var sb strings.Builder
+	for _, s := range samples {
+		idx := 0
+		if hi > lo {
+			idx = int((s - lo) * time.Duration(len(bars)-1) / (hi - lo))
+		}
+		sb.WriteRune(bars[idx])
 	}
+	avg := sum / time.Duration(len(samples))
+	return fmt.Sprintf("%s  min %dms  avg %dms  max %dms", sb.String(), lo.Milliseconds(), avg.Milliseconds(), hi.Milliseconds())
 }
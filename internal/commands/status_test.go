@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+)
+
+func TestLatencyTrend(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    string
+	}{
+		{"empty", nil, ""},
+		{"flat", []time.Duration{10 * ms, 10 * ms}, "▁▁  min 10ms  avg 10ms  max 10ms"},
+		{"rising", []time.Duration{10 * ms, 45 * ms, 80 * ms}, "▁▄█  min 10ms  avg 45ms  max 80ms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latencyTrend(tt.samples); got != tt.want {
+				t.Errorf("latencyTrend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusJSONError(t *testing.T) {
+	obj := statusJSON(nil, 0, errors.New("boom"))
+	if obj["connected"] != false || !strings.Contains(obj["error"].(string), "boom") {
+		t.Errorf("statusJSON(err) = %v", obj)
+	}
+}
+
+func TestConnectionHint(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&api.APIError{StatusCode: 401}, "config init"},
+		{&api.APIError{StatusCode: 404, Path: "/health"}, "/v1"},
+		{fmt.Errorf("%w: dial tcp: refused", api.ErrConnectionFailed), "running"},
+		{&api.APIError{StatusCode: 500, Message: "boom"}, ""},
+	}
+	for _, tt := range tests {
+		got := connectionHint(tt.err)
+		if (tt.want == "" && got != "") || !strings.Contains(got, tt.want) {
+			t.Errorf("connectionHint(%v) = %q, want mention of %q", tt.err, got, tt.want)
+		}
+	}
+	if obj := statusJSON(nil, 0, &api.APIError{StatusCode: 401}); obj["hint"] == nil {
+		t.Errorf("statusJSON should include a hint for 401: %v", obj)
+	}
+}
+
+func TestParseLatencyThresholds(t *testing.T) {
+	got, err := parseLatencyThresholds("500ms, 2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Warn != 500*time.Millisecond || got.Slow != 2*time.Second {
+		t.Errorf("parseLatencyThresholds() = %+v", got)
+	}
+	for _, bad := range []string{"200ms", "1s,200ms", "fast,slow", "0s,1s"} {
+		if _, err := parseLatencyThresholds(bad); err == nil {
+			t.Errorf("parseLatencyThresholds(%q) should fail", bad)
+		}
+	}
+}
+
+func TestLatencyThresholdColor(t *testing.T) {
+	defer func(nc bool) { color.NoColor = nc }(color.NoColor)
+	color.NoColor = false
+
+	th := latencyThresholds{Warn: 200 * time.Millisecond, Slow: time.Second}
+	if got := th.color(50 * time.Millisecond); got != output.Green("50ms") {
+		t.Errorf("fast latency = %q, want green", got)
+	}
+	if got := th.color(500 * time.Millisecond); got != output.Yellow("500ms") {
+		t.Errorf("sluggish latency = %q, want yellow", got)
+	}
+	if got := th.color(3 * time.Second); got != output.Red("3000ms") {
+		t.Errorf("slow latency = %q, want red", got)
+	}
+}
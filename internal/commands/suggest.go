@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// withResultsSuggestions adds "did you mean" hints to a 404 from reading
+// results for repo (and section, if set), using the names the server knows.
+// Any other error, or a 404 with no close names, is returned unchanged.
+func withResultsSuggestions(client *api.Client, err error, repo, section string) error {
+	if !errors.Is(err, api.ErrNotFound) {
+		return err
+	}
+
+	var index api.WikiIndex
+	if section != "" {
+		if ierr := client.Get(ctx(), "/wiki/"+repo, &index); ierr == nil {
+			var names []string
+			for _, s := range index.Sections {
+				names = append(names, s.Name())
+			}
+			return suggestionError(err, "section", section, names)
+		} else if !errors.Is(ierr, api.ErrNotFound) {
+			return err
+		}
+	}
+
+	var list api.WikiReposResponse
+	if lerr := getWikiRepos(client, &list); lerr != nil {
+		return err
+	}
+	var names []string
+	for _, r := range list.Repos {
+		names = append(names, r.Name)
+	}
+	return suggestionError(err, "repo", repo, names)
+}
+
+func suggestionError(err error, kind, name string, candidates []string) error {
+	matches := closestMatches(name, candidates, 3)
+	if len(matches) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\n  💡 No %s %q. Did you mean: %s?", err, kind, name, strings.Join(matches, ", "))
+}
+
+// closestMatches returns up to max candidates that are close to target:
+// within a small edit distance, or containing it (useful for long section
+// IDs typed partially). Closest first.
+func closestMatches(target string, candidates []string, max int) []string {
+	t := strings.ToLower(target)
+	limit := len(t) / 3
+	if limit < 2 {
+		limit = 2
+	}
+
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	for _, c := range candidates {
+		lc := strings.ToLower(c)
+		d := levenshtein(t, lc)
+		if d > limit && (t == "" || !strings.Contains(lc, t)) {
+			continue
+		}
+		matches = append(matches, match{c, d})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < max; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// levenshtein returns the edit distance between a and b (in runes).
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// completeResults returns a shell completion func for commands taking
+// repoArgs repo names followed by a section of the first repo.
+func completeResults(repoArgs int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		client, err := getClient()
+		if err != nil || len(args) > repoArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		if len(args) < repoArgs {
+			var list api.WikiReposResponse
+			if getWikiRepos(client, &list) == nil {
+				for _, r := range list.Repos {
+					names = append(names, r.Name)
+				}
+			}
+		} else {
+			var index api.WikiIndex
+			if client.Get(ctx(), "/wiki/"+args[0], &index) == nil {
+				for _, s := range index.Sections {
+					names = append(names, s.Name())
+				}
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"is-odd", "is-odd", 0},
+		{"is-od", "is-odd", 1},
+		{"kitten", "sitting", 3},
+		{"séction", "section", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"is-odd", "is-even", "meshmart-catalog", "hl_overview", "module_deep_dive"}
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"is-od", []string{"is-odd"}},
+		{"IS-ODD", []string{"is-odd"}},
+		{"catalog", []string{"meshmart-catalog"}},
+		{"hl_overveiw", []string{"hl_overview"}},
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		if got := closestMatches(tt.target, candidates, 3); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closestMatches(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestResultsReadSuggestions(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{{"name": "is-odd"}, {"name": "meshmart-catalog"}},
+		},
+		"GET /wiki/is-odd": map[string]any{
+			"repo":     "is-odd",
+			"sections": []map[string]any{{"id": "hl_overview"}, {"id": "module_deep_dive"}},
+		},
+	})
+	defer cleanup()
+
+	_, err := runCmd(t, "results", "read", "is-od")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean: is-odd?") {
+		t.Errorf("repo typo: err = %v", err)
+	}
+	if !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("suggestion error should still match ErrNotFound: %v", err)
+	}
+
+	_, err = runCmd(t, "results", "read", "is-odd", "hl_overveiw")
+	if err == nil || !strings.Contains(err.Error(), `No section "hl_overveiw". Did you mean: hl_overview?`) {
+		t.Errorf("section typo: err = %v", err)
+	}
+
+	_, err = runCmd(t, "results", "read", "nothing-like-it")
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("no close match: err = %v", err)
+	}
+}
+
+func TestCompleteResults(t *testing.T) {
+	_, cleanup := testServer(t, map[string]any{
+		"GET /wiki": map[string]any{
+			"repos": []map[string]any{{"name": "is-odd"}, {"name": "meshmart-catalog"}},
+		},
+		"GET /wiki/is-odd": map[string]any{
+			"repo":     "is-odd",
+			"sections": []map[string]any{{"id": "hl_overview"}},
+		},
+	})
+	defer cleanup()
+
+	complete := completeResults(1)
+	repos, _ := complete(nil, nil, "")
+	if !reflect.DeepEqual(repos, []string{"is-odd", "meshmart-catalog"}) {
+		t.Errorf("repo completions = %v", repos)
+	}
+	sections, _ := complete(nil, []string{"is-odd"}, "")
+	if !reflect.DeepEqual(sections, []string{"hl_overview"}) {
+		t.Errorf("section completions = %v", sections)
+	}
+	if extra, _ := complete(nil, []string{"is-odd", "hl_overview"}, ""); extra != nil {
+		t.Errorf("no completions expected past the section, got %v", extra)
+	}
+
+	// The agent hint must not end up in the shell's completion output
+	out, err := runCmd(t, "__complete", "results", "read", "")
+	if err != nil || strings.Contains(out, "--for-agent") {
+		t.Errorf("__complete stdout = %q, err = %v", out, err)
+	}
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// fleetSummary is the combined view printed by `summary`. Each part carries
+// its own error so one failing endpoint doesn't hide the others.
+type fleetSummary struct {
+	API struct {
+		Connected bool   `json:"connected"`
+		Status    string `json:"status,omitempty"`
+		Version   string `json:"version,omitempty"`
+		LatencyMs int64  `json:"latencyMs"`
+		Error     string `json:"error,omitempty"`
+	} `json:"api"`
+	Repos struct {
+		Tracked int    `json:"tracked"`
+		Enabled int    `json:"enabled"`
+		Error   string `json:"error,omitempty"`
+	} `json:"repos"`
+	Results struct {
+		Repos int    `json:"repos"`
+		Error string `json:"error,omitempty"`
+	} `json:"results"`
+	Workflows struct {
+		Running   int    `json:"running"`
+		Failed    int    `json:"failed"`
+		Completed int    `json:"completed"`
+		Recent    int    `json:"recent"`
+		Error     string `json:"error,omitempty"`
+	} `json:"workflows"`
+}
+
+func newSummaryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "One-screen overview of API health, repos, results and workflows",
+		Long: `Answer "how's everything?" in one screen: API health and latency, tracked and
+enabled repos, repos with results, and running/failed workflows among the
+most recent 100.
+
+/health, /repos, /wiki and /workflows are queried in parallel. A failing
+endpoint is reported in its own row (or "error" field with --json) without
+hiding the rest.
+
+Examples:
+  reposwarm summary
+  reposwarm summary --json`,
+		Args: friendlyMaxArgs(0, "reposwarm summary"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			status := startStatus("Gathering summary...")
+			sum := gatherFleetSummary(client)
+			status.Stop()
+
+			if flagJSON {
+				return output.JSON(sum)
+			}
+			renderFleetSummary(sum)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// gatherFleetSummary queries the four endpoints concurrently.
+func gatherFleetSummary(client *api.Client) *fleetSummary {
+	sum := &fleetSummary{}
+	var wg sync.WaitGroup
+	wg.Add(4)
+
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		health, err := client.Health(ctx())
+		sum.API.LatencyMs = time.Since(start).Milliseconds()
+		if err != nil {
+			sum.API.Error = err.Error()
+			return
+		}
+		sum.API.Connected = true
+		sum.API.Status = health.Status
+		sum.API.Version = health.Version
+	}()
+
+	go func() {
+		defer wg.Done()
+		var repos []api.Repository
+		if err := client.Get(ctx(), "/repos", &repos); err != nil {
+			sum.Repos.Error = err.Error()
+			return
+		}
+		sum.Repos.Tracked = len(repos)
+		for _, r := range repos {
+			if r.Enabled {
+				sum.Repos.Enabled++
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		var wiki api.WikiReposResponse
+		if err := getWikiRepos(client, &wiki); err != nil {
+			sum.Results.Error = err.Error()
+			return
+		}
+		sum.Results.Repos = len(wiki.Repos)
+	}()
+
+	go func() {
+		defer wg.Done()
+		var wf api.WorkflowsResponse
+		if err := client.Get(ctx(), "/workflows?pageSize=100", &wf); err != nil {
+			sum.Workflows.Error = err.Error()
+			return
+		}
+		sum.Workflows.Recent = len(wf.Executions)
+		for _, w := range wf.Executions {
+			switch strings.ToLower(w.Status) {
+			case "running":
+				sum.Workflows.Running++
+			case "failed", "timed_out", "timedout":
+				sum.Workflows.Failed++
+			case "completed":
+				sum.Workflows.Completed++
+			}
+		}
+	}()
+
+	wg.Wait()
+	return sum
+}
+
+func renderFleetSummary(sum *fleetSummary) {
+	F := output.F
+	F.Section("RepoSwarm Summary")
+
+	if sum.API.Error != "" {
+		F.KeyValue("API", output.Red("unreachable: "+sum.API.Error))
+	} else {
+		F.KeyValue("API", fmt.Sprintf("%s, %s (%dms)", sum.API.Status, sum.API.Version, sum.API.LatencyMs))
+	}
+
+	if sum.Repos.Error != "" {
+		F.KeyValue("Repos", output.Red(sum.Repos.Error))
+	} else {
+		F.KeyValue("Repos", fmt.Sprintf("%d tracked, %d enabled", sum.Repos.Tracked, sum.Repos.Enabled))
+	}
+
+	if sum.Results.Error != "" {
+		F.KeyValue("Results", output.Red(sum.Results.Error))
+	} else {
+		F.KeyValue("Results", fmt.Sprintf("%d repos with results", sum.Results.Repos))
+	}
+
+	if sum.Workflows.Error != "" {
+		F.KeyValue("Workflows", output.Red(sum.Workflows.Error))
+	} else {
+		failed := fmt.Sprintf("%d failed", sum.Workflows.Failed)
+		if sum.Workflows.Failed > 0 {
+			failed = output.Red(failed)
+		}
+		F.KeyValue("Workflows", fmt.Sprintf("%d running, %s, %d completed (last %d)",
+			sum.Workflows.Running, failed, sum.Workflows.Completed, sum.Workflows.Recent))
+	}
+	F.Println()
+}
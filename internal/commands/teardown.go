@@ -92,6 +92,9 @@ Examples:
 
 			// Confirm unless --force
 			if !forceFlag && !flagAgent {
+				if !output.StdinIsTerminal() {
+					return fmt.Errorf("stdin is not a terminal: pass --force to confirm teardown")
+				}
 				reader := bufio.NewReader(os.Stdin)
 				prompt := "  Continue? [y/N]: "
 				if removeVolumes {
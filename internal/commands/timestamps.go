@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// timestampLayouts are the formats the API has been seen to return, tried in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTimestamp parses an API timestamp in any of the known layouts.
+func parseTimestamp(s string) (time.Time, bool) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// timeDisplay controls how timestamp columns are rendered in human/agent
+// tables. --json always keeps the raw API value.
+type timeDisplay struct {
+	age   bool
+	utc   bool
+	local bool
+}
+
+// addTimeDisplayFlags adds --age, --utc and --local to cmd.
+func addTimeDisplayFlags(cmd *cobra.Command, td *timeDisplay) {
+	cmd.Flags().BoolVar(&td.age, "age", false, "Show times as relative ages (e.g. 3h ago)")
+	cmd.Flags().BoolVar(&td.utc, "utc", false, "Show times as absolute UTC")
+	cmd.Flags().BoolVar(&td.local, "local", false, "Show times as absolute local time")
+	cmd.MarkFlagsMutuallyExclusive("age", "utc", "local")
+}
+
+// format renders an API timestamp; values that don't parse are returned as-is.
+func (td timeDisplay) format(s string) string {
+	if !td.age && !td.utc && !td.local {
+		return s
+	}
+	t, ok := parseTimestamp(s)
+	if !ok {
+		return s
+	}
+	switch {
+	case td.age:
+		return formatTimeAgo(time.Since(t))
+	case td.utc:
+		return t.UTC().Format("2006-01-02 15:04:05 UTC")
+	default:
+		return t.Local().Format("2006-01-02 15:04:05 MST")
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	for _, s := range []string{
+		"2026-01-01T12:00:00Z",
+		"2026-01-01T12:00:00.123456Z",
+		"2026-01-01T12:00:00+02:00",
+		"2026-01-01 12:00:00",
+		"2026-01-01",
+	} {
+		if _, ok := parseTimestamp(s); !ok {
+			t.Errorf("parseTimestamp(%q) failed", s)
+		}
+	}
+	if _, ok := parseTimestamp("yesterday"); ok {
+		t.Error("parseTimestamp(yesterday) should fail")
+	}
+}
+
+func TestWorkflowsListAge(t *testing.T) {
+	start := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	_, cleanup := testServer(t, map[string]any{
+		"/workflows": map[string]any{
+			"executions": []map[string]any{
+				{"workflowId": "investigate-single-repo-left-pad", "status": "Running", "type": "InvestigateSingleRepoWorkflow", "startTime": start},
+			},
+		},
+	})
+	defer cleanup()
+
+	out, err := runCmd(t, "wf", "list", "--age", "--for-agent")
+	if err != nil {
+		t.Fatalf("wf list --age: %v", err)
+	}
+	if !strings.Contains(out, "3h ago") || strings.Contains(out, start) {
+		t.Errorf("expected relative age instead of %s:\n%s", start, out)
+	}
+
+	out, err = runCmd(t, "wf", "list", "--age", "--json")
+	if err != nil {
+		t.Fatalf("wf list --age --json: %v", err)
+	}
+	var wfs []map[string]any
+	if err := json.Unmarshal([]byte(out), &wfs); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(wfs) != 1 || wfs[0]["startTime"] != start {
+		t.Errorf("--json should keep the raw startTime, got %v", wfs)
+	}
+
+	if _, err := runCmd(t, "wf", "list", "--age", "--utc"); err == nil {
+		t.Error("expected --age and --utc to be mutually exclusive")
+	}
+}
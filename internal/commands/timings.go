@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// clientTimings, when set, is attached to every client made by getClient.
+var clientTimings *api.Timings
+
+// addTimingsFlag adds --timings to cmd. When set, the number of API requests
+// and the time spent on the network vs. everything else is printed to stderr
+// after the command finishes, even if it fails.
+func addTimingsFlag(cmd *cobra.Command) {
+	var enabled bool
+	cmd.Flags().BoolVar(&enabled, "timings", false, "Print request count and network vs. processing time to stderr")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !enabled {
+			return run(cmd, args)
+		}
+		clientTimings = &api.Timings{}
+		defer func() { clientTimings = nil }()
+
+		start := time.Now()
+		err := run(cmd, args)
+		fmt.Fprintf(os.Stderr, "timings: %s\n", clientTimings.Summary(time.Since(start)))
+		return err
+	}
+}
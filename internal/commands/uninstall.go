@@ -72,6 +72,9 @@ Examples:
 			fmt.Println()
 
 			if !forceFlag && !flagAgent {
+				if !output.StdinIsTerminal() {
+					return fmt.Errorf("stdin is not a terminal: pass --force to confirm uninstall")
+				}
 				reader := bufio.NewReader(os.Stdin)
 				fmt.Printf("  %s Type 'yes' to confirm: ", output.Red("⚠ This cannot be undone."))
 				answer, _ := reader.ReadString('\n')
@@ -126,11 +129,10 @@ Examples:
 
 			// 4. Remove config directory
 			if !keepConfigFlag {
-				home, _ := os.UserHomeDir()
-				configDir := filepath.Join(home, ".reposwarm")
-				if dirExists(configDir) {
-					fmt.Printf("  Removing %s... ", configDir)
-					if err := os.RemoveAll(configDir); err != nil {
+				configDir, _ := config.ConfigDir()
+				if configDir != "" && dirExists(configDir) {
+					fmt.Printf("  Removing config in %s... ", configDir)
+					if err := removeConfigFiles(configDir); err != nil {
 						fmt.Println(output.Yellow("failed"))
 						errors = append(errors, fmt.Sprintf("remove config: %v", err))
 					} else {
@@ -166,7 +168,8 @@ Examples:
 			}
 
 			if keepConfigFlag {
-				output.F.Info("Config preserved at ~/.reposwarm/ (use for reinstall)")
+				configDir, _ := config.ConfigDir()
+				output.F.Info(fmt.Sprintf("Config preserved at %s (use for reinstall)", configDir))
 			}
 
 			return nil
@@ -174,7 +177,7 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&forceFlag, "force", false, "Skip confirmation prompt")
-	cmd.Flags().BoolVar(&keepConfigFlag, "keep-config", false, "Keep ~/.reposwarm config directory")
+	cmd.Flags().BoolVar(&keepConfigFlag, "keep-config", false, "Keep the config directory (see 'reposwarm config path')")
 	return cmd
 }
 
@@ -191,10 +194,9 @@ func buildRemovalList(installDir string, isLocal bool, keepConfig bool) []string
 	}
 
 	if !keepConfig {
-		home, _ := os.UserHomeDir()
-		configDir := filepath.Join(home, ".reposwarm")
-		if dirExists(configDir) {
-			items = append(items, fmt.Sprintf("Config: %s (config.json, providers.json)", configDir))
+		configDir, _ := config.ConfigDir()
+		if configDir != "" && dirExists(configDir) {
+			items = append(items, fmt.Sprintf("Config: %s (config.json, providers.json, cache/; other files are kept)", configDir))
 		}
 	}
 
@@ -207,6 +209,25 @@ func buildRemovalList(installDir string, isLocal bool, keepConfig bool) []string
 	return items
 }
 
+// configOwnedEntries are the files and directories the CLI creates in the
+// config directory. Only these are removed: REPOSWARM_CONFIG_HOME or
+// XDG_CONFIG_HOME may point at a directory shared with other tools.
+var configOwnedEntries = []string{"config.json", "providers.json", "cache"}
+
+// removeConfigFiles deletes the CLI's own entries from dir, then dir itself
+// if that left it empty.
+func removeConfigFiles(dir string) error {
+	for _, name := range configOwnedEntries {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		return os.Remove(dir)
+	}
+	return nil
+}
+
 func stopAllServices(installDir string) []string {
 	var errors []string
 	services := []struct {
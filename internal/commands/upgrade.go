@@ -18,7 +18,7 @@ import (
 )
 
 func newUpgradeCmd(currentVersion string) *cobra.Command {
-	var force bool
+	var force, rollback bool
 
 	cmd := &cobra.Command{
 		Use:     "upgrade [component]",
@@ -44,8 +44,13 @@ Examples:
   reposwarm upgrade cli       # Upgrade CLI binary
   reposwarm upgrade api       # Pull latest API image + restart
   reposwarm upgrade all       # Upgrade CLI + all Docker services
-  reposwarm upgrade --force   # Force pull even if up to date`,
+  reposwarm upgrade --force   # Force pull even if up to date
+  reposwarm upgrade --rollback  # Restore the CLI binary replaced by the last upgrade`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if rollback {
+				return rollbackCLI()
+			}
+
 			component := "cli"
 			if len(args) > 0 {
 				component = args[0]
@@ -83,6 +88,7 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Force pull even if up to date")
+	cmd.Flags().BoolVar(&rollback, "rollback", false, "Restore the CLI binary kept from the previous upgrade")
 	return cmd
 }
 
@@ -127,13 +133,9 @@ func upgradeCLI(currentVersion string, force bool) error {
 	defer os.Remove(tmpFile)
 	fmt.Printf(" done\n")
 
-	binPath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("finding current binary: %w", err)
-	}
-	binPath, err = filepath.EvalSymlinks(binPath)
+	binPath, err := currentBinaryPath()
 	if err != nil {
-		return fmt.Errorf("resolving binary path: %w", err)
+		return err
 	}
 
 	fmt.Printf("  Installing to %s...", binPath)
@@ -143,6 +145,10 @@ func upgradeCLI(currentVersion string, force bool) error {
 	fmt.Printf(" done\n\n")
 
 	output.F.Success(fmt.Sprintf("reposwarm v%s installed — restart your shell or run 'reposwarm version' to verify", latestVer))
+	if oldPath := backupBinaryPath(binPath); fileExists(oldPath) {
+		output.Infof("Previous binary kept at %s", oldPath)
+		output.Infof("To revert: reposwarm upgrade --rollback (or: mv %s %s)", oldPath, binPath)
+	}
 
 	changes, err := getChangelog(currentVersion, latestVer)
 	if err == nil && len(changes) > 0 {
@@ -347,8 +353,15 @@ type ghRelease struct {
 }
 
 func getLatestRelease() (version, downloadURL string, err error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(config.CLIReleasesAPI + "/latest")
+	client, err := newHTTPClient(10 * time.Second)
+	if err != nil {
+		return "", "", err
+	}
+	req, err := newGetRequest(config.CLIReleasesAPI + "/latest")
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", "", err
 	}
@@ -381,10 +394,17 @@ func getLatestRelease() (version, downloadURL string, err error) {
 // getChangelog fetches release notes between the old and new version from GitHub.
 // Returns one-liner changes (commit messages) or release body lines.
 func getChangelog(oldVersion, newVersion string) ([]string, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
+	client, err := newHTTPClient(10 * time.Second)
+	if err != nil {
+		return nil, err
+	}
 
 	// Fetch the new release body — it contains the changelog
-	resp, err := client.Get(fmt.Sprintf("%s/tags/v%s", config.CLIReleasesAPI, newVersion))
+	req, err := newGetRequest(fmt.Sprintf("%s/tags/v%s", config.CLIReleasesAPI, newVersion))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -471,9 +491,26 @@ func findSubstring(s, sub string) bool {
 	return false
 }
 
+// newGetRequest builds a GET request carrying the CLI User-Agent.
+func newGetRequest(url string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent())
+	return req, nil
+}
+
 func downloadBinary(url string) (string, error) {
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Get(url)
+	client, err := newHTTPClient(60 * time.Second)
+	if err != nil {
+		return "", err
+	}
+	req, err := newGetRequest(url)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -509,24 +546,47 @@ func isLocalInstall(cfg *config.Config) bool {
 	return strings.Contains(url, "localhost") || strings.Contains(url, "127.0.0.1")
 }
 
+// currentBinaryPath returns the resolved path of the running reposwarm binary.
+func currentBinaryPath() (string, error) {
+	binPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding current binary: %w", err)
+	}
+	binPath, err = filepath.EvalSymlinks(binPath)
+	if err != nil {
+		return "", fmt.Errorf("resolving binary path: %w", err)
+	}
+	return binPath, nil
+}
+
+// backupBinaryPath returns where the previous binary is kept after an upgrade.
+func backupBinaryPath(binPath string) string {
+	return filepath.Join(filepath.Dir(binPath), "."+filepath.Base(binPath)+".old")
+}
+
 // safeReplaceBinary replaces the binary without corrupting the running process.
 // On macOS/Linux, a running binary can be renamed but not overwritten safely.
-// Strategy: rename old → write new → delete old.
+// Strategy: rename old → write new. The old binary is kept next to the new one
+// (see backupBinaryPath) so 'upgrade --rollback' can restore it; it is only
+// replaced by the next successful upgrade.
 func safeReplaceBinary(src, dst string) error {
 	newData, err := os.ReadFile(src)
 	if err != nil {
 		return err
 	}
 
-	dir := filepath.Dir(dst)
-	base := filepath.Base(dst)
-	oldPath := filepath.Join(dir, "."+base+".old")
+	oldPath := backupBinaryPath(dst)
+	prevPath := oldPath + ".prev"
 
-	// Remove any leftover from previous upgrade
-	os.Remove(oldPath)
+	// Keep the backup from the previous upgrade until this one succeeds
+	os.Remove(prevPath)
+	hadPrev := os.Rename(oldPath, prevPath) == nil
 
 	// Rename running binary out of the way (safe on macOS/Linux)
 	if err := os.Rename(dst, oldPath); err != nil {
+		if hadPrev {
+			os.Rename(prevPath, oldPath)
+		}
 		// Can't rename — try direct write as last resort
 		if err := os.WriteFile(dst, newData, 0755); err != nil {
 			return fmt.Errorf("cannot replace %s (try: sudo reposwarm upgrade): %w", dst, err)
@@ -538,14 +598,59 @@ func safeReplaceBinary(src, dst string) error {
 	if err := os.WriteFile(dst, newData, 0755); err != nil {
 		// Rollback
 		os.Rename(oldPath, dst)
+		if hadPrev {
+			os.Rename(prevPath, oldPath)
+		}
 		return fmt.Errorf("failed to write new binary: %w", err)
 	}
 
-	// Clean up old binary (best effort — may fail if still running, that's fine)
-	go func() {
-		time.Sleep(2 * time.Second)
-		os.Remove(oldPath)
-	}()
+	os.Remove(prevPath)
+	return nil
+}
+
+// rollbackCLI swaps the current binary with the one kept by the last upgrade.
+// Running it twice returns to the upgraded binary.
+func rollbackCLI() error {
+	binPath, err := currentBinaryPath()
+	if err != nil {
+		return err
+	}
+	oldPath := backupBinaryPath(binPath)
+	if err := swapBinaries(binPath, oldPath); err != nil {
+		return err
+	}
+
+	if flagJSON {
+		return output.JSON(map[string]any{
+			"action":   "rollback",
+			"restored": binPath,
+			"backup":   oldPath,
+		})
+	}
+
+	output.F.Success(fmt.Sprintf("Restored previous binary to %s", binPath))
+	output.Infof("The replaced binary is now at %s (run 'reposwarm upgrade --rollback' again to undo)", oldPath)
+	output.Infof("Run 'reposwarm version' to verify")
+	return nil
+}
+
+// swapBinaries exchanges the files at binPath and oldPath.
+func swapBinaries(binPath, oldPath string) error {
+	if !fileExists(oldPath) {
+		return fmt.Errorf("no previous binary found at %s — nothing to roll back", oldPath)
+	}
 
+	swapPath := oldPath + ".swap"
+	os.Remove(swapPath)
+	if err := os.Rename(binPath, swapPath); err != nil {
+		return fmt.Errorf("cannot move %s aside (try: sudo reposwarm upgrade --rollback): %w", binPath, err)
+	}
+	if err := os.Rename(oldPath, binPath); err != nil {
+		os.Rename(swapPath, binPath)
+		return fmt.Errorf("restoring %s: %w", oldPath, err)
+	}
+	if err := os.Rename(swapPath, oldPath); err != nil {
+		return fmt.Errorf("keeping replaced binary at %s: %w", oldPath, err)
+	}
 	return nil
 }
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeReplaceBinaryKeepsBackup(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "reposwarm")
+	src := filepath.Join(dir, "download")
+	os.WriteFile(bin, []byte("v1"), 0755)
+	os.WriteFile(src, []byte("v2"), 0755)
+
+	if err := safeReplaceBinary(src, bin); err != nil {
+		t.Fatalf("safeReplaceBinary: %v", err)
+	}
+
+	if got, _ := os.ReadFile(bin); string(got) != "v2" {
+		t.Errorf("binary = %q, want v2", got)
+	}
+	if got, _ := os.ReadFile(backupBinaryPath(bin)); string(got) != "v1" {
+		t.Errorf("backup = %q, want v1", got)
+	}
+
+	// A second upgrade replaces the backup with the binary it supersedes
+	os.WriteFile(src, []byte("v3"), 0755)
+	if err := safeReplaceBinary(src, bin); err != nil {
+		t.Fatalf("second safeReplaceBinary: %v", err)
+	}
+	if got, _ := os.ReadFile(backupBinaryPath(bin)); string(got) != "v2" {
+		t.Errorf("backup after second upgrade = %q, want v2", got)
+	}
+	if fileExists(backupBinaryPath(bin) + ".prev") {
+		t.Error("intermediate .prev backup should be cleaned up")
+	}
+}
+
+func TestSwapBinaries(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "reposwarm")
+	old := backupBinaryPath(bin)
+
+	if err := swapBinaries(bin, old); err == nil {
+		t.Fatal("expected error when no backup exists")
+	}
+
+	os.WriteFile(bin, []byte("new"), 0755)
+	os.WriteFile(old, []byte("old"), 0755)
+
+	if err := swapBinaries(bin, old); err != nil {
+		t.Fatalf("swapBinaries: %v", err)
+	}
+	if got, _ := os.ReadFile(bin); string(got) != "old" {
+		t.Errorf("binary = %q, want old", got)
+	}
+	if got, _ := os.ReadFile(old); string(got) != "new" {
+		t.Errorf("backup = %q, want new", got)
+	}
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"net/url"
 
+	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
@@ -69,7 +71,7 @@ func getServiceURL(cfg *config.Config, service string) (string, error) {
 	case "temporal-grpc":
 		return fmt.Sprintf("localhost:%s", cfg.EffectiveTemporalPort()), nil
 	case "ui":
-		return fmt.Sprintf("http://localhost:%s", cfg.EffectiveUIPort()), nil
+		return cfg.EffectiveUIURL(), nil
 	case "api":
 		// Use configured API URL if set, otherwise construct from port
 		if cfg.APIUrl != "" {
@@ -83,6 +85,21 @@ func getServiceURL(cfg *config.Config, service string) (string, error) {
 	}
 }
 
+// temporalWorkflowURL returns the Temporal UI page for a workflow execution.
+// The namespace falls back to "default" and the run ID is omitted when unknown.
+func temporalWorkflowURL(cfg *config.Config, wf api.WorkflowExecution) string {
+	ns := wf.Namespace
+	if ns == "" {
+		ns = "default"
+	}
+	u := fmt.Sprintf("http://localhost:%s/namespaces/%s/workflows/%s",
+		cfg.EffectiveTemporalUIPort(), url.PathEscape(ns), url.PathEscape(wf.WorkflowID))
+	if wf.RunID != "" {
+		u += "/" + url.PathEscape(wf.RunID)
+	}
+	return u
+}
+
 // printAllURLs prints all service URLs.
 func printAllURLs(cfg *config.Config) error {
 	services := []struct {
@@ -91,7 +108,7 @@ func printAllURLs(cfg *config.Config) error {
 	}{
 		{"temporal", fmt.Sprintf("http://localhost:%s", cfg.EffectiveTemporalUIPort())},
 		{"temporal-grpc", fmt.Sprintf("localhost:%s", cfg.EffectiveTemporalPort())},
-		{"ui", fmt.Sprintf("http://localhost:%s", cfg.EffectiveUIPort())},
+		{"ui", cfg.EffectiveUIURL()},
 		{"api", cfg.APIUrl},
 		{"hub", cfg.EffectiveHubURL()},
 	}
@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func newWatchCmd() *cobra.Command {
 	var interval int
+	var openUI, printURL bool
 
 	cmd := &cobra.Command{
 		Use:   "watch [workflow-id]",
@@ -24,14 +26,30 @@ With workflow-id: watches a specific workflow until it finishes.
 Examples:
   reposwarm workflows watch                              # All running
   reposwarm workflows watch investigate-single-my-repo   # Specific workflow
-  reposwarm workflows watch --interval 10                # Poll every 10s`,
+  reposwarm workflows watch --interval 10                # Poll every 10s
+  reposwarm workflows watch my-workflow --open           # Also open it in the Temporal UI`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient()
 			if err != nil {
 				return err
 			}
 
+			if (openUI || printURL) && len(args) == 0 {
+				return fmt.Errorf("--open/--print require a workflow-id")
+			}
+
 			if len(args) > 0 {
+				if openUI || printURL {
+					cfg, err := config.Load()
+					if err != nil {
+						return err
+					}
+					var wf api.WorkflowExecution
+					if err := client.Get(ctx(), "/workflows/"+args[0], &wf); err != nil {
+						return err
+					}
+					openOrPrint(temporalWorkflowURL(cfg, wf), printURL, "workflow in Temporal UI")
+				}
 				return watchSingle(client, args[0], interval)
 			}
 			return watchAll(client, interval)
@@ -39,6 +57,8 @@ Examples:
 	}
 
 	cmd.Flags().IntVar(&interval, "interval", 5, "Poll interval in seconds")
+	cmd.Flags().BoolVar(&openUI, "open", false, "Open the workflow in the Temporal UI before watching")
+	cmd.Flags().BoolVar(&printURL, "print", false, "Print the Temporal UI URL before watching instead of opening it")
 	return cmd
 }
 
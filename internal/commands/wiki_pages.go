@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+)
+
+// fetchWikiRepos reads the /wiki repo list, following nextPageToken when all
+// is set. pageSize 0 leaves the page size to the server, and limit > 0 stops
+// once that many repos are read. more reports whether the server had repos
+// that weren't read.
+func fetchWikiRepos(client *api.Client, pageSize, limit int, all bool) (repos []api.WikiRepoSummary, more bool, err error) {
+	token := ""
+	for {
+		q := url.Values{}
+		if pageSize > 0 {
+			q.Set("pageSize", strconv.Itoa(pageSize))
+		}
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+		path := "/wiki"
+		if len(q) > 0 {
+			path += "?" + q.Encode()
+		}
+
+		var page api.WikiReposResponse
+		if err := client.Get(ctx(), path, &page); err != nil {
+			return nil, false, err
+		}
+		repos = append(repos, page.Repos...)
+		if limit > 0 && len(repos) >= limit {
+			more = len(repos) > limit || page.NextPageToken != ""
+			return repos[:limit], more, nil
+		}
+		// A repeated token would loop forever; treat it as the last page
+		if page.NextPageToken == "" || page.NextPageToken == token {
+			return repos, false, nil
+		}
+		if !all {
+			return repos, true, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+// getWikiRepos reads every page of /wiki into result, for commands that work
+// across the whole fleet and must not silently miss repos.
+func getWikiRepos(client *api.Client, result *api.WikiReposResponse) error {
+	repos, _, err := fetchWikiRepos(client, 0, 0, true)
+	if err != nil {
+		return err
+	}
+	result.Repos = repos
+	return nil
+}
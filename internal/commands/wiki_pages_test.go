@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// pagedWikiServer serves /wiki two repos per page across three pages.
+func pagedWikiServer(t *testing.T) func() {
+	t.Helper()
+	server, cleanup := testServer(t, nil)
+	pages := map[string]map[string]any{
+		"":   {"repos": []map[string]any{{"name": "is-odd"}, {"name": "is-even"}}, "nextPageToken": "p2"},
+		"p2": {"repos": []map[string]any{{"name": "left-pad"}, {"name": "meshmart"}}, "nextPageToken": "p3"},
+		"p3": {"repos": []map[string]any{{"name": "zebra"}}},
+	}
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if r.URL.Path != "/wiki" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": page})
+	})
+	return cleanup
+}
+
+func TestResultsListPagination(t *testing.T) {
+	cleanup := pagedWikiServer(t)
+	defer cleanup()
+
+	count := func(args ...string) int {
+		t.Helper()
+		out, err := runCmd(t, append([]string{"results", "list", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("results list %v: %v", args, err)
+		}
+		var repos []map[string]any
+		if err := json.Unmarshal([]byte(out), &repos); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		return len(repos)
+	}
+
+	if n := count(); n != 2 {
+		t.Errorf("first page only: got %d repos, want 2", n)
+	}
+	if n := count("--all"); n != 5 {
+		t.Errorf("--all: got %d repos, want 5", n)
+	}
+	if n := count("--limit", "3"); n != 3 {
+		t.Errorf("--limit 3: got %d repos, want 3", n)
+	}
+}
+
+func TestFetchWikiReposAllPages(t *testing.T) {
+	cleanup := pagedWikiServer(t)
+	defer cleanup()
+
+	client, err := getClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos, more, err := fetchWikiRepos(client, 2, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 5 || more {
+		t.Errorf("got %d repos (more=%v), want all 5", len(repos), more)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/config"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,7 @@ func newWorkflowsCmd() *cobra.Command {
 	cmd.AddCommand(newWorkflowsListCmd())
 	cmd.AddCommand(newWorkflowsStatusCmd())
 	cmd.AddCommand(newWorkflowsHistoryCmd())
+	cmd.AddCommand(newWorkflowsDescribeCmd())
 	cmd.AddCommand(newWorkflowsTerminateCmd())
 	cmd.AddCommand(newWorkflowsWatchRepoCmd())
 	cmd.AddCommand(newWatchCmd())
@@ -30,6 +32,8 @@ func newWorkflowsCmd() *cobra.Command {
 
 func newWorkflowsListCmd() *cobra.Command {
 	var limit int
+	var filters []string
+	var times timeDisplay
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -40,14 +44,22 @@ func newWorkflowsListCmd() *cobra.Command {
 				return err
 			}
 
+			byField, err := parseFieldFilters(filters)
+			if err != nil {
+				return err
+			}
+
 			var result api.WorkflowsResponse
 			path := fmt.Sprintf("/workflows?pageSize=%d", limit)
 			if err := client.Get(ctx(), path, &result); err != nil {
 				return err
 			}
+			if result.Executions, err = filterByFields(result.Executions, byField); err != nil {
+				return err
+			}
 
 			if flagJSON {
-				return output.JSON(result.Executions)
+				return outputList(result.Executions)
 			}
 
 			F := output.F
@@ -63,7 +75,7 @@ func newWorkflowsListCmd() *cobra.Command {
 					wfID,
 					F.StatusText(w.Status),
 					w.Type,
-					w.StartTime,
+					times.format(w.StartTime),
 				})
 			}
 			F.Table(headers, rows)
@@ -73,11 +85,14 @@ func newWorkflowsListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Max workflows to show")
+	addFieldFilterFlag(cmd, &filters)
+	addTimeDisplayFlags(cmd, &times)
 	return cmd
 }
 
 func newWorkflowsStatusCmd() *cobra.Command {
 	var verbose bool
+	var openUI, printURL bool
 
 	cmd := &cobra.Command{
 		Use:   "status <workflow-id>",
@@ -94,6 +109,22 @@ func newWorkflowsStatusCmd() *cobra.Command {
 				return err
 			}
 
+			if openUI || printURL {
+				cfg, err := config.Load()
+				if err != nil {
+					return err
+				}
+				uiURL := temporalWorkflowURL(cfg, wf)
+				if flagJSON {
+					return output.JSON(struct {
+						api.WorkflowExecution
+						TemporalUIURL string `json:"temporalUiUrl"`
+					}{wf, uiURL})
+				}
+				openOrPrint(uiURL, printURL, "workflow in Temporal UI")
+				return nil
+			}
+
 			if flagJSON {
 				return output.JSON(wf)
 			}
@@ -173,6 +204,8 @@ func newWorkflowsStatusCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show activity details from workflow history")
+	cmd.Flags().BoolVar(&openUI, "open", false, "Open the workflow in the Temporal UI")
+	cmd.Flags().BoolVar(&printURL, "print", false, "Print the Temporal UI URL instead of opening it")
 	return cmd
 }
 
@@ -183,13 +216,19 @@ func newWorkflowsTerminateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "terminate <workflow-id>",
 		Short: "Terminate a running workflow",
-		Args:  friendlyExactArgs(1, "reposwarm workflows terminate <workflow-id>\n\nExample:\n  reposwarm workflows terminate wf-12345"),
+		Long: `Hard-stop a running workflow. The current activity is abandoned and no
+cleanup runs. Prefer 'reposwarm workflows cancel' for a graceful stop.
+
+Examples:
+  reposwarm workflows terminate wf-12345 --reason "stuck" -y`,
+		Args: friendlyExactArgs(1, "reposwarm workflows terminate <workflow-id>\n\nExample:\n  reposwarm workflows terminate wf-12345"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !yes {
-				fmt.Printf("  Terminate workflow %s? [y/N] ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm(fmt.Sprintf("Terminate workflow %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.F.Info("Cancelled")
 					return nil
 				}
@@ -1,33 +1,42 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
-	"strings"
 
+	"github.com/reposwarm/reposwarm-cli/internal/api"
 	"github.com/reposwarm/reposwarm-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func newWorkflowsCancelCmd() *cobra.Command {
 	var yes bool
+	var reason string
 
 	cmd := &cobra.Command{
 		Use:   "cancel <workflow-id>",
 		Short: "Request graceful cancellation of a workflow",
 		Long: `Send a cancellation signal to a running workflow.
-Unlike terminate, cancel allows the current activity to complete before stopping.
+Unlike terminate, cancel allows the current activity to complete before stopping,
+so the workflow can run its cleanup. Use terminate when a workflow is stuck and
+must be stopped immediately.
+
+If the API server has no cancel endpoint, the workflow is terminated instead
+and a warning is shown.
 
 Examples:
-  reposwarm workflows cancel investigate-single-is-odd-1772470037390`,
+  reposwarm workflows cancel investigate-single-is-odd-1772470037390
+  reposwarm workflows cancel investigate-single-is-odd-1772470037390 --reason "wrong model" -y`,
 		Args: friendlyExactArgs(1, "reposwarm workflows cancel <workflow-id>\n\nExample:\n  reposwarm workflows cancel investigate-single-is-odd-123"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			workflowID := args[0]
 
 			if !yes && !flagJSON {
-				fmt.Printf("  Cancel workflow %s? (current activity will complete first) [y/N] ", workflowID)
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm(fmt.Sprintf("Cancel workflow %s? (current activity will complete first)", workflowID))
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.F.Info("Cancelled")
 					return nil
 				}
@@ -39,13 +48,26 @@ Examples:
 			}
 
 			// Send cancel signal
+			body := map[string]string{"reason": reason}
 			var result any
-			if err := client.Post(ctx(), "/workflows/"+workflowID+"/cancel", nil, &result); err != nil {
-				// If /cancel endpoint doesn't exist, fall back to terminate with reason
-				body := map[string]string{"reason": "Gracefully cancelled via CLI"}
+			if err := client.Post(ctx(), "/workflows/"+workflowID+"/cancel", body, &result); err != nil {
+				if !errors.Is(err, api.ErrNotFound) {
+					return fmt.Errorf("cancel failed: %w", err)
+				}
+				// Older API servers have no /cancel endpoint, fall back to terminate
 				if err2 := client.Post(ctx(), "/workflows/"+workflowID+"/terminate", body, &result); err2 != nil {
 					return fmt.Errorf("cancel failed: %w (terminate fallback also failed: %v)", err, err2)
 				}
+				if flagJSON {
+					return output.JSON(map[string]any{
+						"workflowId": workflowID,
+						"cancelled":  false,
+						"terminated": true,
+					})
+				}
+				output.F.Warning("API server does not support cancel; workflow was terminated instead")
+				output.F.Success(fmt.Sprintf("Terminated workflow %s", workflowID))
+				return nil
 			}
 
 			if flagJSON {
@@ -63,5 +85,6 @@ Examples:
 	}
 
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation")
+	cmd.Flags().StringVar(&reason, "reason", "Cancelled via CLI", "Cancellation reason")
 	return cmd
 }
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/reposwarm/reposwarm-cli/internal/api"
+	"github.com/reposwarm/reposwarm-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// timelineEntry is one line of a condensed workflow history.
+type timelineEntry struct {
+	Time     string `json:"time"`
+	Event    string `json:"event"`
+	Activity string `json:"activity,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Failed   bool   `json:"failed,omitempty"`
+}
+
+// describeHistory is the history half of `workflows describe --json`.
+type describeHistory struct {
+	*historySummary
+	Timeline []timelineEntry `json:"timeline"`
+}
+
+func newWorkflowsDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe <workflow-id>",
+		Short: "Show a workflow's status, progress and condensed history together",
+		Long: `Fetch a workflow's status and event history in one go and print a single
+view: metadata, elapsed time or duration, step progress for single-repo
+investigations, and a condensed timeline of workflow and activity events with
+failures highlighted.
+
+Use 'workflows history' for every raw event.
+
+Examples:
+  reposwarm workflows describe investigate-single-my-app-1700000000000
+  reposwarm wf describe investigate-single-my-app-1700000000000 --json`,
+		Args: friendlyExactArgs(1, "reposwarm workflows describe <workflow-id>\n\nExample:\n  reposwarm workflows describe investigate-single-my-app-1700000000000"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			var wf api.WorkflowExecution
+			if err := client.Get(ctx(), "/workflows/"+args[0], &wf); err != nil {
+				return err
+			}
+
+			path := fmt.Sprintf("/workflows/%s/history", args[0])
+			if wf.RunID != "" {
+				path += "?runId=" + wf.RunID
+			}
+			var response struct {
+				Events []map[string]any `json:"events"`
+			}
+			historyErr := client.Get(ctx(), path, &response)
+			summary := summarizeHistory(response.Events)
+			timeline := condenseHistory(response.Events)
+
+			// Step progress only means something for single-repo investigations
+			done, total := -1, len(investigationSteps)
+			if wf.Type == "InvestigateSingleRepoWorkflow" {
+				completed, _ := getCompletedSteps(client, repoName(wf.WorkflowID))
+				done = 0
+				for _, step := range investigationSteps {
+					if completed[step.ID] {
+						done++
+					}
+				}
+			}
+
+			if flagJSON {
+				out := map[string]any{
+					"execution": wf,
+					"duration":  duration(wf),
+				}
+				if historyErr == nil {
+					if timeline == nil {
+						timeline = []timelineEntry{}
+					}
+					out["history"] = describeHistory{summary, timeline}
+				} else {
+					out["historyError"] = historyErr.Error()
+				}
+				if done >= 0 {
+					out["progress"] = map[string]int{"completedSteps": done, "totalSteps": total}
+				}
+				return output.JSON(out)
+			}
+
+			F := output.F
+			F.Section("Workflow " + wf.WorkflowID)
+			F.KeyValue("Run ID", wf.RunID)
+			F.KeyValue("Type", wf.Type)
+			F.KeyValue("Status", F.StatusText(wf.Status))
+			F.KeyValue("Started", wf.StartTime)
+			if wf.CloseTime == "" {
+				F.KeyValue("Elapsed", duration(wf))
+			} else {
+				F.KeyValue("Closed", wf.CloseTime)
+				F.KeyValue("Duration", duration(wf))
+			}
+			if done >= 0 {
+				F.KeyValue("Steps", fmt.Sprintf("%d/%d", done, total))
+			}
+			if summary != nil {
+				F.KeyValue("History", fmt.Sprintf("%d events, last %s", summary.Events, summary.LastEvent))
+				if summary.FailedActivities > 0 {
+					F.KeyValue("Failed activities", output.Red(fmt.Sprint(summary.FailedActivities)))
+				}
+			}
+
+			F.Println()
+			F.Section("Timeline")
+			switch {
+			case historyErr != nil:
+				F.Warning(fmt.Sprintf("Could not fetch history: %v", historyErr))
+			case len(timeline) == 0:
+				F.Info("No workflow or activity events yet")
+			}
+			for _, e := range timeline {
+				line := fmt.Sprintf("  %s  %-26s", formatEventTime(e.Time), e.Event)
+				if e.Activity != "" {
+					line += " " + e.Activity
+				}
+				if e.Detail != "" {
+					line += "  " + e.Detail
+				}
+				if e.Failed {
+					line = output.Red(line)
+				}
+				F.Println(line)
+			}
+			F.Println()
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// condenseHistory keeps the events worth reading from a workflow history:
+// workflow start/close and activity outcomes, with activity durations and
+// failure messages. Workflow tasks, activity starts and timers are dropped.
+func condenseHistory(events []map[string]any) []timelineEntry {
+	var timeline []timelineEntry
+	scheduled := map[string]map[string]any{} // scheduled event ID -> event
+	activityNames := map[string]string{}     // scheduled event ID -> activity type
+
+	for _, event := range events {
+		rawType, _ := event["eventType"].(string)
+		eventType := normalizeEventType(rawType)
+		eventTime, _ := event["eventTime"].(string)
+		details, _ := event["details"].(map[string]any)
+		if details == nil {
+			details = event
+		}
+		entry := timelineEntry{Time: eventTime, Event: eventType}
+
+		switch eventType {
+		case "ActivityTaskScheduled":
+			id := eventIDString(event["eventId"])
+			scheduled[id] = event
+			activityNames[id] = activityTypeName(details)
+			continue
+
+		case "ActivityTaskCompleted", "ActivityTaskFailed", "ActivityTaskTimedOut":
+			sid := eventIDString(details["scheduledEventId"])
+			entry.Activity = activityNames[sid]
+			if entry.Activity == "" {
+				entry.Activity = activityTypeName(details)
+			}
+			switch eventType {
+			case "ActivityTaskCompleted":
+				if sched, ok := scheduled[sid]; ok {
+					schedTime, _ := sched["eventTime"].(string)
+					if d, ok := eventGap(schedTime, eventTime); ok {
+						entry.Detail = "took " + formatDuration(d)
+					}
+				}
+			case "ActivityTaskFailed":
+				entry.Failed = true
+				summary, _, _ := extractActivityFailure(details, nil)
+				entry.Detail = truncate(summary, 160)
+			case "ActivityTaskTimedOut":
+				entry.Failed = true
+				if timeoutType, ok := details["timeoutType"].(string); ok {
+					entry.Detail = "timeout: " + timeoutType
+				}
+			}
+
+		case "WorkflowExecutionStarted", "WorkflowExecutionCompleted", "WorkflowExecutionCanceled", "WorkflowExecutionContinuedAsNew":
+			// Kept as is
+
+		case "WorkflowExecutionFailed":
+			entry.Failed = true
+			summary, _ := extractWorkflowFailure(details)
+			entry.Detail = truncate(summary, 160)
+
+		case "WorkflowExecutionTimedOut":
+			entry.Failed = true
+
+		case "WorkflowExecutionTerminated":
+			entry.Detail, _ = details["reason"].(string)
+
+		default:
+			continue
+		}
+		timeline = append(timeline, entry)
+	}
+	return timeline
+}
+
+// eventIDString normalizes event IDs, which arrive as strings or numbers.
+func eventIDString(v any) string {
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%.0f", id)
+	}
+	return ""
+}
+
+// activityTypeName reads an activity type given either as a plain string or
+// as Temporal's {"name": ...} object.
+func activityTypeName(details map[string]any) string {
+	switch at := details["activityType"].(type) {
+	case string:
+		return at
+	case map[string]any:
+		name, _ := at["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// eventGap returns the time between two RFC 3339 event timestamps.
+func eventGap(from, to string) (time.Duration, bool) {
+	start, ok1 := parseTimestamp(from)
+	end, ok2 := parseTimestamp(to)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
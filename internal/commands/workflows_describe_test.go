@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCondenseHistory(t *testing.T) {
+	var events []map[string]any
+	json.Unmarshal([]byte(`[
+		{"eventId": "1", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED", "eventTime": "2026-03-02T10:00:00Z"},
+		{"eventId": "2", "eventType": "EVENT_TYPE_WORKFLOW_TASK_SCHEDULED", "eventTime": "2026-03-02T10:00:00Z"},
+		{"eventId": "5", "eventType": "EVENT_TYPE_ACTIVITY_TASK_SCHEDULED", "eventTime": "2026-03-02T10:00:01Z", "details": {"activityType": {"name": "clone_repo"}}},
+		{"eventId": "6", "eventType": "EVENT_TYPE_ACTIVITY_TASK_STARTED", "eventTime": "2026-03-02T10:00:02Z"},
+		{"eventId": "7", "eventType": "EVENT_TYPE_ACTIVITY_TASK_COMPLETED", "eventTime": "2026-03-02T10:01:31Z", "details": {"scheduledEventId": "5"}},
+		{"eventId": 9, "eventType": "ActivityTaskScheduled", "eventTime": "2026-03-02T10:01:32Z", "details": {"activityType": "analyze"}},
+		{"eventId": "10", "eventType": "EVENT_TYPE_ACTIVITY_TASK_FAILED", "eventTime": "2026-03-02T10:02:00Z", "details": {"scheduledEventId": 9, "failure": {"message": "rate limited"}}},
+		{"eventId": "11", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_FAILED", "eventTime": "2026-03-02T10:02:01Z", "details": {"failure": {"message": "activity failed"}}}
+	]`), &events)
+
+	timeline := condenseHistory(events)
+	if len(timeline) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(timeline), timeline)
+	}
+	if e := timeline[1]; e.Event != "ActivityTaskCompleted" || e.Activity != "clone_repo" || e.Detail != "took 1m30s" {
+		t.Errorf("completed entry = %+v", e)
+	}
+	if e := timeline[2]; !e.Failed || e.Activity != "analyze" || e.Detail != "rate limited" {
+		t.Errorf("failed activity entry = %+v", e)
+	}
+	if e := timeline[3]; !e.Failed || e.Detail != "activity failed" {
+		t.Errorf("workflow failure entry = %+v", e)
+	}
+}
@@ -74,7 +74,7 @@ Use --filter to search for specific event types (case-insensitive).`,
 
 			// JSON output
 			if flagJSON {
-				return output.JSON(events)
+				return outputList(events)
 			}
 
 			// Human-readable output
@@ -100,17 +100,7 @@ Use --filter to search for specific event types (case-insensitive).`,
 				eventTime, _ := event["eventTime"].(string)
 				rawEventType, _ := event["eventType"].(string)
 
-				// Normalize event type: strip EVENT_TYPE_ prefix, convert to readable form
-				eventType := rawEventType
-				eventType = strings.TrimPrefix(eventType, "EVENT_TYPE_")
-				// Convert SNAKE_CASE to PascalCase for readability
-				parts := strings.Split(eventType, "_")
-				for i, p := range parts {
-					if len(p) > 0 {
-						parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
-					}
-				}
-				eventType = strings.Join(parts, "")
+				eventType := normalizeEventType(rawEventType)
 
 				// Details may be nested or at top level
 				details, _ := event["details"].(map[string]any)
@@ -225,10 +215,27 @@ Use --filter to search for specific event types (case-insensitive).`,
 	cmd.Flags().StringVar(&runID, "run-id", "", "Optional Temporal run ID")
 	cmd.Flags().StringVar(&filter, "filter", "", "Filter events by type (case-insensitive substring match)")
 	cmd.Flags().IntVar(&limit, "limit", 50, "Max events to show (0 = unlimited)")
+	addOutputFileFlag(cmd)
 
 	return cmd
 }
 
+// normalizeEventType turns EVENT_TYPE_ACTIVITY_TASK_FAILED into
+// ActivityTaskFailed; already readable types are returned as is.
+func normalizeEventType(raw string) string {
+	eventType := strings.TrimPrefix(raw, "EVENT_TYPE_")
+	if !strings.Contains(eventType, "_") && strings.ToUpper(eventType) != eventType {
+		return eventType
+	}
+	parts := strings.Split(eventType, "_")
+	for i, p := range parts {
+		if len(p) > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		}
+	}
+	return strings.Join(parts, "")
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -130,10 +130,11 @@ Examples:
 
 			// Confirm
 			if !yes && !flagJSON {
-				fmt.Printf("  Prune %d workflow(s)? [y/N] ", len(candidates))
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm(fmt.Sprintf("Prune %d workflow(s)?", len(candidates)))
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.F.Info("Cancelled")
 					return nil
 				}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/reposwarm/reposwarm-cli/internal/api"
@@ -47,10 +46,11 @@ Equivalent to:
 			if !yes && !flagJSON {
 				fmt.Printf("  Retry investigation for '%s'?\n", repo)
 				fmt.Printf("  This will terminate workflow %s and start a new investigation.\n", workflowID)
-				fmt.Printf("  Continue? [y/N] ")
-				var confirm string
-				fmt.Scanln(&confirm)
-				if strings.ToLower(confirm) != "y" {
+				ok, err := output.Confirm("Continue?")
+				if err != nil {
+					return err
+				}
+				if !ok {
 					output.F.Info("Cancelled")
 					return nil
 				}
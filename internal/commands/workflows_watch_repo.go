@@ -43,20 +43,31 @@ func newWorkflowsWatchRepoCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "progress",
+		Use:   "progress [repo]",
 		Short: "Show progress of active investigations",
 		Long: `Shows a summary of active investigations (daily batch or individual).
 Displays completed, in-progress, and pending steps.
 
+Pass a repo name to narrow the running daily batch down to that repo's
+investigation: its status, elapsed time or duration, completed steps and a
+summary of its workflow history.
+
 Use --repo to track a specific repo's investigation with a live progress bar.
 Add --wait to keep watching until the investigation finishes.`,
-		Args: friendlyMaxArgs(0, `reposwarm workflows progress [--repo <name>] [--wait]
+		Args: friendlyMaxArgs(1, `reposwarm workflows progress [repo] [--repo <name>] [--wait]
 
 Examples:
   reposwarm wf progress
+  reposwarm wf progress is-odd
   reposwarm wf progress --repo is-odd
   reposwarm wf progress --repo is-odd --wait`),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				if repo != "" || wait {
+					return fmt.Errorf("a repo argument can't be combined with --repo or --wait")
+				}
+				return showDailyRepoProgress(args[0])
+			}
 			if repo == "" {
 				// No --repo: fall back to the original overview progress
 				return showOverviewProgress()
@@ -71,6 +82,12 @@ Examples:
 }
 
 func showRepoProgress(repoName string, wait bool) error {
+	return showWorkflowProgress(repoName, "", wait)
+}
+
+// showWorkflowProgress shows repoName's investigation progress for the given
+// workflow, or for the repo's most recent one when workflowID is empty.
+func showWorkflowProgress(repoName, workflowID string, wait bool) error {
 	client, err := getClient()
 	if err != nil {
 		return err
@@ -81,9 +98,10 @@ func showRepoProgress(repoName string, wait bool) error {
 	model := cliCfg.EffectiveModel()
 
 	// Find the workflow for this repo
-	workflowID, err := findRepoWorkflow(client, repoName)
-	if err != nil {
-		return err
+	if workflowID == "" {
+		if workflowID, err = findRepoWorkflow(client, repoName); err != nil {
+			return err
+		}
 	}
 
 	if workflowID == "" {
@@ -104,17 +122,18 @@ func showRepoProgress(repoName string, wait bool) error {
 	return watchRepoUntilDone(client, repoName, workflowID, model)
 }
 
-func findRepoWorkflow(client *api.Client, repoName string) (string, error) {
+func findRepoWorkflow(client *api.Client, repo string) (string, error) {
 	var result api.WorkflowsResponse
 	if err := client.Get(ctx(), "/workflows?pageSize=100", &result); err != nil {
 		return "", err
 	}
 
-	// Find the most recent running workflow for this repo
-	prefix := fmt.Sprintf("investigate-single-%s-", repoName)
+	// Find the most recent workflow for this repo, in either the
+	// investigate-single-<repo>-<ts> or investigate-single-repo-<repo> form
+	prefix := fmt.Sprintf("investigate-single-%s-", repo)
 	var best *api.WorkflowExecution
 	for i, w := range result.Executions {
-		if strings.HasPrefix(w.WorkflowID, prefix) {
+		if strings.HasPrefix(w.WorkflowID, prefix) || repoName(w.WorkflowID) == repo {
 			if best == nil || w.StartTime > best.StartTime {
 				best = &result.Executions[i]
 			}
@@ -423,6 +442,137 @@ func renderProgressDisplay(repoName, workflowID, status, startTime string, compl
 	fmt.Println()
 }
 
+// showDailyRepoProgress shows the progress of one repo's child workflow
+// within the running daily investigation.
+func showDailyRepoProgress(name string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	var result api.WorkflowsResponse
+	if err := client.Get(ctx(), "/workflows?pageSize=100", &result); err != nil {
+		return err
+	}
+
+	var daily *api.WorkflowExecution
+	for i, w := range result.Executions {
+		if w.Type == "InvestigateReposWorkflow" && w.Status == "Running" {
+			daily = &result.Executions[i]
+			break
+		}
+	}
+	if daily == nil {
+		return fmt.Errorf("no daily investigation is running (use --repo %s for a standalone investigation)", name)
+	}
+
+	var child *api.WorkflowExecution
+	for i, w := range result.Executions {
+		if w.Type != "InvestigateSingleRepoWorkflow" || w.StartTime < daily.StartTime || repoName(w.WorkflowID) != name {
+			continue
+		}
+		if child == nil || w.StartTime > child.StartTime {
+			child = &result.Executions[i]
+		}
+	}
+	if child == nil {
+		return fmt.Errorf("%s is not part of the current daily run %s (it may not have started yet)", name, daily.WorkflowID)
+	}
+
+	completed, _ := getCompletedSteps(client, name)
+	done := 0
+	for _, step := range investigationSteps {
+		if completed[step.ID] {
+			done++
+		}
+	}
+	history := workflowHistorySummary(client, *child)
+
+	if flagJSON {
+		out := map[string]any{
+			"repo":            name,
+			"workflowId":      child.WorkflowID,
+			"runId":           child.RunID,
+			"dailyWorkflowId": daily.WorkflowID,
+			"status":          child.Status,
+			"startTime":       child.StartTime,
+			"duration":        duration(*child),
+			"completedSteps":  done,
+			"totalSteps":      len(investigationSteps),
+		}
+		if child.CloseTime != "" {
+			out["closeTime"] = child.CloseTime
+		}
+		if history != nil {
+			out["history"] = history
+		}
+		return output.JSON(out)
+	}
+
+	F := output.F
+	F.Section(fmt.Sprintf("Daily Investigation Progress — %s", name))
+	F.KeyValue("Daily run", daily.WorkflowID)
+	F.KeyValue("Workflow", child.WorkflowID)
+	F.KeyValue("Status", output.StatusColor(child.Status))
+	if child.CloseTime == "" {
+		F.KeyValue("Elapsed", duration(*child))
+	} else {
+		F.KeyValue("Duration", duration(*child))
+	}
+	F.KeyValue("Steps", fmt.Sprintf("%d/%d", done, len(investigationSteps)))
+	if history != nil {
+		F.KeyValue("History", fmt.Sprintf("%d events, last %s", history.Events, history.LastEvent))
+		if history.FailedActivities > 0 {
+			F.KeyValue("Failed activities", output.Red(fmt.Sprint(history.FailedActivities)))
+		}
+	}
+	F.Println()
+	return nil
+}
+
+// historySummary condenses a workflow's event history.
+type historySummary struct {
+	Events           int    `json:"events"`
+	LastEvent        string `json:"lastEvent"`
+	LastEventTime    string `json:"lastEventTime,omitempty"`
+	FailedActivities int    `json:"failedActivities"`
+}
+
+// workflowHistorySummary fetches w's history and summarizes it, or returns
+// nil if the history isn't available.
+func workflowHistorySummary(client *api.Client, w api.WorkflowExecution) *historySummary {
+	path := fmt.Sprintf("/workflows/%s/history", w.WorkflowID)
+	if w.RunID != "" {
+		path += "?runId=" + w.RunID
+	}
+	var response struct {
+		Events []map[string]any `json:"events"`
+	}
+	if err := client.Get(ctx(), path, &response); err != nil {
+		return nil
+	}
+	return summarizeHistory(response.Events)
+}
+
+// summarizeHistory condenses history events, or returns nil if there are none.
+func summarizeHistory(events []map[string]any) *historySummary {
+	if len(events) == 0 {
+		return nil
+	}
+	sum := &historySummary{Events: len(events)}
+	for _, e := range events {
+		eventType, _ := e["eventType"].(string)
+		if strings.Contains(eventType, "ACTIVITY_TASK_FAILED") || strings.Contains(eventType, "ActivityTaskFailed") {
+			sum.FailedActivities++
+		}
+	}
+	last := events[len(events)-1]
+	sum.LastEvent, _ = last["eventType"].(string)
+	sum.LastEvent = strings.TrimPrefix(sum.LastEvent, "EVENT_TYPE_")
+	sum.LastEventTime, _ = last["eventTime"].(string)
+	return sum
+}
+
 // showOverviewProgress is the original progress behavior (batch + standalone).
 func showOverviewProgress() error {
 	client, err := getClient()
@@ -588,18 +738,9 @@ func showOverviewProgress() error {
 
 	var totalRepos int
 	if daily != nil {
-		var repos []api.Repository
 		totalRepos = 36
-		if err := client.Get(ctx(), "/repos", &repos); err == nil {
-			enabled := 0
-			for _, r := range repos {
-				if r.Enabled {
-					enabled++
-				}
-			}
-			if enabled > 0 {
-				totalRepos = enabled
-			}
+		if enabled, err := enabledRepos(client); err == nil && len(enabled) > 0 {
+			totalRepos = len(enabled)
 		}
 	} else {
 		totalRepos = len(children)
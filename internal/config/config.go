@@ -1,12 +1,17 @@
-// Package config manages CLI configuration stored in ~/.reposwarm/config.json.
+// Package config manages CLI configuration stored in config.json under
+// ConfigDir (~/.reposwarm by default).
 package config
 
 import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // GitHub organization and repository constants.
@@ -37,13 +42,27 @@ const (
 
 // Config holds all CLI configuration.
 type Config struct {
-	APIUrl       string `json:"apiUrl"`
-	APIToken     string `json:"apiToken"`
+	APIUrl   string `json:"apiUrl"`
+	APIToken string `json:"apiToken"`
+	// TokenCommand, when set, is run to obtain the API token (e.g. from a
+	// vault or SSO CLI) and re-run when the token is rejected.
+	TokenCommand string `json:"tokenCommand,omitempty"`
 	Region       string `json:"region"`
 	DefaultModel string `json:"defaultModel"`
 	ChunkSize    int    `json:"chunkSize"`
 	OutputFormat string `json:"outputFormat"`
 
+	// TLS settings for self-signed API servers (dev only)
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+	CACert             string `json:"caCert,omitempty"`
+
+	// HTTPProxy overrides HTTP_PROXY/HTTPS_PROXY for API and download requests
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// ExtraHeaders are sent on every API request (e.g. gateway headers like
+	// X-Team-Id). They can't override Authorization.
+	ExtraHeaders map[string]string `json:"extraHeaders,omitempty"`
+
 	// Provider configuration
 	ProviderConfig ProviderConfig `json:"providerConfig,omitempty"`
 
@@ -63,7 +82,35 @@ type Config struct {
 	TemporalUIPort string `json:"temporalUiPort,omitempty"`
 	APIPort        string `json:"apiPort,omitempty"`
 	UIPort         string `json:"uiPort,omitempty"`
+	UIURL          string `json:"uiUrl,omitempty"`
 	InstallDir     string `json:"installDir,omitempty"`
+
+	// Docker Compose customization for 'reposwarm new --local'
+	ComposeFile     string `json:"composeFile,omitempty"`     // replaces the generated docker-compose.yml
+	ComposeOverride string `json:"composeOverride,omitempty"` // merged as docker-compose.override.yml
+
+	// AgentCmd replaces the coding agent launch of 'reposwarm new --agent';
+	// {guide} and {dir} are substituted
+	AgentCmd string `json:"agentCmd,omitempty"`
+
+	// Readiness waits for local setup, as Go durations ("5m", "90s")
+	TemporalTimeout       string `json:"temporalTimeout,omitempty"`
+	ServiceTimeout        string `json:"serviceTimeout,omitempty"`
+	ReadinessPollInterval string `json:"readinessPollInterval,omitempty"`
+
+	// PromptListDefault is the filter 'prompts list' applies when no
+	// --enabled/--disabled/--all flag is given: "all", "enabled" or "disabled"
+	PromptListDefault string `json:"promptListDefault,omitempty"`
+
+	// NoIcons drops emoji section icons from human output (--no-icons)
+	NoIcons bool `json:"noIcons,omitempty"`
+
+	// NoHTTPCache turns off the on-disk ETag cache of API responses (--no-cache)
+	NoHTTPCache bool `json:"noHttpCache,omitempty"`
+
+	// ExcludeRepos are repo names or globs that fleet-wide commands
+	// (results search/audit/report) skip, on top of any --exclude
+	ExcludeRepos []string `json:"excludeRepos,omitempty"`
 }
 
 // Effective* methods return the configured value or the built-in default.
@@ -124,6 +171,14 @@ func (c *Config) EffectiveUIPort() string {
 	return "3001"
 }
 
+// EffectiveUIURL returns the configured UI base URL, or localhost on the UI port.
+func (c *Config) EffectiveUIURL() string {
+	if c.UIURL != "" {
+		return strings.TrimRight(c.UIURL, "/")
+	}
+	return "http://localhost:" + c.EffectiveUIPort()
+}
+
 // DefaultConfig returns sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
@@ -138,24 +193,66 @@ func DefaultConfig() *Config {
 // ValidKeys returns the list of settable config keys.
 func ValidKeys() []string {
 	return []string{
-		"apiUrl", "apiToken", "region", "defaultModel", "chunkSize", "outputFormat",
+		"apiUrl", "apiToken", "tokenCommand", "region", "defaultModel", "chunkSize", "outputFormat",
+		"insecureSkipVerify", "caCert", "httpProxy", "extraHeaders",
 		"installType", "workerRepoUrl", "apiRepoUrl", "uiRepoUrl", "hubUrl", "archHubUrl", "askboxUrl", "dynamodbTable",
-		"temporalPort", "temporalUiPort", "apiPort", "uiPort", "installDir",
+		"temporalPort", "temporalUiPort", "apiPort", "uiPort", "uiUrl", "installDir",
+		"composeFile", "composeOverride", "agentCmd", "temporalTimeout", "serviceTimeout", "readinessPollInterval",
+		"promptListDefault", "noIcons", "noHttpCache", "excludeRepos",
 		"provider", "awsRegion", "proxyUrl", "proxyKey", "smallModel",
 	}
 }
 
-// ConfigDir returns the config directory path.
+// pathOverride, when set, replaces the resolved config file path (--config).
+var pathOverride string
+
+// SetPath makes Load and Save use path instead of <ConfigDir>/config.json.
+// An empty path restores the default.
+func SetPath(path string) {
+	pathOverride = path
+}
+
+// ConfigHomeEnv overrides the config directory.
+const ConfigHomeEnv = "REPOSWARM_CONFIG_HOME"
+
+// ConfigDir returns the config directory path, resolved in order:
+//
+//  1. $REPOSWARM_CONFIG_HOME
+//  2. ~/.reposwarm, if it already holds a config.json
+//  3. $XDG_CONFIG_HOME/reposwarm, on Linux when XDG_CONFIG_HOME is set
+//  4. ~/.reposwarm
 func ConfigDir() (string, error) {
+	if dir := os.Getenv(ConfigHomeEnv); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("finding home directory: %w", err)
 	}
-	return filepath.Join(home, ".reposwarm"), nil
+	legacy := filepath.Join(home, ".reposwarm")
+	if _, err := os.Stat(filepath.Join(legacy, "config.json")); err == nil {
+		return legacy, nil
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); runtime.GOOS == "linux" && filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "reposwarm"), nil
+	}
+	return legacy, nil
+}
+
+// CacheDir returns the directory for cached data (HTTP ETag cache etc.).
+func CacheDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
 }
 
 // ConfigPath returns the config file path.
 func ConfigPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
 	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
@@ -165,7 +262,21 @@ func ConfigPath() (string, error) {
 
 // Load reads config from disk, falling back to defaults.
 // Environment variables REPOSWARM_API_URL and REPOSWARM_API_TOKEN override file values.
+// An unusable httpProxy or proxyUrl is an error rather than being ignored.
 func Load() (*Config, error) {
+	cfg, err := LoadForEdit()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProxies(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadForEdit is Load without the proxy checks, so 'config set' and
+// 'config validate' still work on a config with a bad proxy.
+func LoadForEdit() (*Config, error) {
 	cfg := DefaultConfig()
 
 	path, err := ConfigPath()
@@ -201,11 +312,11 @@ func applyEnvOverrides(cfg *Config) {
 
 // Save writes config to disk.
 func Save(cfg *Config) error {
-	dir, err := ConfigDir()
+	path, err := ConfigPath()
 	if err != nil {
 		return err
 	}
-	if err := os.MkdirAll(dir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return fmt.Errorf("creating config dir: %w", err)
 	}
 
@@ -214,7 +325,6 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("encoding config: %w", err)
 	}
 
-	path := filepath.Join(dir, "config.json")
 	return os.WriteFile(path, data, 0600)
 }
 
@@ -222,9 +332,15 @@ func Save(cfg *Config) error {
 func Set(cfg *Config, key, value string) error {
 	switch key {
 	case "apiUrl":
-		cfg.APIUrl = value
+		u, err := NormalizeAPIURL(value)
+		if err != nil {
+			return err
+		}
+		cfg.APIUrl = u
 	case "apiToken":
 		cfg.APIToken = value
+	case "tokenCommand":
+		cfg.TokenCommand = value
 	case "region":
 		cfg.Region = value
 	case "defaultModel":
@@ -240,6 +356,46 @@ func Set(cfg *Config, key, value string) error {
 			return fmt.Errorf("outputFormat must be 'pretty' or 'json'")
 		}
 		cfg.OutputFormat = value
+	case "insecureSkipVerify":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("insecureSkipVerify must be 'true' or 'false'")
+		}
+		cfg.InsecureSkipVerify = b
+	case "caCert":
+		cfg.CACert = value
+	case "httpProxy":
+		if value != "" {
+			if err := checkProxyURL(value); err != nil {
+				return fmt.Errorf("httpProxy %v", err)
+			}
+		}
+		cfg.HTTPProxy = value
+	case "extraHeaders":
+		// One "Key=Value" per set, since header values may contain commas:
+		// it adds or replaces Key, "Key=" removes it and "" clears them all
+		if strings.TrimSpace(value) == "" {
+			cfg.ExtraHeaders = nil
+			break
+		}
+		k, v, err := ParseHeader(value)
+		if err != nil {
+			return err
+		}
+		for existing := range cfg.ExtraHeaders {
+			if strings.EqualFold(existing, k) {
+				delete(cfg.ExtraHeaders, existing)
+			}
+		}
+		if v != "" {
+			if cfg.ExtraHeaders == nil {
+				cfg.ExtraHeaders = map[string]string{}
+			}
+			cfg.ExtraHeaders[k] = v
+		}
+		if len(cfg.ExtraHeaders) == 0 {
+			cfg.ExtraHeaders = nil
+		}
 	case "workerRepoUrl":
 		cfg.WorkerRepoURL = value
 	case "apiRepoUrl":
@@ -262,8 +418,58 @@ func Set(cfg *Config, key, value string) error {
 		cfg.APIPort = value
 	case "uiPort":
 		cfg.UIPort = value
+	case "uiUrl":
+		cfg.UIURL = value
 	case "installDir":
 		cfg.InstallDir = value
+	case "composeFile":
+		cfg.ComposeFile = value
+	case "composeOverride":
+		cfg.ComposeOverride = value
+	case "agentCmd":
+		cfg.AgentCmd = value
+	case "temporalTimeout", "serviceTimeout", "readinessPollInterval":
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration like 90s or 5m", key)
+		}
+		switch key {
+		case "temporalTimeout":
+			cfg.TemporalTimeout = value
+		case "serviceTimeout":
+			cfg.ServiceTimeout = value
+		default:
+			cfg.ReadinessPollInterval = value
+		}
+	case "noIcons":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("noIcons must be 'true' or 'false'")
+		}
+		cfg.NoIcons = b
+	case "noHttpCache":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("noHttpCache must be 'true' or 'false'")
+		}
+		cfg.NoHTTPCache = b
+	case "excludeRepos":
+		// Comma-separated names or globs; an empty value clears them
+		var patterns []string
+		for _, p := range strings.Split(value, ",") {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("excludeRepos: invalid pattern %q", p)
+			}
+			patterns = append(patterns, p)
+		}
+		cfg.ExcludeRepos = patterns
+	case "promptListDefault":
+		if !validPromptListDefault(value) {
+			return fmt.Errorf("promptListDefault must be 'all', 'enabled' or 'disabled'")
+		}
+		cfg.PromptListDefault = value
 	case "installType":
 		if value != "docker" && value != "source" {
 			return fmt.Errorf("installType must be 'docker' or 'source'")
@@ -277,6 +483,11 @@ func Set(cfg *Config, key, value string) error {
 	case "awsRegion":
 		cfg.ProviderConfig.AWSRegion = value
 	case "proxyUrl":
+		if value != "" {
+			if err := checkHTTPURL(value); err != nil {
+				return fmt.Errorf("proxyUrl %v", err)
+			}
+		}
 		cfg.ProviderConfig.ProxyURL = value
 	case "proxyKey":
 		cfg.ProviderConfig.ProxyKey = value
@@ -289,8 +500,42 @@ func Set(cfg *Config, key, value string) error {
 	return nil
 }
 
-// MaskedToken returns a token with most characters replaced by *.
+func validPromptListDefault(v string) bool {
+	return v == "all" || v == "enabled" || v == "disabled"
+}
+
+// TokenFilePrefix marks an apiToken value that names a file holding the
+// token (e.g. a mounted Kubernetes or Docker secret) instead of the token.
+const TokenFilePrefix = "file:"
+
+// ResolveToken returns token, or for a "file:<path>" value the contents of
+// path with surrounding whitespace trimmed. The file is read on every call so
+// rotated secrets are picked up.
+func ResolveToken(token string) (string, error) {
+	path, ok := strings.CutPrefix(token, TokenFilePrefix)
+	if !ok {
+		return token, nil
+	}
+	if path == "" {
+		return "", fmt.Errorf("%s needs a path (e.g. file:/run/secrets/reposwarm-token)", TokenFilePrefix)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading API token file: %w", err)
+	}
+	resolved := strings.TrimSpace(string(data))
+	if resolved == "" {
+		return "", fmt.Errorf("API token file %s is empty", path)
+	}
+	return resolved, nil
+}
+
+// MaskedToken returns a token with most characters replaced by *. A
+// "file:<path>" reference is returned as-is since the path isn't secret.
 func MaskedToken(token string) string {
+	if strings.HasPrefix(token, TokenFilePrefix) {
+		return token
+	}
 	if len(token) <= 8 {
 		return "***"
 	}
@@ -3,6 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -25,6 +28,8 @@ func TestSaveAndLoad(t *testing.T) {
 	origHome := os.Getenv("HOME")
 	os.Setenv("HOME", dir)
 	defer os.Setenv("HOME", origHome)
+	t.Setenv(ConfigHomeEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "")
 
 	cfg := DefaultConfig()
 	cfg.APIUrl = "https://test.example.com/v1"
@@ -58,6 +63,8 @@ func TestEnvOverrides(t *testing.T) {
 	origHome := os.Getenv("HOME")
 	os.Setenv("HOME", dir)
 	defer os.Setenv("HOME", origHome)
+	t.Setenv(ConfigHomeEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "")
 
 	os.Setenv("REPOSWARM_API_URL", "https://env.example.com")
 	os.Setenv("REPOSWARM_API_TOKEN", "env-token")
@@ -84,12 +91,34 @@ func TestSetValidKeys(t *testing.T) {
 		wantErr    bool
 	}{
 		{"apiUrl", "https://new.com", false},
+		{"apiUrl", "localhost:3000", true},
+		{"apiUrl", "http://", true},
 		{"apiToken", "new-token", false},
+		{"tokenCommand", "vault read -field=token secret/reposwarm", false},
 		{"region", "eu-west-1", false},
 		{"chunkSize", "20", false},
 		{"chunkSize", "notanumber", true},
 		{"outputFormat", "json", false},
 		{"outputFormat", "xml", true},
+		{"temporalTimeout", "10m", false},
+		{"serviceTimeout", "90s", false},
+		{"serviceTimeout", "90", true},
+		{"readinessPollInterval", "-1s", true},
+		{"promptListDefault", "enabled", false},
+		{"promptListDefault", "on", true},
+		{"extraHeaders", "X-Team-Id=42", false},
+		{"extraHeaders", "", false},
+		{"httpProxy", "socks5://127.0.0.1:1080", false},
+		{"httpProxy", "ftp://proxy", true},
+		{"httpProxy", "proxy:8080", true},
+		{"httpProxy", "", false},
+		{"proxyUrl", "http://litellm:4000", false},
+		{"proxyUrl", "litellm", true},
+		{"extraHeaders", "X-Team-Id", true},
+		{"extraHeaders", "authorization=Bearer x", true},
+		{"excludeRepos", "archived-*, sandbox", false},
+		{"excludeRepos", "", false},
+		{"excludeRepos", "bad[", true},
 		{"bogusKey", "value", true},
 	}
 
@@ -101,6 +130,60 @@ func TestSetValidKeys(t *testing.T) {
 	}
 }
 
+func TestLoadRejectsBadProxy(t *testing.T) {
+	t.Setenv(ConfigHomeEnv, t.TempDir())
+	cfg := DefaultConfig()
+	cfg.HTTPProxy = "proxy:8080"
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "httpProxy") {
+		t.Errorf("Load() err = %v, want an httpProxy error", err)
+	}
+	if _, err := LoadForEdit(); err != nil {
+		t.Errorf("LoadForEdit() err = %v", err)
+	}
+}
+
+func TestSetExtraHeaders(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, v := range []string{"X-Team-Id=42", "Accept=text/html, application/json", "x-team-id=43", "X-Env=dev", "X-Env="} {
+		if err := Set(cfg, "extraHeaders", v); err != nil {
+			t.Fatalf("Set(extraHeaders, %q): %v", v, err)
+		}
+	}
+	want := map[string]string{"x-team-id": "43", "Accept": "text/html, application/json"}
+	if !reflect.DeepEqual(cfg.ExtraHeaders, want) {
+		t.Errorf("ExtraHeaders = %v, want %v", cfg.ExtraHeaders, want)
+	}
+	if err := Set(cfg, "extraHeaders", ""); err != nil || cfg.ExtraHeaders != nil {
+		t.Errorf("clearing: err = %v, ExtraHeaders = %v", err, cfg.ExtraHeaders)
+	}
+}
+
+func TestNormalizeAPIURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"http://localhost:3000/v1", "http://localhost:3000/v1", false},
+		{"https://api.example.com/v1/", "https://api.example.com/v1", false},
+		{" http://localhost:3000// ", "http://localhost:3000", false},
+		{"localhost:3000", "", true},
+		{"ftp://example.com", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeAPIURL(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeAPIURL(%q) = %q, %v; want %q, wantErr=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if _, err := NormalizeAPIURL("localhost:3000"); err == nil || !strings.Contains(err.Error(), "http://localhost:3000") {
+		t.Errorf("expected scheme hint, got %v", err)
+	}
+}
+
 func TestMaskedToken(t *testing.T) {
 	tests := []struct {
 		input, want string
@@ -108,6 +191,7 @@ func TestMaskedToken(t *testing.T) {
 		{"", "***"},
 		{"short", "***"},
 		{"abcdefghijklmnop", "***...klmnop"},
+		{"file:/run/secrets/token", "file:/run/secrets/token"},
 	}
 	for _, tt := range tests {
 		got := MaskedToken(tt.input)
@@ -117,6 +201,59 @@ func TestMaskedToken(t *testing.T) {
 	}
 }
 
+func TestConfigDirResolution(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ConfigHomeEnv, "")
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	legacy := filepath.Join(home, ".reposwarm")
+	want := legacy
+	if runtime.GOOS == "linux" {
+		want = filepath.Join(xdg, "reposwarm")
+	}
+	if dir, _ := ConfigDir(); dir != want {
+		t.Errorf("ConfigDir() = %q, want %q", dir, want)
+	}
+
+	// An existing ~/.reposwarm/config.json keeps winning over XDG
+	os.MkdirAll(legacy, 0700)
+	os.WriteFile(filepath.Join(legacy, "config.json"), []byte("{}"), 0600)
+	if dir, _ := ConfigDir(); dir != legacy {
+		t.Errorf("ConfigDir() = %q, want existing %q", dir, legacy)
+	}
+
+	override := t.TempDir()
+	t.Setenv(ConfigHomeEnv, override)
+	if dir, _ := ConfigDir(); dir != override {
+		t.Errorf("ConfigDir() = %q, want %s %q", dir, ConfigHomeEnv, override)
+	}
+	if path, _ := ConfigPath(); path != filepath.Join(override, "config.json") {
+		t.Errorf("ConfigPath() = %q", path)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	if got, err := ResolveToken("plain-token"); err != nil || got != "plain-token" {
+		t.Errorf("ResolveToken(plain) = %q, %v", got, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("  secret-token\n"), 0600)
+	if got, err := ResolveToken("file:" + path); err != nil || got != "secret-token" {
+		t.Errorf("ResolveToken(file:) = %q, %v, want trimmed file contents", got, err)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	os.WriteFile(empty, []byte("\n"), 0600)
+	for _, token := range []string{"file:", "file:" + empty, "file:" + filepath.Join(t.TempDir(), "missing")} {
+		if _, err := ResolveToken(token); err == nil {
+			t.Errorf("ResolveToken(%q) should fail", token)
+		}
+	}
+}
+
 func TestValidKeys(t *testing.T) {
 	keys := ValidKeys()
 	if len(keys) == 0 {
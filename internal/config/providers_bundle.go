@@ -66,7 +66,7 @@ type ProvidersFile struct {
 var cachedProviders *ProvidersFile
 
 // LoadProviders loads the provider configuration.
-// Lookup order: API server → ~/.reposwarm/providers.json → embedded providers.json
+// Lookup order: API server → <ConfigDir>/providers.json → embedded providers.json
 func LoadProviders() (*ProvidersFile, error) {
 	if cachedProviders != nil {
 		return cachedProviders, nil
@@ -78,10 +78,10 @@ func LoadProviders() (*ProvidersFile, error) {
 		return cachedProviders, nil
 	}
 
-	// Try external file (~/.reposwarm/providers.json)
-	home, err := os.UserHomeDir()
+	// Try external file (<ConfigDir>/providers.json)
+	dir, err := ConfigDir()
 	if err == nil {
-		extPath := filepath.Join(home, ".reposwarm", "providers.json")
+		extPath := filepath.Join(dir, "providers.json")
 		if data, err := os.ReadFile(extPath); err == nil {
 			var pf ProvidersFile
 			if err := json.Unmarshal(data, &pf); err == nil {
@@ -122,13 +122,11 @@ func loadEmbeddedProviders() (*ProvidersFile, error) {
 // fetchProvidersFromAPI tries to fetch the providers bundle from the API server.
 // Returns nil if the API is unavailable or doesn't support the endpoint.
 func fetchProvidersFromAPI() *ProvidersFile {
-	home, err := os.UserHomeDir()
+	// Read config to get API URL and token
+	cfgPath, err := ConfigPath()
 	if err != nil {
 		return nil
 	}
-
-	// Read config to get API URL and token
-	cfgPath := filepath.Join(home, ".reposwarm", "config.json")
 	data, err := os.ReadFile(cfgPath)
 	if err != nil {
 		return nil
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Problem is a single invalid config value.
+type Problem struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// Validate checks cfg for values that would break the CLI at runtime:
+// malformed URLs, non-numeric ports, bad enums. It never touches the network.
+func Validate(cfg *Config) []Problem {
+	var problems []Problem
+	add := func(key, format string, args ...any) {
+		problems = append(problems, Problem{key, fmt.Sprintf(format, args...)})
+	}
+
+	if err := checkHTTPURL(cfg.APIUrl); err != nil {
+		add("apiUrl", "%v", err)
+	}
+	if cfg.APIToken == "" && cfg.TokenCommand == "" {
+		add("apiToken", "is empty — run 'reposwarm config init' or set REPOSWARM_API_TOKEN")
+	} else if _, err := ResolveToken(cfg.APIToken); err != nil {
+		add("apiToken", "%v", err)
+	}
+	if cfg.ChunkSize <= 0 {
+		add("chunkSize", "must be a positive number (got %d)", cfg.ChunkSize)
+	}
+	if cfg.OutputFormat != "pretty" && cfg.OutputFormat != "json" {
+		add("outputFormat", "must be 'pretty' or 'json' (got %q)", cfg.OutputFormat)
+	}
+	if cfg.InstallType != "" && cfg.InstallType != "docker" && cfg.InstallType != "source" {
+		add("installType", "must be 'docker' or 'source' (got %q)", cfg.InstallType)
+	}
+	if cfg.PromptListDefault != "" && !validPromptListDefault(cfg.PromptListDefault) {
+		add("promptListDefault", "must be 'all', 'enabled' or 'disabled' (got %q)", cfg.PromptListDefault)
+	}
+	if p := cfg.ProviderConfig.Provider; p != "" && !IsValidProvider(string(p)) {
+		add("provider", "unknown provider %q (valid: anthropic, bedrock, litellm)", p)
+	}
+
+	for _, port := range []struct{ key, value string }{
+		{"temporalPort", cfg.TemporalPort},
+		{"temporalUiPort", cfg.TemporalUIPort},
+		{"apiPort", cfg.APIPort},
+		{"uiPort", cfg.UIPort},
+	} {
+		if port.value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(port.value); err != nil || n < 1 || n > 65535 {
+			add(port.key, "must be a port number between 1 and 65535 (got %q)", port.value)
+		}
+	}
+
+	for _, u := range []struct{ key, value string }{
+		{"uiUrl", cfg.UIURL},
+		{"hubUrl", cfg.HubURL},
+		{"archHubUrl", cfg.ArchHubURL},
+		{"askboxUrl", cfg.AskboxURL},
+	} {
+		if u.value == "" {
+			continue
+		}
+		if err := checkHTTPURL(u.value); err != nil {
+			add(u.key, "%v", err)
+		}
+	}
+	if cfg.HTTPProxy != "" {
+		if err := checkProxyURL(cfg.HTTPProxy); err != nil {
+			add("httpProxy", "%v", err)
+		}
+	}
+	if cfg.ProviderConfig.ProxyURL != "" {
+		if err := checkHTTPURL(cfg.ProviderConfig.ProxyURL); err != nil {
+			add("proxyUrl", "%v", err)
+		}
+	}
+
+	for k := range cfg.ExtraHeaders {
+		if err := checkHeaderName(k); err != nil {
+			add("extraHeaders", "%v", err)
+		}
+	}
+
+	for _, d := range []struct{ key, value string }{
+		{"temporalTimeout", cfg.TemporalTimeout},
+		{"serviceTimeout", cfg.ServiceTimeout},
+		{"readinessPollInterval", cfg.ReadinessPollInterval},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v <= 0 {
+			add(d.key, "must be a positive duration like 90s or 5m (got %q)", d.value)
+		}
+	}
+
+	return problems
+}
+
+// ParseHeader splits a "Key=Value" (or "Key: Value") header spec. The
+// Authorization header is rejected: the API token always sets it.
+func ParseHeader(s string) (string, string, error) {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		k, v, ok = strings.Cut(s, ":")
+	}
+	k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+	if !ok || k == "" {
+		return "", "", fmt.Errorf("invalid header %q: expected key=value", s)
+	}
+	if err := checkHeaderName(k); err != nil {
+		return "", "", err
+	}
+	return k, v, nil
+}
+
+func checkHeaderName(k string) error {
+	if strings.EqualFold(k, "Authorization") {
+		return fmt.Errorf("the Authorization header can't be set as an extra header (use apiToken)")
+	}
+	if strings.ContainsAny(k, " \t\r\n:") {
+		return fmt.Errorf("invalid header name %q", k)
+	}
+	return nil
+}
+
+// NormalizeAPIURL validates an API base URL and strips trailing slashes so
+// request paths ("/repos") join cleanly.
+func NormalizeAPIURL(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if err := checkHTTPURL(s); err != nil {
+		if s != "" && !strings.Contains(s, "://") {
+			return "", fmt.Errorf("API URL %w — did you mean http://%s?", err, s)
+		}
+		return "", fmt.Errorf("API URL %w", err)
+	}
+	return strings.TrimRight(s, "/"), nil
+}
+
+// checkProxyURL checks an httpProxy value: an http, https, socks5 or
+// socks5h URL with a host.
+func checkProxyURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("must be a URL like http://proxy:8080 (got %q)", s)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return nil
+	}
+	return fmt.Errorf("must use http, https, socks5 or socks5h (got %q)", s)
+}
+
+// checkProxies is the subset of Validate that Load enforces: proxy values
+// that would otherwise be silently ignored.
+func checkProxies(cfg *Config) error {
+	for _, p := range []struct {
+		key, value string
+		check      func(string) error
+	}{
+		{"httpProxy", cfg.HTTPProxy, checkProxyURL},
+		{"proxyUrl", cfg.ProviderConfig.ProxyURL, checkHTTPURL},
+	} {
+		if p.value == "" {
+			continue
+		}
+		if err := p.check(p.value); err != nil {
+			return fmt.Errorf("config key %s %v; fix it with 'reposwarm config set %s <url>' (\"\" clears it)", p.key, err, p.key)
+		}
+	}
+	return nil
+}
+
+// checkHTTPURL reports why s isn't an absolute http(s) URL with a host.
+func checkHTTPURL(s string) error {
+	if s == "" {
+		return fmt.Errorf("is empty")
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("is not a valid URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must start with http:// or https:// (got %q)", s)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("has no host (got %q)", s)
+	}
+	return nil
+}
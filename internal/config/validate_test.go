@@ -0,0 +1,45 @@
+package config
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := DefaultConfig()
+		cfg.APIToken = "secret-token"
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		want   []string // offending keys, in order
+	}{
+		{"defaults with token", func(c *Config) {}, nil},
+		{"missing scheme", func(c *Config) { c.APIUrl = "localhost:3000/v1" }, []string{"apiUrl"}},
+		{"empty token", func(c *Config) { c.APIToken = "" }, []string{"apiToken"}},
+		{"bad chunk size", func(c *Config) { c.ChunkSize = 0 }, []string{"chunkSize"}},
+		{"bad output format", func(c *Config) { c.OutputFormat = "yaml" }, []string{"outputFormat"}},
+		{"non-numeric port", func(c *Config) { c.APIPort = "30OO"; c.UIPort = "70000" }, []string{"apiPort", "uiPort"}},
+		{"valid port", func(c *Config) { c.TemporalPort = "7233" }, nil},
+		{"bad ui url", func(c *Config) { c.UIURL = "ftp://host" }, []string{"uiUrl"}},
+		{"bad duration", func(c *Config) { c.ServiceTimeout = "2 minutes" }, []string{"serviceTimeout"}},
+		{"bad install type", func(c *Config) { c.InstallType = "k8s" }, []string{"installType"}},
+		{"extra headers", func(c *Config) { c.ExtraHeaders = map[string]string{"X-Team-Id": "42"} }, nil},
+		{"authorization header", func(c *Config) { c.ExtraHeaders = map[string]string{"Authorization": "x"} }, []string{"extraHeaders"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(cfg)
+			problems := Validate(cfg)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("Validate() = %+v, want keys %v", problems, tt.want)
+			}
+			for i, p := range problems {
+				if p.Key != tt.want[i] {
+					t.Errorf("problem %d key = %q, want %q", i, p.Key, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ErrNotInteractive is returned by Confirm when there is no terminal to ask.
+var ErrNotInteractive = errors.New("stdin is not a terminal: pass --yes (-y) to confirm")
+
+// Confirm asks a yes/no question on stdout and reads a full line from stdin.
+// Only "y" or "yes" (any case) confirm; an empty answer or EOF means no.
+// If stdin isn't a terminal it returns ErrNotInteractive rather than silently
+// declining, so scripts fail loudly instead of skipping the action.
+func Confirm(prompt string) (bool, error) {
+	if !StdinIsTerminal() {
+		return false, ErrNotInteractive
+	}
+	return confirmFrom(os.Stdin, os.Stdout, prompt)
+}
+
+// StdinIsTerminal reports whether stdin is an interactive terminal.
+func StdinIsTerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+func confirmFrom(r io.Reader, w io.Writer, prompt string) (bool, error) {
+	fmt.Fprintf(w, "  %s [y/N] ", prompt)
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("reading confirmation: %w", err)
+	}
+	if errors.Is(err, io.EOF) && line == "" {
+		fmt.Fprintln(w)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
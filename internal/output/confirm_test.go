@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirmFrom(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"  YES \n", true},
+		{"yes please\n", false},
+		{"n\n", false},
+		{"\n", false},
+		{"", false}, // EOF
+		{"y", true}, // EOF after answer
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := confirmFrom(strings.NewReader(tt.input), &out, "Remove repo?")
+		if err != nil {
+			t.Fatalf("confirmFrom(%q): %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("confirmFrom(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if !strings.Contains(out.String(), "Remove repo? [y/N]") {
+			t.Errorf("prompt not written: %q", out.String())
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
@@ -14,6 +15,12 @@ var (
 	F Formatter = &AgentFormatter{w: os.Stdout}
 	// IsHuman indicates whether rich output mode is active.
 	IsHuman bool
+	// Quiet suppresses decorative output: section banners, blank lines
+	// and the agent hint. Data lines are still printed.
+	Quiet bool
+	// NoIcons drops the emoji section icons in human mode (colors and
+	// layout are kept), for terminals that can't render them.
+	NoIcons bool
 )
 
 // Formatter provides structured output methods for CLI commands.
@@ -77,12 +84,12 @@ func (f *AgentFormatter) Table(headers []string, rows [][]string) {
 	}
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = utf8.RuneCountInString(h)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
 			}
 		}
 	}
@@ -108,6 +115,9 @@ func (f *AgentFormatter) Table(headers []string, rows [][]string) {
 }
 
 func (f *AgentFormatter) Section(title string) {
+	if Quiet {
+		return
+	}
 	fmt.Fprintf(f.w, "\n## %s\n\n", title)
 }
 
@@ -168,6 +178,9 @@ func (f *AgentFormatter) SectionIcon(_ string) string {
 }
 
 func (f *AgentFormatter) Println(a ...any) {
+	if Quiet && len(a) == 0 {
+		return
+	}
 	fmt.Fprintln(f.w, a...)
 }
 
@@ -188,6 +201,9 @@ func (f *HumanFormatter) Table(headers []string, rows [][]string) {
 }
 
 func (f *HumanFormatter) Section(title string) {
+	if Quiet {
+		return
+	}
 	fmt.Printf("\n  %s\n\n", Bold(title))
 }
 
@@ -257,6 +273,9 @@ func (f *HumanFormatter) StatusText(status string) string {
 }
 
 func (f *HumanFormatter) SectionIcon(id string) string {
+	if NoIcons {
+		return ""
+	}
 	icons := map[string]string{
 		"hl_overview": "📋", "module_deep_dive": "🔍", "dependencies": "📦",
 		"core_entities": "🏗", "DBs": "💾", "APIs": "🌐", "api_surface": "🔌",
@@ -273,6 +292,9 @@ func (f *HumanFormatter) SectionIcon(id string) string {
 }
 
 func (f *HumanFormatter) Println(a ...any) {
+	if Quiet && len(a) == 0 {
+		return
+	}
 	fmt.Fprintln(f.w, a...)
 }
 
@@ -282,6 +304,9 @@ func (f *HumanFormatter) Printf(format string, a ...any) {
 
 // Finish prints the agent hint at the end of human-mode output
 func (f *HumanFormatter) Finish() {
+	if Quiet {
+		return
+	}
 	fmt.Fprintf(f.w, "\n  %s\n\n", Dim("If you're an agent and not a human, add --for-agent to any command for machine-friendly output."))
 }
 
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrdered = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdQuote   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdRule    = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdBold    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// RenderMarkdown styles markdown for a terminal: headings, bold, inline and
+// fenced code, lists, quotes, links and rules. Anything else (e.g. tables)
+// passes through unchanged. Styling follows the current color settings.
+func RenderMarkdown(src string) string {
+	var out []string
+	inFence := false
+	for _, line := range strings.Split(strings.TrimRight(src, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			if lang := strings.Trim(trimmed, "`~ "); inFence && lang != "" {
+				out = append(out, Dim("┌ "+lang))
+			}
+			continue
+		}
+		if inFence {
+			out = append(out, Dim("│ ")+Yellow(line))
+			continue
+		}
+
+		switch {
+		case mdHeading.MatchString(line):
+			m := mdHeading.FindStringSubmatch(line)
+			text := renderInline(m[2])
+			switch len(m[1]) {
+			case 1:
+				out = append(out, Bold(Cyan(text)), Cyan(strings.Repeat("═", len([]rune(m[2])))))
+			case 2:
+				out = append(out, Bold(Cyan(text)), Dim(strings.Repeat("─", len([]rune(m[2])))))
+			default:
+				out = append(out, Bold(text))
+			}
+		case mdRule.MatchString(line):
+			out = append(out, Dim(strings.Repeat("─", 40)))
+		case mdBullet.MatchString(line):
+			m := mdBullet.FindStringSubmatch(line)
+			out = append(out, m[1]+Cyan("•")+" "+renderInline(m[2]))
+		case mdOrdered.MatchString(line):
+			m := mdOrdered.FindStringSubmatch(line)
+			out = append(out, m[1]+Cyan(m[2]+".")+" "+renderInline(m[3]))
+		case mdQuote.MatchString(line):
+			m := mdQuote.FindStringSubmatch(line)
+			out = append(out, Dim("│ "+m[1]))
+		default:
+			out = append(out, renderInline(line))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderInline styles `code`, **bold** and [links](url) within a line. Code
+// spans are left alone by the other rules.
+func renderInline(s string) string {
+	parts := strings.Split(s, "`")
+	if len(parts)%2 == 0 {
+		// Unbalanced backtick: treat it literally
+		return styleText(s)
+	}
+	for i := range parts {
+		if i%2 == 1 {
+			parts[i] = Yellow(parts[i])
+		} else {
+			parts[i] = styleText(parts[i])
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func styleText(s string) string {
+	s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLink.FindStringSubmatch(m)
+		return Cyan(sub[1]) + Dim(" ("+sub[2]+")")
+	})
+	return mdBold.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdBold.FindStringSubmatch(m)
+		return Bold(sub[1] + sub[2])
+	})
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	InitFormatter(false) // plain styling so the structure can be compared
+
+	src := strings.Join([]string{
+		"# Overview",
+		"Uses **Express** and `pg` — see [docs](https://example.com).",
+		"",
+		"## Modules",
+		"- api",
+		"  * handlers",
+		"1. first",
+		"> note",
+		"---",
+		"```go",
+		"x := **not bold**",
+		"```",
+		"| a | b |",
+	}, "\n")
+
+	want := strings.Join([]string{
+		"Overview",
+		"════════",
+		"Uses Express and pg — see docs (https://example.com).",
+		"",
+		"Modules",
+		"───────",
+		"• api",
+		"  • handlers",
+		"1. first",
+		"│ note",
+		strings.Repeat("─", 40),
+		"┌ go",
+		"│ x := **not bold**",
+		"| a | b |",
+	}, "\n")
+
+	if got := RenderMarkdown(src); got != want {
+		t.Errorf("RenderMarkdown mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderInlineUnbalancedBacktick(t *testing.T) {
+	InitFormatter(false)
+	if got := renderInline("a ` b **c**"); got != "a ` b c" {
+		t.Errorf("renderInline = %q", got)
+	}
+}
@@ -2,10 +2,13 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
@@ -21,13 +24,128 @@ var (
 	Error   = color.New(color.FgRed, color.Bold).SprintFunc()
 )
 
-// JSON prints data as indented JSON to stdout.
+// Fields, when set (--fields), limits JSON and JSONL output to these
+// top-level keys of each object.
+var Fields []string
+
+// Compact, when set (--compact), makes JSON print single-line output.
+var Compact bool
+
+// JSON prints data as indented JSON to stdout, or on one line when Compact
+// is set.
 func JSON(data any) error {
+	if Compact {
+		return JSONCompact(data)
+	}
+	data, err := project(data)
+	if err != nil {
+		return err
+	}
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(data)
 }
 
+// JSONCompact prints data as JSON on a single line to stdout.
+func JSONCompact(data any) error {
+	data, err := project(data)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(os.Stdout).Encode(data)
+}
+
+// project applies Fields to data: objects keep only the named keys, and
+// arrays are projected element by element. Other values pass through.
+func project(data any) (any, error) {
+	if len(Fields) == 0 {
+		return data, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return projectValue(v), nil
+}
+
+func projectValue(v any) any {
+	switch t := v.(type) {
+	case []any:
+		for i := range t {
+			t[i] = projectValue(t[i])
+		}
+		return t
+	case map[string]any:
+		out := make(map[string]any, len(Fields))
+		for _, f := range Fields {
+			if val, ok := t[f]; ok {
+				out[f] = val
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// Redirect sends all stdout output (formatter, JSON and plain prints) to f,
+// without colors, until the returned restore func is called.
+func Redirect(f *os.File) (restore func()) {
+	oldStdout, oldNoColor := os.Stdout, color.NoColor
+	os.Stdout = f
+	color.NoColor = true
+	InitFormatter(IsHuman)
+	return func() {
+		os.Stdout = oldStdout
+		color.NoColor = oldNoColor
+		InitFormatter(IsHuman)
+	}
+}
+
+// ForceColor turns colors on even when stdout isn't a terminal (e.g. piped
+// to less -R), unless NO_COLOR is set, until the returned restore func is
+// called.
+func ForceColor() (restore func()) {
+	old := color.NoColor
+	if os.Getenv("NO_COLOR") == "" {
+		color.NoColor = false
+		InitFormatter(IsHuman)
+	}
+	return func() {
+		color.NoColor = old
+		InitFormatter(IsHuman)
+	}
+}
+
+// JSONL prints each element of a slice as one compact JSON object per line
+// (JSON Lines / NDJSON). Non-slice values are written as a single line.
+func JSONL(items any) error {
+	enc := json.NewEncoder(os.Stdout)
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		p, err := project(items)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(p)
+	}
+	for i := 0; i < v.Len(); i++ {
+		p, err := project(v.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Table prints a simple table with headers and rows.
 func Table(headers []string, rows [][]string) {
 	if len(rows) == 0 {
@@ -38,12 +156,12 @@ func Table(headers []string, rows [][]string) {
 	// Calculate column widths
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = utf8.RuneCountInString(h)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
 			}
 		}
 	}
@@ -73,10 +191,11 @@ func Table(headers []string, rows [][]string) {
 }
 
 func pad(s string, width int) string {
-	if len(s) >= width {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
 		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return s + strings.Repeat(" ", width-n)
 }
 
 // StatusColor returns a colored status string.
@@ -5,6 +5,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
 )
 
 func TestJSON(t *testing.T) {
@@ -64,6 +67,30 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableMultibyteAlignment(t *testing.T) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	oldNoColor := color.NoColor
+	color.NoColor = true
+
+	Table([]string{"Repo", "overview"}, [][]string{{"a", "✓"}, {"b", "—"}})
+
+	w.Close()
+	os.Stdout = old
+	color.NoColor = oldNoColor
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := utf8.RuneCountInString(lines[0])
+	for _, line := range lines[1:] {
+		if got := utf8.RuneCountInString(line); got != want {
+			t.Errorf("line %q has width %d, want %d", line, got, want)
+		}
+	}
+}
+
 func TestTableEmpty(t *testing.T) {
 	old := os.Stdout
 	r, w, _ := os.Pipe()
@@ -94,6 +121,18 @@ func TestHumanFormatterFinishShowsAgentHint(t *testing.T) {
 	}
 }
 
+func TestHumanFormatterNoIcons(t *testing.T) {
+	f := &HumanFormatter{w: os.Stdout}
+	if got := f.SectionIcon("hl_overview"); got != "📋 " {
+		t.Errorf("SectionIcon = %q, want icon", got)
+	}
+	NoIcons = true
+	defer func() { NoIcons = false }()
+	if got := f.SectionIcon("hl_overview"); got != "" {
+		t.Errorf("SectionIcon with NoIcons = %q, want empty", got)
+	}
+}
+
 func TestAgentFormatterFinishIsEmpty(t *testing.T) {
 	var buf bytes.Buffer
 	f := &AgentFormatter{w: &buf}
@@ -118,3 +157,87 @@ func TestForAgentFlagSuppressesHint(t *testing.T) {
 	// Restore
 	InitFormatter(true)
 }
+
+func TestQuietSuppressesDecoration(t *testing.T) {
+	Quiet = true
+	defer func() { Quiet = false }()
+
+	var buf bytes.Buffer
+	f := &AgentFormatter{w: &buf}
+	f.Section("Title")
+	f.Println()
+	f.KeyValue("key", "value")
+	if got := buf.String(); strings.TrimSpace(got) != "key:                 value" {
+		t.Errorf("quiet agent output = %q, want only the key/value line", got)
+	}
+
+	buf.Reset()
+	h := &HumanFormatter{w: &buf}
+	h.Println()
+	h.Finish()
+	if buf.Len() != 0 {
+		t.Errorf("quiet human Println()/Finish() should be silent, got: %q", buf.String())
+	}
+}
+
+func TestJSONL(t *testing.T) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	JSONL([]map[string]int{{"n": 1}, {"n": 2}})
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	if got, want := buf.String(), "{\"n\":1}\n{\"n\":2}\n"; got != want {
+		t.Errorf("JSONL output = %q, want %q", got, want)
+	}
+}
+
+func TestJSONFieldsProjection(t *testing.T) {
+	capture := func(fn func()) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		fn()
+		w.Close()
+		os.Stdout = old
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		return buf.String()
+	}
+
+	Fields = []string{"name", "enabled", "missing"}
+	defer func() { Fields = nil }()
+
+	type repo struct {
+		Name    string `json:"name"`
+		URL     string `json:"url"`
+		Enabled bool   `json:"enabled"`
+		Size    int64  `json:"size"`
+	}
+	repos := []repo{{"a", "https://x/a", true, 9007199254740993}, {"b", "https://x/b", false, 1}}
+
+	if got, want := capture(func() { JSONL(repos) }), "{\"enabled\":true,\"name\":\"a\"}\n{\"enabled\":false,\"name\":\"b\"}\n"; got != want {
+		t.Errorf("JSONL with fields = %q, want %q", got, want)
+	}
+
+	Fields = []string{"size"}
+	out := capture(func() { JSON(repos[0]) })
+	if !strings.Contains(out, "9007199254740993") || strings.Contains(out, "url") {
+		t.Errorf("JSON with fields = %s", out)
+	}
+}
+
+func TestJSONCompact(t *testing.T) {
+	Compact = true
+	defer func() { Compact = false }()
+
+	out := captureStdout(t, func() { JSON(map[string]any{"a": 1, "b": []int{1, 2}}) })
+	if want := "{\"a\":1,\"b\":[1,2]}\n"; out != want {
+		t.Errorf("compact JSON = %q, want %q", out, want)
+	}
+}
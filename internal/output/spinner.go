@@ -9,29 +9,55 @@ import (
 // Spinner shows an animated spinner on a single line for human mode.
 // In agent mode it's a no-op.
 type Spinner struct {
-	msg    string
-	done   chan struct{}
-	once   sync.Once
-	frames []string
+	mu      sync.Mutex
+	msg     string
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+	frames  []string
+	silent  bool
 }
 
 // NewSpinner creates and starts a spinner with the given message.
 func NewSpinner(msg string) *Spinner {
 	s := &Spinner{
-		msg:    msg,
-		done:   make(chan struct{}),
-		frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		msg:     msg,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		frames:  []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
 	}
 	if !IsHuman {
 		// Agent mode — just print once
 		fmt.Printf("%s\n", msg)
+		close(s.stopped)
 		return s
 	}
 	go s.run()
 	return s
 }
 
+// NewStatus starts a transient status spinner for long-running commands.
+// Unlike NewSpinner it prints nothing at all unless enabled (callers pass
+// whether stdout is an interactive human terminal), and Stop erases it
+// without a trace so it never ends up in captured output.
+func NewStatus(msg string, enabled bool) *Spinner {
+	if enabled && IsHuman {
+		return NewSpinner(msg)
+	}
+	s := &Spinner{msg: msg, done: make(chan struct{}), stopped: make(chan struct{}), silent: true}
+	close(s.stopped)
+	return s
+}
+
+// Update replaces the spinner message, e.g. "Searching repo 12/40...".
+func (s *Spinner) Update(msg string) {
+	s.mu.Lock()
+	s.msg = msg
+	s.mu.Unlock()
+}
+
 func (s *Spinner) run() {
+	defer close(s.stopped)
 	i := 0
 	ticker := time.NewTicker(80 * time.Millisecond)
 	defer ticker.Stop()
@@ -40,7 +66,10 @@ func (s *Spinner) run() {
 		case <-s.done:
 			return
 		case <-ticker.C:
-			fmt.Printf("\r  %s %s  ", Cyan(s.frames[i%len(s.frames)]), s.msg)
+			s.mu.Lock()
+			msg := s.msg
+			s.mu.Unlock()
+			fmt.Printf("\r\033[K  %s %s", Cyan(s.frames[i%len(s.frames)]), msg)
 			i++
 		}
 	}
@@ -50,7 +79,8 @@ func (s *Spinner) run() {
 func (s *Spinner) Stop() {
 	s.once.Do(func() {
 		close(s.done)
-		if IsHuman {
+		<-s.stopped // no frame may land after the line is cleared
+		if IsHuman && !s.silent {
 			fmt.Print("\r\033[K") // clear line
 		}
 	})
@@ -60,6 +90,7 @@ func (s *Spinner) Stop() {
 func (s *Spinner) StopWith(icon, msg string) {
 	s.once.Do(func() {
 		close(s.done)
+		<-s.stopped
 		if IsHuman {
 			fmt.Printf("\r\033[K  %s %s\n", icon, msg)
 		} else {
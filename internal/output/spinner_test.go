@@ -0,0 +1,54 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String()
+}
+
+func TestStatusDisabledPrintsNothing(t *testing.T) {
+	InitFormatter(true)
+	defer InitFormatter(false)
+
+	out := captureStdout(t, func() {
+		s := NewStatus("Searching...", false)
+		s.Update("Searching repo 1/2...")
+		s.Stop()
+	})
+	if out != "" {
+		t.Errorf("disabled status printed %q", out)
+	}
+}
+
+func TestStatusUpdateAndErase(t *testing.T) {
+	InitFormatter(true)
+	defer InitFormatter(false)
+
+	out := captureStdout(t, func() {
+		s := NewStatus("Searching...", true)
+		s.Update("Searching repo 12/40...")
+		time.Sleep(200 * time.Millisecond)
+		s.Stop()
+	})
+	if !strings.Contains(out, "Searching repo 12/40...") {
+		t.Errorf("status should show the updated message, got %q", out)
+	}
+	if !strings.HasSuffix(out, "\r\033[K") {
+		t.Errorf("status should end by clearing its line, got %q", out)
+	}
+}